@@ -0,0 +1,46 @@
+package kway
+
+import (
+	"iter"
+)
+
+// WithYieldBatching enables buffering of the merge output, such that up to
+// `n` elements are merged, in a tight loop, before being yielded to the
+// caller, in turn, which may reduce the overhead of alternating between the
+// merge and the caller, for cheap comparison functions. A value of 0 or 1
+// disables batching. The API is unchanged.
+//
+// If iteration stops early, up to n-1 elements, which were merged, and
+// counted as yielded by [WithStats] and [WithProgress], are discarded.
+func WithYieldBatching(n int) Option {
+	if n < 0 {
+		panic("kway: negative yield batch size")
+	}
+	return func(o *options) {
+		o.yieldBatch = n
+	}
+}
+
+// batchYield returns seq, accumulating batches of n elements, before
+// yielding them.
+func batchYield[E any](seq iter.Seq[E], n int) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		buf := make([]E, 0, n)
+		flush := func() bool {
+			for _, v := range buf {
+				if !yield(v) {
+					return false
+				}
+			}
+			clear(buf)
+			buf = buf[:0]
+			return true
+		}
+		for v := range seq {
+			if buf = append(buf, v); len(buf) == n && !flush() {
+				return
+			}
+		}
+		flush()
+	}
+}
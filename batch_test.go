@@ -0,0 +1,108 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestWithYieldBatching(t *testing.T) {
+	inputs := [][]int{{1, 4, 7, 10}, {2, 5, 8}, {3, 6, 9}}
+	expected := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	for _, n := range []int{0, 1, 2, 3, 10, 100} {
+		var seqs []iter.Seq[int]
+		var seqs2 []iter.Seq2[int, int]
+		for _, s := range inputs {
+			seqs = append(seqs, slices.Values(s))
+			seqs2 = append(seqs2, sliceSeq2(s, s))
+		}
+		m := NewMerger(cmp.Compare[int], WithYieldBatching(n))
+		if result := collectSeq(m.Merge(seqs...)); !slices.Equal(result, expected) {
+			t.Errorf("n=%d: Expected %v, got %v", n, expected, result)
+		}
+		var cursors []Cursor[int]
+		for _, s := range inputs {
+			cursors = append(cursors, NewSortedSlice(cmp.Compare[int], s).Cursor())
+		}
+		if result := collectSeq(m.MergeCursors(cursors...)); !slices.Equal(result, expected) {
+			t.Errorf("n=%d: MergeCursors: Expected %v, got %v", n, expected, result)
+		}
+		var result2 []int
+		for k := range NewMerger2(func(a1, _, b1, _ int) int { return cmp.Compare(a1, b1) }, WithYieldBatching(n)).Merge(seqs2...) {
+			result2 = append(result2, k)
+		}
+		if !slices.Equal(result2, expected) {
+			t.Errorf("n=%d: Merger2: Expected %v, got %v", n, expected, result2)
+		}
+	}
+}
+
+func TestWithYieldBatching_earlyExit(t *testing.T) {
+	var stats Stats
+	m := NewMerger(cmp.Compare[int], WithYieldBatching(4), WithStats(&stats))
+	var result []int
+	for v := range m.Merge(slices.Values([]int{1, 3, 5, 7, 9}), slices.Values([]int{2, 4, 6, 8})) {
+		result = append(result, v)
+		if len(result) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(result, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", result)
+	}
+	if stats.Yielded != 4 {
+		t.Errorf("Expected 4 elements merged, got %d", stats.Yielded)
+	}
+}
+
+func TestWithYieldBatching_checked(t *testing.T) {
+	m := NewMerger(cmp.Compare[int], WithYieldBatching(8))
+	var (
+		result []int
+		err    error
+	)
+	for v, e := range m.MergeChecked(slices.Values([]int{1, 3, 2}), slices.Values([]int{2})) {
+		if e != nil {
+			err = e
+			continue
+		}
+		result = append(result, v)
+	}
+	if _, ok := err.(*OrderError); !ok || !slices.Equal(result, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3] and an *OrderError, got %v, %v", result, err)
+	}
+}
+
+func TestWithYieldBatching_negative(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "kway: negative yield batch size" {
+			t.Errorf("Expected panic, got %v", r)
+		}
+	}()
+	WithYieldBatching(-1)
+}
+
+func BenchmarkWithYieldBatching(b *testing.B) {
+	inputs := make([][]int, 8)
+	for i := range inputs {
+		inputs[i] = make([]int, 10_000)
+		for j := range inputs[i] {
+			inputs[i][j] = j*len(inputs) + i
+		}
+	}
+	for _, n := range []int{0, 64} {
+		m := NewMerger(cmp.Compare[int], WithYieldBatching(n))
+		b.Run("n="+strconv.Itoa(n), func(b *testing.B) {
+			for b.Loop() {
+				cursors := make([]Cursor[int], len(inputs))
+				for i, s := range inputs {
+					cursors[i] = NewSortedSlice(cmp.Compare[int], s).Cursor()
+				}
+				for range m.MergeCursors(cursors...) {
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Package bench measures the performance of k-way merges, using
+// [github.com/joeycumines/go-kway], to help determine whether the comparison
+// function, the input sequences, or the merge itself, is the bottleneck.
+package bench
+
+import (
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/joeycumines/go-kway"
+)
+
+// Config configures [Run].
+type Config[T any] struct {
+	// Compare is the comparison function, see [kway.Merge].
+	Compare func(a, b T) int
+	// Sources are the sorted input sequences, which must support being
+	// iterated multiple times, e.g. see [Ints].
+	Sources []iter.Seq[T]
+	// Options are passed to [kway.NewMerger].
+	Options []kway.Option
+	// Rounds is the number of times each measurement is repeated, with the
+	// fastest result reported. Defaults to 1.
+	Rounds int
+	// Samples is the maximum number of elements retained to measure the
+	// comparison function. Defaults to 1024.
+	Samples int
+}
+
+// Report is the result of [Run].
+type Report struct {
+	// Sources is the number of input sequences.
+	Sources int
+	// Elements is the total number of elements across all input sequences.
+	Elements int64
+	// Comparisons is the number of comparisons performed by the merge.
+	Comparisons int64
+	// CompareCost is the mean duration of a single comparison.
+	CompareCost time.Duration
+	// PullLatency is the mean duration to pull a single element from an
+	// input sequence, iterated directly.
+	PullLatency time.Duration
+	// MergeDuration is the duration of the merge.
+	MergeDuration time.Duration
+}
+
+// Throughput returns the number of elements merged per second.
+func (x Report) Throughput() float64 {
+	if x.MergeDuration <= 0 {
+		return 0
+	}
+	return float64(x.Elements) / x.MergeDuration.Seconds()
+}
+
+// CompareShare returns the estimated fraction of the merge duration spent in
+// the comparison function.
+func (x Report) CompareShare() float64 {
+	return x.share(time.Duration(x.Comparisons) * x.CompareCost)
+}
+
+// PullShare returns the estimated fraction of the merge duration spent
+// pulling elements from the input sequences.
+func (x Report) PullShare() float64 {
+	return x.share(time.Duration(x.Elements) * x.PullLatency)
+}
+
+func (x Report) share(d time.Duration) float64 {
+	if x.MergeDuration <= 0 {
+		return 0
+	}
+	return min(1, d.Seconds()/x.MergeDuration.Seconds())
+}
+
+// Bottleneck returns the dominant cost of the merge: "compare", "pull", or
+// "merge" (the overhead of the engine itself).
+func (x Report) Bottleneck() string {
+	c, p := x.CompareShare(), x.PullShare()
+	switch e := 1 - c - p; {
+	case c >= p && c >= e:
+		return "compare"
+	case p >= e:
+		return "pull"
+	}
+	return "merge"
+}
+
+func (x Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "sources:      %d\n", x.Sources)
+	fmt.Fprintf(&b, "elements:     %d\n", x.Elements)
+	fmt.Fprintf(&b, "comparisons:  %d\n", x.Comparisons)
+	fmt.Fprintf(&b, "compare cost: %v (%.1f%%)\n", x.CompareCost, 100*x.CompareShare())
+	fmt.Fprintf(&b, "pull latency: %v (%.1f%%)\n", x.PullLatency, 100*x.PullShare())
+	fmt.Fprintf(&b, "merge:        %v (%.0f elements/s)\n", x.MergeDuration, x.Throughput())
+	fmt.Fprintf(&b, "bottleneck:   %s\n", x.Bottleneck())
+	return b.String()
+}
+
+// Run measures the comparison function, the input sequences, and merges of
+// the input sequences, per the config.
+func Run[T any](cfg Config[T]) Report {
+	if cfg.Compare == nil {
+		panic("bench: nil comparison function")
+	}
+	rounds := max(cfg.Rounds, 1)
+	samples := cfg.Samples
+	if samples <= 0 {
+		samples = 1024
+	}
+
+	report := Report{Sources: len(cfg.Sources)}
+
+	// pull latency, collecting samples for the comparison function
+	var sample []T
+	for round := range rounds {
+		var n int64
+		start := time.Now()
+		for _, seq := range cfg.Sources {
+			if seq == nil {
+				continue
+			}
+			for v := range seq {
+				if round == 0 && len(sample) < samples {
+					sample = append(sample, v)
+				}
+				n++
+			}
+		}
+		elapsed := time.Since(start)
+		report.Elements = n
+		if n != 0 {
+			if d := elapsed / time.Duration(n); round == 0 || d < report.PullLatency {
+				report.PullLatency = d
+			}
+		}
+	}
+
+	// comparison cost, over a shuffled sample, to avoid only comparing
+	// neighbors
+	if len(sample) >= 2 {
+		rand.New(rand.NewPCG(1, 2)).Shuffle(len(sample), func(i, j int) {
+			sample[i], sample[j] = sample[j], sample[i]
+		})
+		const minComparisons = 1 << 16
+		iterations := max(1, minComparisons/len(sample))
+		for round := range rounds {
+			var sink int
+			start := time.Now()
+			for range iterations {
+				for i := 1; i < len(sample); i++ {
+					sink += cfg.Compare(sample[i-1], sample[i])
+				}
+			}
+			elapsed := time.Since(start)
+			_ = sink
+			if d := elapsed / time.Duration(iterations*(len(sample)-1)); round == 0 || d < report.CompareCost {
+				report.CompareCost = d
+			}
+		}
+	}
+
+	// merge throughput
+	var stats kway.Stats
+	m := kway.NewMerger(cfg.Compare, append(slices.Clip(cfg.Options), kway.WithStats(&stats))...)
+	for round := range rounds {
+		start := time.Now()
+		for range m.Merge(cfg.Sources...) {
+		}
+		elapsed := time.Since(start)
+		if round == 0 || elapsed < report.MergeDuration {
+			report.MergeDuration = elapsed
+			report.Comparisons = stats.Comparisons
+		}
+	}
+
+	return report
+}
+
+// Shape describes the distribution of synthetic data, see [Ints].
+type Shape int
+
+const (
+	// Interleaved sources each contain every kth value, e.g. 0, k, 2k...
+	Interleaved Shape = iota
+	// Disjoint sources each contain a contiguous, non-overlapping range.
+	Disjoint
+	// Duplicates sources all contain the same values.
+	Duplicates
+	// Random sources contain uniformly distributed values, from a fixed seed.
+	Random
+)
+
+// Ints returns `k` sorted input sequences, each of `n` elements, distributed
+// according to `shape`. The sequences may be iterated multiple times.
+func Ints(k, n int, shape Shape) []iter.Seq[int] {
+	rng := rand.New(rand.NewPCG(uint64(k), uint64(n)))
+	seqs := make([]iter.Seq[int], k)
+	for i := range seqs {
+		values := make([]int, n)
+		for j := range values {
+			switch shape {
+			case Interleaved:
+				values[j] = j*k + i
+			case Disjoint:
+				values[j] = i*n + j
+			case Duplicates:
+				values[j] = j
+			case Random:
+				values[j] = rng.IntN(k * n)
+			default:
+				panic(fmt.Sprintf("bench: unknown shape %d", shape))
+			}
+		}
+		slices.Sort(values)
+		seqs[i] = slices.Values(values)
+	}
+	return seqs
+}
@@ -0,0 +1,124 @@
+package bench
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joeycumines/go-kway"
+)
+
+func TestInts(t *testing.T) {
+	for _, shape := range []Shape{Interleaved, Disjoint, Duplicates, Random} {
+		seqs := Ints(3, 10, shape)
+		if len(seqs) != 3 {
+			t.Fatalf("Expected 3 sequences, got %d", len(seqs))
+		}
+		for i, seq := range seqs {
+			values := slices.Collect(seq)
+			if len(values) != 10 || !slices.IsSorted(values) {
+				t.Errorf("Shape %d, sequence %d: expected 10 sorted values, got %v", shape, i, values)
+			}
+			if again := slices.Collect(seq); !slices.Equal(values, again) {
+				t.Errorf("Shape %d, sequence %d: expected repeatable iteration", shape, i)
+			}
+		}
+	}
+
+	if v := slices.Collect(Ints(3, 2, Interleaved)[1]); !slices.Equal(v, []int{1, 4}) {
+		t.Errorf("Unexpected interleaved values: %v", v)
+	}
+	if v := slices.Collect(Ints(3, 2, Disjoint)[1]); !slices.Equal(v, []int{2, 3}) {
+		t.Errorf("Unexpected disjoint values: %v", v)
+	}
+}
+
+func TestInts_UnknownShape(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for unknown shape")
+		}
+	}()
+	Ints(1, 1, Shape(99))
+}
+
+func TestRun(t *testing.T) {
+	var calls int
+	report := Run(Config[int]{
+		Compare: func(a, b int) int {
+			calls++
+			return cmp.Compare(a, b)
+		},
+		Sources: Ints(4, 100, Interleaved),
+		Options: []kway.Option{kway.WithVerifySorted()},
+		Rounds:  2,
+		Samples: 64,
+	})
+
+	if report.Sources != 4 || report.Elements != 400 {
+		t.Errorf("Unexpected counts: %+v", report)
+	}
+	if report.Comparisons == 0 || calls == 0 {
+		t.Errorf("Expected comparisons, got %+v", report)
+	}
+	if report.MergeDuration <= 0 || report.Throughput() <= 0 {
+		t.Errorf("Expected positive merge duration, got %+v", report)
+	}
+	if s := report.String(); !strings.Contains(s, "elements:     400\n") || !strings.Contains(s, "bottleneck:") {
+		t.Errorf("Unexpected report:\n%s", s)
+	}
+}
+
+func TestRun_Empty(t *testing.T) {
+	report := Run(Config[int]{Compare: cmp.Compare[int], Sources: []iter.Seq[int]{nil}})
+	if report.Elements != 0 || report.CompareCost != 0 || report.PullLatency != 0 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+}
+
+func TestRun_NilCompareFunction(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil comparison function")
+		}
+	}()
+	Run(Config[int]{})
+}
+
+func TestReport_Bottleneck(t *testing.T) {
+	tests := []struct {
+		name     string
+		report   Report
+		expected string
+	}{
+		{
+			name:     "compare",
+			report:   Report{Elements: 10, Comparisons: 100, CompareCost: 90 * time.Millisecond, PullLatency: time.Millisecond, MergeDuration: 10 * time.Second},
+			expected: "compare",
+		},
+		{
+			name:     "pull",
+			report:   Report{Elements: 10, Comparisons: 10, CompareCost: time.Millisecond, PullLatency: 900 * time.Millisecond, MergeDuration: 10 * time.Second},
+			expected: "pull",
+		},
+		{
+			name:     "merge",
+			report:   Report{Elements: 10, Comparisons: 10, CompareCost: time.Millisecond, PullLatency: time.Millisecond, MergeDuration: 10 * time.Second},
+			expected: "merge",
+		},
+		{
+			name:     "zero",
+			expected: "merge",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if v := tt.report.Bottleneck(); v != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, v)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package bench
+
+import (
+	"iter"
+	"math/rand/v2"
+	"slices"
+)
+
+// Distribution is the distribution of values generated by [Generator].
+type Distribution int
+
+const (
+	// Uniform values are distributed uniformly across the range.
+	Uniform Distribution = iota
+	// Zipfian values are distributed per Zipf's law, such that small values
+	// are far more frequent than large ones, e.g. like the popularity of
+	// keys in many real data sets.
+	Zipfian
+)
+
+// Generator generates reproducible sorted input sequences, for benchmarking
+// merges and comparison functions against realistic data. The same
+// configuration always generates the same sequences.
+type Generator struct {
+	// Seed seeds the random number generator.
+	Seed uint64
+	// Sources is the number of input sequences.
+	Sources int
+	// Length is the number of elements of each input sequence.
+	Length int
+	// Range is the exclusive upper bound of the generated values, which are
+	// non-negative. Defaults to Sources*Length.
+	Range int
+	// Distribution is the distribution of the generated values.
+	Distribution Distribution
+	// Skew is the exponent of the Zipfian distribution, which must be
+	// greater than 1. Larger values concentrate the distribution on smaller
+	// values. Defaults to 1.1.
+	Skew float64
+	// DuplicateRate is the probability, in [0, 1], that each element is a
+	// duplicate of a previously generated element, of any input sequence,
+	// in addition to any duplicates arising from the distribution.
+	DuplicateRate float64
+}
+
+// Slices returns the generated input sequences, as sorted slices.
+func (g Generator) Slices() [][]int {
+	if g.Sources < 0 || g.Length < 0 {
+		panic("bench: negative generator size")
+	}
+	if g.DuplicateRate < 0 || g.DuplicateRate > 1 {
+		panic("bench: duplicate rate out of range")
+	}
+	values := g.Range
+	if values <= 0 {
+		values = max(1, g.Sources*g.Length)
+	}
+
+	rng := rand.New(rand.NewPCG(g.Seed, uint64(g.Distribution)))
+	var draw func() int
+	switch g.Distribution {
+	case Uniform:
+		draw = func() int { return rng.IntN(values) }
+	case Zipfian:
+		skew := g.Skew
+		if skew == 0 {
+			skew = 1.1
+		}
+		if !(skew > 1) {
+			panic("bench: zipfian skew must be greater than 1")
+		}
+		zipf := rand.NewZipf(rng, skew, 1, uint64(values-1))
+		draw = func() int { return int(zipf.Uint64()) }
+	default:
+		panic("bench: unknown distribution")
+	}
+
+	var generated []int
+	inputs := make([][]int, g.Sources)
+	for i := range inputs {
+		s := make([]int, g.Length)
+		for j := range s {
+			if len(generated) != 0 && rng.Float64() < g.DuplicateRate {
+				s[j] = generated[rng.IntN(len(generated))]
+			} else {
+				s[j] = draw()
+			}
+			generated = append(generated, s[j])
+		}
+		slices.Sort(s)
+		inputs[i] = s
+	}
+	return inputs
+}
+
+// Ints returns the generated input sequences, which may be iterated multiple
+// times, e.g. for [Config.Sources].
+func (g Generator) Ints() []iter.Seq[int] {
+	inputs := g.Slices()
+	seqs := make([]iter.Seq[int], len(inputs))
+	for i, s := range inputs {
+		seqs[i] = slices.Values(s)
+	}
+	return seqs
+}
@@ -0,0 +1,108 @@
+package bench
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/joeycumines/go-kway"
+)
+
+func TestGenerator(t *testing.T) {
+	tests := []struct {
+		name string
+		g    Generator
+	}{
+		{name: "uniform", g: Generator{Seed: 1, Sources: 4, Length: 100}},
+		{name: "uniform range", g: Generator{Seed: 1, Sources: 4, Length: 100, Range: 10}},
+		{name: "zipfian", g: Generator{Seed: 1, Sources: 4, Length: 100, Distribution: Zipfian}},
+		{name: "zipfian skew", g: Generator{Seed: 1, Sources: 4, Length: 100, Distribution: Zipfian, Skew: 3}},
+		{name: "duplicates", g: Generator{Seed: 1, Sources: 4, Length: 100, DuplicateRate: 0.5}},
+		{name: "empty", g: Generator{Seed: 1, Sources: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputs := tt.g.Slices()
+			if len(inputs) != tt.g.Sources {
+				t.Fatalf("Expected %d inputs, got %d", tt.g.Sources, len(inputs))
+			}
+			limit := tt.g.Range
+			if limit == 0 {
+				limit = tt.g.Sources * tt.g.Length
+			}
+			for i, input := range inputs {
+				if len(input) != tt.g.Length || !slices.IsSorted(input) {
+					t.Errorf("Input %d: expected %d sorted values, got %v", i, tt.g.Length, input)
+				}
+				for _, v := range input {
+					if v < 0 || v >= limit {
+						t.Errorf("Input %d: value %d out of range", i, v)
+					}
+				}
+			}
+			if again := tt.g.Slices(); !slices.EqualFunc(inputs, again, slices.Equal) {
+				t.Error("Expected reproducible inputs")
+			}
+		})
+	}
+}
+
+func TestGenerator_distinct(t *testing.T) {
+	distinct := func(g Generator) int {
+		seen := make(map[int]bool)
+		for _, input := range g.Slices() {
+			for _, v := range input {
+				seen[v] = true
+			}
+		}
+		return len(seen)
+	}
+	uniform := distinct(Generator{Seed: 2, Sources: 4, Length: 1000})
+	duplicates := distinct(Generator{Seed: 2, Sources: 4, Length: 1000, DuplicateRate: 0.9})
+	zipfian := distinct(Generator{Seed: 2, Sources: 4, Length: 1000, Distribution: Zipfian})
+	if duplicates >= uniform/2 || zipfian >= uniform/2 {
+		t.Errorf("Expected fewer distinct values, got uniform %d, duplicates %d, zipfian %d", uniform, duplicates, zipfian)
+	}
+	if a, b := (Generator{Seed: 1, Sources: 1, Length: 10}).Slices(), (Generator{Seed: 2, Sources: 1, Length: 10}).Slices(); slices.Equal(a[0], b[0]) {
+		t.Errorf("Expected different seeds to generate different inputs, got %v", a)
+	}
+}
+
+func TestGenerator_panics(t *testing.T) {
+	for _, g := range []Generator{
+		{Sources: -1},
+		{Length: -1},
+		{DuplicateRate: 2},
+		{Sources: 1, Length: 1, Distribution: Zipfian, Skew: 1},
+		{Sources: 1, Length: 1, Distribution: Distribution(99)},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected panic for %+v", g)
+				}
+			}()
+			g.Slices()
+		}()
+	}
+}
+
+func BenchmarkMerge(b *testing.B) {
+	for _, tt := range []struct {
+		name string
+		g    Generator
+	}{
+		{name: "uniform", g: Generator{Seed: 1, Sources: 8, Length: 1000}},
+		{name: "zipfian", g: Generator{Seed: 1, Sources: 8, Length: 1000, Distribution: Zipfian}},
+		{name: "duplicates", g: Generator{Seed: 1, Sources: 8, Length: 1000, DuplicateRate: 0.5}},
+	} {
+		seqs := tt.g.Ints()
+		b.Run(tt.name, func(b *testing.B) {
+			for b.Loop() {
+				for range kway.Merge(cmp.Compare[int], seqs...) {
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package kway
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"sort"
+)
+
+// Block is an entry of the index of a [BlockSource], locating a block of
+// sorted elements, by the offset of its first byte, and its first element.
+type Block[T any] struct {
+	Offset int64
+	First  T
+}
+
+// BlockSource is a sorted input sequence, stored as consecutive blocks of an
+// [io.ReaderAt], such as a file, or an object in object storage, read on
+// demand, during iteration, one block at a time, such that huge inputs may
+// be merged without loading them fully. It is safe for concurrent use,
+// provided the reader is.
+//
+// Errors reading, or decoding, blocks are yielded, and stop the iteration,
+// so the sequences may be merged using [MergeValuesErr].
+type BlockSource[T any] struct {
+	cmp    func(a, b T) int
+	r      io.ReaderAt
+	size   int64
+	index  []Block[T]
+	decode func(block []byte) ([]T, error)
+}
+
+// NewBlockSource returns a [BlockSource] reading the `size` bytes of `r`,
+// consisting of the blocks of `index`, each extending to the offset of the
+// next, or to `size`, and decoded by `decode`, which must not retain the
+// block. The blocks must be in order, and their elements sorted, according
+// to `cmp`.
+func NewBlockSource[T any](cmp func(a, b T) int, r io.ReaderAt, size int64, index []Block[T], decode func(block []byte) ([]T, error)) *BlockSource[T] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	if r == nil {
+		panic("kway: nil reader")
+	}
+	if decode == nil {
+		panic("kway: nil decode function")
+	}
+	for i, b := range index {
+		if b.Offset < 0 || b.Offset > size || (i != 0 && b.Offset < index[i-1].Offset) {
+			panic("kway: invalid block index")
+		}
+	}
+	return &BlockSource[T]{cmp: cmp, r: r, size: size, index: index, decode: decode}
+}
+
+// All returns a sequence of all elements, or the first error.
+func (x *BlockSource[T]) All() iter.Seq2[T, error] {
+	return x.from(0, nil)
+}
+
+// Seek returns a sequence of the elements greater than or equal to key, or
+// the first error, starting from the block located by binary search of the
+// index.
+func (x *BlockSource[T]) Seek(key T) iter.Seq2[T, error] {
+	// the first block whose first element is not less than key, preceded by
+	// the block which may hold lesser and equal elements
+	i := sort.Search(len(x.index), func(i int) bool { return x.cmp(x.index[i].First, key) >= 0 })
+	return x.from(max(i-1, 0), &key)
+}
+
+// from returns the elements from the block i, skipping those less than key,
+// if not nil.
+func (x *BlockSource[T]) from(i int, key *T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var buf []byte
+		for ; i < len(x.index); i++ {
+			end := x.size
+			if i+1 < len(x.index) {
+				end = x.index[i+1].Offset
+			}
+			n := int(end - x.index[i].Offset)
+			if cap(buf) < n {
+				buf = make([]byte, n)
+			}
+			buf = buf[:n]
+			values, err := x.read(buf, i)
+			if err != nil {
+				yield(*new(T), err)
+				return
+			}
+			for _, v := range values {
+				if key != nil {
+					if x.cmp(v, *key) < 0 {
+						continue
+					}
+					key = nil
+				}
+				if !yield(v, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// read reads and decodes the block i into buf.
+func (x *BlockSource[T]) read(buf []byte, i int) ([]T, error) {
+	off := x.index[i].Offset
+	if n, err := x.r.ReadAt(buf, off); err != nil && !(errors.Is(err, io.EOF) && n == len(buf)) {
+		return nil, fmt.Errorf("kway: reading block %d at offset %d: %w", i, off, err)
+	}
+	values, err := x.decode(buf)
+	if err != nil {
+		return nil, fmt.Errorf("kway: decoding block %d at offset %d: %w", i, off, err)
+	}
+	return values, nil
+}
+
+// IntersectBlocks yields the elements present in every one of the provided
+// block sources, per [Intersect], using the index of each as a sparse index:
+// each source is advanced to the current candidate by binary search of its
+// index, reading only the block which may hold the candidate, such that
+// blocks of lesser elements are skipped without being read, or decoded. The
+// cost is therefore driven by the blocks holding matches, rather than the
+// sizes of the sources. Each candidate is taken from the source with the
+// fewest remaining blocks.
+//
+// Elements are yielded with a nil error. If reading, or decoding, a block
+// fails, the zero value is yielded with the error, and iteration stops.
+func IntersectBlocks[T any](cmp func(a, b T) int, srcs ...*BlockSource[T]) iter.Seq2[T, error] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	if len(srcs) == 0 {
+		return emptySeq2[T, error]
+	}
+	return func(yield func(T, error) bool) {
+		sources := make([]*blockIntersectSource[T], len(srcs))
+		isrcs := make([]intersectSource[T], len(srcs))
+		for i, x := range srcs {
+			sources[i] = &blockIntersectSource[T]{cmp: cmp, x: x}
+			isrcs[i] = sources[i]
+		}
+		stopped := false
+		intersect(cmp, isrcs, func(v T) bool {
+			stopped = !yield(v, nil)
+			return !stopped
+		})
+		if stopped {
+			return
+		}
+		for _, s := range sources {
+			if s.err != nil {
+				yield(*new(T), s.err)
+				return
+			}
+		}
+	}
+}
+
+// blockIntersectSource is an intersectSource that advances using the index
+// of a [BlockSource].
+type blockIntersectSource[T any] struct {
+	cmp    func(a, b T) int
+	x      *BlockSource[T]
+	block  int // the next block to read
+	values []T // the remaining elements of the last block read
+	buf    []byte
+	err    error
+}
+
+func (s *blockIntersectSource[T]) next() (T, bool) {
+	for len(s.values) == 0 {
+		if !s.load() {
+			return *new(T), false
+		}
+	}
+	v := s.values[0]
+	s.values = s.values[1:]
+	return v, true
+}
+
+func (s *blockIntersectSource[T]) seek(key T) (T, bool) {
+	if len(s.values) == 0 || s.cmp(s.values[len(s.values)-1], key) < 0 {
+		// skip to the block preceding the first whose first element is not
+		// less than key, which may hold lesser and equal elements
+		i := sort.Search(len(s.x.index), func(i int) bool { return s.cmp(s.x.index[i].First, key) >= 0 })
+		s.block, s.values = max(i-1, s.block), nil
+	}
+	for {
+		i := sort.Search(len(s.values), func(i int) bool { return s.cmp(s.values[i], key) >= 0 })
+		if s.values = s.values[i:]; len(s.values) != 0 {
+			return s.next()
+		}
+		if !s.load() {
+			return *new(T), false
+		}
+	}
+}
+
+func (s *blockIntersectSource[T]) remaining() int { return len(s.x.index) - s.block }
+
+// load reads the next block, returning false if there are none, or on error.
+func (s *blockIntersectSource[T]) load() bool {
+	if s.block >= len(s.x.index) || s.err != nil {
+		return false
+	}
+	end := s.x.size
+	if s.block+1 < len(s.x.index) {
+		end = s.x.index[s.block+1].Offset
+	}
+	n := int(end - s.x.index[s.block].Offset)
+	if cap(s.buf) < n {
+		s.buf = make([]byte, n)
+	}
+	s.buf = s.buf[:n]
+	s.values, s.err = s.x.read(s.buf, s.block)
+	s.block++
+	return s.err == nil
+}
@@ -0,0 +1,254 @@
+package kway
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// countingReaderAt records the number of bytes read from r.
+type countingReaderAt struct {
+	r     *bytes.Reader
+	reads int
+	bytes int
+	err   error
+}
+
+func (x *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if x.err != nil {
+		return 0, x.err
+	}
+	x.reads++
+	x.bytes += len(p)
+	return x.r.ReadAt(p, off)
+}
+
+// encodeBlocks encodes values as big-endian uint32s, in blocks of n,
+// returning the data and index.
+func encodeBlocks(values []uint32, n int) ([]byte, []Block[uint32]) {
+	var (
+		data  []byte
+		index []Block[uint32]
+	)
+	for i, v := range values {
+		if i%n == 0 {
+			index = append(index, Block[uint32]{Offset: int64(len(data)), First: v})
+		}
+		data = binary.BigEndian.AppendUint32(data, v)
+	}
+	return data, index
+}
+
+func decodeUint32s(block []byte) ([]uint32, error) {
+	if len(block)%4 != 0 {
+		return nil, errors.New("truncated")
+	}
+	values := make([]uint32, len(block)/4)
+	for i := range values {
+		values[i] = binary.BigEndian.Uint32(block[i*4:])
+	}
+	return values, nil
+}
+
+func collectBlocks(t *testing.T, seq iter.Seq2[uint32, error]) []uint32 {
+	t.Helper()
+	var result []uint32
+	for v, err := range seq {
+		if err != nil {
+			t.Fatal(err)
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+func TestBlockSource(t *testing.T) {
+	values := []uint32{1, 2, 2, 2, 2, 3, 5, 8, 13, 21}
+	data, index := encodeBlocks(values, 3)
+	r := &countingReaderAt{r: bytes.NewReader(data)}
+	x := NewBlockSource(cmp.Compare[uint32], r, int64(len(data)), index, decodeUint32s)
+
+	if result := collectBlocks(t, x.All()); !slices.Equal(result, values) {
+		t.Errorf("Expected %v, got %v", values, result)
+	}
+	for key := range uint32(23) {
+		r.reads = 0
+		i, _ := slices.BinarySearch(values, key)
+		if result := collectBlocks(t, x.Seek(key)); !slices.Equal(result, values[i:]) {
+			t.Errorf("Seek(%d): Expected %v, got %v", key, values[i:], result)
+		}
+		if blocks := (len(values) - i + 2) / 3; r.reads > blocks+1 {
+			t.Errorf("Seek(%d): Expected at most %d reads, got %d", key, blocks+1, r.reads)
+		}
+	}
+
+	// only the blocks needed are read
+	r.reads, r.bytes = 0, 0
+	for v := range x.Seek(8) {
+		if v != 8 {
+			t.Errorf("Expected 8, got %d", v)
+		}
+		break
+	}
+	if r.reads != 1 || r.bytes != 12 {
+		t.Errorf("Expected 1 read of 12 bytes, got %d of %d", r.reads, r.bytes)
+	}
+}
+
+func TestBlockSource_merge(t *testing.T) {
+	var seqs []iter.Seq2[uint32, error]
+	var expected []uint32
+	for i := range uint32(3) {
+		var values []uint32
+		for j := range uint32(50) {
+			values = append(values, j*3+i)
+		}
+		expected = append(expected, values...)
+		data, index := encodeBlocks(values, 7)
+		seqs = append(seqs, NewBlockSource(cmp.Compare[uint32], bytes.NewReader(data), int64(len(data)), index, decodeUint32s).All())
+	}
+	slices.Sort(expected)
+	if result := collectBlocks(t, MergeValuesErr(cmp.Compare[uint32], StopSource, seqs...)); !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestBlockSource_errors(t *testing.T) {
+	data, index := encodeBlocks([]uint32{1, 2, 3}, 2)
+	errRead := errors.New("read failed")
+	x := NewBlockSource(cmp.Compare[uint32], &countingReaderAt{r: bytes.NewReader(data), err: errRead}, int64(len(data)), index, decodeUint32s)
+	for _, err := range x.All() {
+		if !errors.Is(err, errRead) {
+			t.Errorf("Expected %v, got %v", errRead, err)
+		}
+	}
+
+	// a size beyond the data is a short read
+	x = NewBlockSource(cmp.Compare[uint32], bytes.NewReader(data), int64(len(data))+1, index, decodeUint32s)
+	var result []uint32
+	var err error
+	for v, e := range x.All() {
+		if e != nil {
+			err = e
+			break
+		}
+		result = append(result, v)
+	}
+	if err == nil || !slices.Equal(result, []uint32{1, 2}) {
+		t.Errorf("Expected [1 2] and an error, got %v, %v", result, err)
+	}
+
+	x = NewBlockSource(cmp.Compare[uint32], bytes.NewReader(data), int64(len(data))-1, index, decodeUint32s)
+	for _, e := range x.Seek(3) {
+		if e == nil || e.Error() != "kway: decoding block 1 at offset 8: truncated" {
+			t.Errorf("Expected decoding error, got %v", e)
+		}
+	}
+}
+
+func TestNewBlockSource_panics(t *testing.T) {
+	r := bytes.NewReader(nil)
+	for _, tt := range []struct {
+		name  string
+		f     func()
+		panic string
+	}{
+		{"nil cmp", func() { NewBlockSource(nil, r, 0, nil, decodeUint32s) }, "kway: nil comparison function"},
+		{"nil reader", func() { NewBlockSource(cmp.Compare[uint32], nil, 0, nil, decodeUint32s) }, "kway: nil reader"},
+		{"nil decode", func() { NewBlockSource(cmp.Compare[uint32], r, 0, nil, nil) }, "kway: nil decode function"},
+		{"offset beyond size", func() {
+			NewBlockSource(cmp.Compare[uint32], r, 4, []Block[uint32]{{Offset: 8}}, decodeUint32s)
+		}, "kway: invalid block index"},
+		{"offsets out of order", func() {
+			NewBlockSource(cmp.Compare[uint32], r, 8, []Block[uint32]{{Offset: 4}, {Offset: 0}}, decodeUint32s)
+		}, "kway: invalid block index"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tt.panic {
+					t.Errorf("Expected %q, got %v", tt.panic, r)
+				}
+			}()
+			tt.f()
+		})
+	}
+}
+
+func TestIntersectBlocks(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 50 {
+		var (
+			s    [][]uint32
+			srcs []*BlockSource[uint32]
+		)
+		for range 1 + r.IntN(4) {
+			values := make([]uint32, r.IntN(200))
+			for j := range values {
+				values[j] = uint32(r.IntN(300))
+			}
+			slices.Sort(values)
+			s = append(s, values)
+			data, index := encodeBlocks(values, 1+r.IntN(16))
+			srcs = append(srcs, NewBlockSource(cmp.Compare[uint32], bytes.NewReader(data), int64(len(data)), index, decodeUint32s))
+		}
+		expected := collectSeq(IntersectSlices(cmp.Compare[uint32], s...))
+		if result := collectBlocks(t, IntersectBlocks(cmp.Compare[uint32], srcs...)); !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	}
+	if result := collectBlocks(t, IntersectBlocks[uint32](cmp.Compare[uint32])); len(result) != 0 {
+		t.Errorf("Expected no elements, got %v", result)
+	}
+}
+
+func TestIntersectBlocks_skipping(t *testing.T) {
+	var large []uint32
+	for i := range uint32(10000) {
+		large = append(large, i)
+	}
+	data, index := encodeBlocks(large, 100)
+	lr := &countingReaderAt{r: bytes.NewReader(data)}
+	data, sindex := encodeBlocks([]uint32{5010, 5011, 9050, 20000}, 2)
+	sr := &countingReaderAt{r: bytes.NewReader(data)}
+	result := collectBlocks(t, IntersectBlocks(cmp.Compare[uint32],
+		NewBlockSource(cmp.Compare[uint32], lr, lr.r.Size(), index, decodeUint32s),
+		NewBlockSource(cmp.Compare[uint32], sr, sr.r.Size(), sindex, decodeUint32s),
+	))
+	if expected := []uint32{5010, 5011, 9050}; !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+	if lr.reads > 3 {
+		t.Errorf("Expected at most 3 blocks read, got %d", lr.reads)
+	}
+}
+
+func TestIntersectBlocks_errors(t *testing.T) {
+	data, index := encodeBlocks([]uint32{1, 2, 3, 4}, 2)
+	errRead := errors.New("read failed")
+	r := &countingReaderAt{r: bytes.NewReader(data)}
+	x := NewBlockSource(cmp.Compare[uint32], r, int64(len(data)), index, decodeUint32s)
+	var (
+		result []uint32
+		errs   []error
+	)
+	for v, err := range IntersectBlocks(cmp.Compare[uint32], x, x) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		result = append(result, v)
+		r.err = errRead
+	}
+	// the first block of each is read before the reader fails
+	if !slices.Equal(result, []uint32{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", result)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], errRead) {
+		t.Errorf("Expected [%v], got %v", errRead, errs)
+	}
+}
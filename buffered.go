@@ -0,0 +1,154 @@
+package kway
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// WithMaxBuffered caps the total number of elements held by each of the
+// Merger's merges, across all internal buffers, giving a hard bound on their
+// memory usage, excluding memory referenced by the elements. A value of 0
+// disables the limit.
+//
+// The engine holds the current element of each source, plus the previous
+// element of each, if [WithVerifySorted], and the previous element yielded,
+// for each of [WithUniqueKeys] and [WithMaxPerKey]. If those alone exceed the
+// limit, the merge fails with a [*BufferLimitError], reported like a
+// violation, e.g. as a panic from [Merger.Merge]. Features that buffer
+// further elements are degraded to fit within the remainder, in order: the
+// per-producer buffers of [Merger.FanIn], then the read-ahead of
+// [WithPrefetch], then the batches of [WithYieldBatching], each being
+// disabled if nothing remains.
+//
+// [Merger.MergePartitioned] merges fewer ranges concurrently, if necessary,
+// and fails with a [*BufferLimitError] if the ranges it buffers exceed the
+// remainder.
+func WithMaxBuffered(n int) Option {
+	if n < 0 {
+		panic("kway: negative max buffered elements")
+	}
+	return func(o *options) {
+		o.maxBuffered = n
+	}
+}
+
+// BufferLimitError is the error reported if a merge would hold more
+// elements than permitted by [WithMaxBuffered].
+type BufferLimitError struct {
+	// Limit is the configured maximum.
+	Limit int
+	// Required is the number of elements the merge required.
+	Required int
+}
+
+func (e *BufferLimitError) Error() string {
+	return fmt.Sprintf("kway: merge requires %d buffered elements, exceeding the limit of %d", e.Required, e.Limit)
+}
+
+// fixedBuffered returns the number of elements held by a merge of k
+// sources, which cannot be degraded.
+func (o *options) fixedBuffered(k int, verify bool) int {
+	n := k
+	if verify {
+		n += k
+	}
+	if o.uniqueKeys {
+		n++
+	}
+	if o.maxPerKey > 0 {
+		n++
+	}
+	return n
+}
+
+// bounded returns a copy of o, with its buffering options degraded for a
+// merge of k sources to fit within [WithMaxBuffered], along with perSource,
+// the number of elements buffered per source by the caller, degraded first.
+func (o *options) bounded(k int, verify bool, perSource int) (options, int, error) {
+	b := *o
+	if o.maxBuffered <= 0 {
+		return b, perSource, nil
+	}
+	fixed := o.fixedBuffered(k, verify)
+	if fixed > o.maxBuffered {
+		return b, 0, &BufferLimitError{Limit: o.maxBuffered, Required: fixed}
+	}
+	remaining := o.maxBuffered - fixed
+	if k > 0 {
+		perSource = min(perSource, remaining/k)
+		remaining -= k * perSource
+		if b.prefetch > 0 && prefetchCapacity(b.prefetch) > remaining/k {
+			// the largest capacity that fits, as a power of two
+			if n := remaining / k; n > 0 {
+				b.prefetch = 1 << (bits.Len(uint(n)) - 1)
+			} else {
+				b.prefetch = 0
+			}
+		}
+		if b.prefetch > 0 {
+			remaining -= k * prefetchCapacity(b.prefetch)
+		}
+	}
+	if b.yieldBatch > remaining {
+		b.yieldBatch = remaining
+	}
+	if b.yieldBatch < 2 {
+		b.yieldBatch = 0
+	}
+	return b, perSource, nil
+}
+
+// prefetchCapacity returns the number of elements buffered per source, for a
+// prefetch size of n, see [newSPSCRing].
+func prefetchCapacity(n int) int {
+	return 1 << bits.Len(uint(n-1))
+}
+
+// bounded returns m, or a copy of m, with its options bounded, per
+// [options.bounded].
+func (m *Merger[T]) bounded(k int, verify bool, perSource int) (*Merger[T], int, error) {
+	if m.opts.maxBuffered <= 0 {
+		return m, perSource, nil
+	}
+	o, perSource, err := m.opts.bounded(k, verify, perSource)
+	if err != nil {
+		return nil, 0, err
+	}
+	b := *m
+	b.opts = o
+	return &b, perSource, nil
+}
+
+// bounded is the [Merger2] equivalent of [Merger.bounded].
+func (m *Merger2[T1, T2]) bounded(k int) (*Merger2[T1, T2], error) {
+	if m.opts.maxBuffered <= 0 {
+		return m, nil
+	}
+	o, _, err := m.opts.bounded(k, m.opts.verifySorted, 0)
+	if err != nil {
+		return nil, err
+	}
+	b := *m
+	b.opts = o
+	return &b, nil
+}
+
+// countSeqs returns the number of non-nil sequences.
+func countSeqs[S ~func(Y), Y any](seqs []S) (n int) {
+	for _, seq := range seqs {
+		if seq != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// countSeekables returns the number of non-nil sources.
+func countSeekables[T any](srcs []Seekable[T]) (n int) {
+	for _, src := range srcs {
+		if src != nil {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,238 @@
+package kway
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestWithMaxBuffered_Negative(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "kway: negative max buffered elements" {
+			t.Errorf("Expected panic, got %v", r)
+		}
+	}()
+	WithMaxBuffered(-1)
+}
+
+func TestBufferLimitError(t *testing.T) {
+	err := &BufferLimitError{Limit: 10, Required: 12}
+	if actual, expected := err.Error(), "kway: merge requires 12 buffered elements, exceeding the limit of 10"; actual != expected {
+		t.Errorf("Expected %q, got %q", expected, actual)
+	}
+}
+
+func TestOptions_bounded(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		opts       []Option
+		k          int
+		perSource  int
+		prefetch   int
+		yieldBatch int
+		buffer     int
+		err        *BufferLimitError
+	}{
+		{"unlimited", []Option{WithPrefetch(1000), WithYieldBatching(1000)}, 10, 64, 1000, 1000, 64, nil},
+		{"fits", []Option{WithMaxBuffered(1000), WithPrefetch(16), WithYieldBatching(100)}, 10, 0, 16, 100, 0, nil},
+		{"prefetch", []Option{WithMaxBuffered(100), WithPrefetch(1000)}, 10, 0, 8, 0, 0, nil},
+		{"prefetch rounded", []Option{WithMaxBuffered(90), WithPrefetch(5)}, 10, 0, 5, 0, 0, nil},
+		{"prefetch degraded", []Option{WithMaxBuffered(89), WithPrefetch(5)}, 10, 0, 4, 0, 0, nil},
+		{"prefetch disabled", []Option{WithMaxBuffered(15), WithPrefetch(4)}, 10, 0, 0, 0, 0, nil},
+		{"batch", []Option{WithMaxBuffered(100), WithPrefetch(4), WithYieldBatching(100)}, 10, 0, 4, 50, 0, nil},
+		{"batch disabled", []Option{WithMaxBuffered(91), WithPrefetch(8), WithYieldBatching(100)}, 10, 0, 8, 0, 0, nil},
+		{"per source", []Option{WithMaxBuffered(100), WithPrefetch(4)}, 10, 64, 0, 0, 9, nil},
+		{"verify", []Option{WithMaxBuffered(25), WithVerifySorted(), WithUniqueKeys(), WithYieldBatching(10)}, 10, 0, 0, 4, 0, nil},
+		{"per key", []Option{WithMaxBuffered(13), WithMaxPerKey(2), WithUniqueKeys(), WithYieldBatching(10)}, 10, 0, 0, 0, 0, nil},
+		{"exceeded", []Option{WithMaxBuffered(20), WithVerifySorted(), WithUniqueKeys()}, 10, 0, 0, 0, 0, &BufferLimitError{Limit: 20, Required: 21}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			o := newOptions(tc.opts)
+			b, buffer, err := o.bounded(tc.k, o.verifySorted, tc.perSource)
+			if tc.err != nil {
+				var e *BufferLimitError
+				if !errors.As(err, &e) || *e != *tc.err {
+					t.Errorf("Expected %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if b.prefetch != tc.prefetch {
+				t.Errorf("Expected prefetch %d, got %d", tc.prefetch, b.prefetch)
+			}
+			if b.yieldBatch != tc.yieldBatch {
+				t.Errorf("Expected yield batch %d, got %d", tc.yieldBatch, b.yieldBatch)
+			}
+			if buffer != tc.buffer {
+				t.Errorf("Expected buffer %d, got %d", tc.buffer, buffer)
+			}
+		})
+	}
+}
+
+func TestMerger_MaxBuffered(t *testing.T) {
+	seqs := []iter.Seq[int]{
+		slices.Values([]int{1, 4, 7, 10}),
+		slices.Values([]int{2, 5, 8}),
+		nil,
+		slices.Values([]int{3, 6, 9}),
+	}
+	expected := collectSeq(Merge(cmp.Compare[int], seqs...))
+	for _, limit := range []int{3, 4, 8, 100} {
+		m := NewMerger(cmp.Compare[int], WithMaxBuffered(limit), WithPrefetch(16), WithYieldBatching(8))
+		if actual := collectSeq(m.Merge(seqs...)); !slices.Equal(actual, expected) {
+			t.Errorf("Limit %d: expected %v, got %v", limit, expected, actual)
+		}
+		if actual := collectSeq(m.MergeCursors(NewSortedSlice(cmp.Compare[int], expected).Cursor())); !slices.Equal(actual, expected) {
+			t.Errorf("Limit %d: expected %v, got %v", limit, expected, actual)
+		}
+	}
+}
+
+func TestMerger_MaxBuffered_Exceeded(t *testing.T) {
+	seqs := []iter.Seq[int]{slices.Values([]int{1, 3}), slices.Values([]int{2, 4}), slices.Values([]int{5})}
+	m := NewMerger(cmp.Compare[int], WithMaxBuffered(2))
+
+	check := func(name string, err any, required int) {
+		t.Helper()
+		if e, ok := err.(*BufferLimitError); !ok || *e != (BufferLimitError{Limit: 2, Required: required}) {
+			t.Errorf("%s: expected a limit error requiring %d, got %v", name, required, err)
+		}
+	}
+	recovered := func(f func()) (r any) {
+		defer func() { r = recover() }()
+		f()
+		return nil
+	}
+
+	check("Merge", recovered(func() {
+		for range m.Merge(seqs...) {
+			t.Error("Expected no elements")
+		}
+	}), 3)
+	check("MergeCursors", recovered(func() {
+		for range m.MergeCursors(NewSortedSlice(cmp.Compare[int], []int{1}).Cursor(), NewSortedSlice(cmp.Compare[int], []int{2}).Cursor(), NewSortedSlice(cmp.Compare[int], []int{3}).Cursor()) {
+			t.Error("Expected no elements")
+		}
+	}), 3)
+
+	var errs []error
+	for v, err := range m.MergeChecked(seqs[:2]...) {
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			t.Errorf("Expected no elements, got %d", v)
+		}
+	}
+	// verification doubles the elements held
+	if len(errs) != 1 {
+		t.Fatalf("Expected one error, got %v", errs)
+	}
+	check("MergeChecked", errs[0], 4)
+
+	n, err := m.MergeTo(io.Discard, func(w io.Writer, v int) error { return nil }, seqs...)
+	if n != 0 {
+		t.Errorf("Expected 0 bytes, got %d", n)
+	}
+	check("MergeTo", err, 3)
+
+	producer := func(ctx context.Context, emit func(int) error) error { return emit(1) }
+	seq, wait := m.FanIn(context.Background(), producer, producer, producer)
+	for range seq {
+		t.Error("Expected no elements")
+	}
+	var e *BufferLimitError
+	if err := wait(); !errors.As(err, &e) {
+		t.Errorf("Expected a buffer limit error, got %v", err)
+	} else {
+		check("FanIn", e, 3)
+	}
+
+	m2 := NewMerger2(func(a1, a2, b1, b2 int) int { return cmp.Compare(a1, b1) }, WithMaxBuffered(2))
+	check("Merger2.Merge", recovered(func() {
+		for range m2.Merge(slices.All([]int{1}), slices.All([]int{2}), slices.All([]int{3})) {
+			t.Error("Expected no elements")
+		}
+	}), 3)
+}
+
+func TestMerger_FanIn_MaxBuffered(t *testing.T) {
+	var producers []Producer[int]
+	var expected []int
+	for i := range 4 {
+		producers = append(producers, func(ctx context.Context, emit func(int) error) error {
+			for j := range 100 {
+				if err := emit(j*4 + i); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	for j := range 400 {
+		expected = append(expected, j)
+	}
+	seq, wait := NewMerger(cmp.Compare[int], WithMaxBuffered(4)).FanIn(context.Background(), producers...)
+	if actual := collectSeq(seq); !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+	if err := wait(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestMerger_MergeTo_MaxBuffered(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewMerger(cmp.Compare[byte], WithMaxBuffered(2), WithPrefetch(4))
+	if _, err := m.MergeTo(&buf, func(w io.Writer, v byte) error {
+		_, err := w.Write([]byte{v})
+		return err
+	}, slices.Values([]byte("aceg")), slices.Values([]byte("bdf"))); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if actual, expected := buf.String(), "abcdefg"; actual != expected {
+		t.Errorf("Expected %q, got %q", expected, actual)
+	}
+}
+
+func TestMergePartitioned_MaxBuffered(t *testing.T) {
+	var s []int
+	for i := range 100 {
+		s = append(s, i)
+	}
+	src := NewSortedSlice(cmp.Compare[int], s)
+	bounds := []int{25, 50, 75}
+	expected := collectSeq(Merge(cmp.Compare[int], slices.Values(s), slices.Values(s)))
+
+	// each range is 50 elements, with up to two ranges buffered, while the
+	// next is merged
+	m := NewMerger(cmp.Compare[int], WithParallelism(1), WithMaxBuffered(110))
+	if actual := collectSeq(m.MergePartitioned(bounds, src, src)); !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		limit int
+	}{
+		{"ranges", 40},
+		{"merge", 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if _, ok := recover().(*BufferLimitError); !ok {
+					t.Error("Expected a buffer limit error")
+				}
+			}()
+			for range NewMerger(cmp.Compare[int], WithMaxBuffered(tc.limit)).MergePartitioned(bounds, src, src) {
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package kway
+
+import (
+	"sync/atomic"
+)
+
+// mergeBuffers are the buffers of a single merge, which are retained by a
+// [Merger], once the merge completes, for reuse by the next.
+type mergeBuffers[E any] struct {
+	// owner is the cache the buffers are returned to, or nil
+	owner *bufferCache[E]
+	srcs  []pullSource[E]
+	pulls []func() (E, bool)
+	items []E
+	nodes []int
+	done  []bool
+}
+
+// release clears the buffers, and returns them to their owner, if any,
+// replacing any previously retained buffers. The buffers must not be used
+// afterward.
+func (x *mergeBuffers[E]) release() {
+	if x != nil && x.owner != nil {
+		x.clear()
+		x.owner.p.Store(x)
+	}
+}
+
+// clear zeroes the buffers, to their full capacity, releasing references to
+// elements and sources.
+func (x *mergeBuffers[E]) clear() {
+	clear(x.srcs[:cap(x.srcs)])
+	clear(x.pulls[:cap(x.pulls)])
+	clear(x.items[:cap(x.items)])
+}
+
+// bufferCache retains the buffers of at most one completed merge. It is safe
+// for concurrent use, with concurrent merges each taking their own buffers.
+type bufferCache[E any] struct {
+	p atomic.Pointer[mergeBuffers[E]]
+}
+
+// get takes the retained buffers, or returns new buffers, if there are none.
+func (x *bufferCache[E]) get() *mergeBuffers[E] {
+	if b := x.p.Swap(nil); b != nil {
+		return b
+	}
+	return &mergeBuffers[E]{owner: x}
+}
+
+// reset clears the retained buffers, if any, see [Merger.Reset].
+func (x *bufferCache[E]) reset() {
+	if b := x.p.Swap(nil); b != nil {
+		b.clear()
+		x.p.CompareAndSwap(nil, b)
+	}
+}
+
+// reuse returns s, resized to n zeroed elements, reallocating only if its
+// capacity is insufficient.
+func reuse[S ~[]E, E any](s S, n int) S {
+	if cap(s) < n {
+		return make(S, n)
+	}
+	s = s[:n]
+	clear(s)
+	return s
+}
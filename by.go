@@ -0,0 +1,73 @@
+package kway
+
+import (
+	"cmp"
+)
+
+// Order is the direction of a key, in a [Comparator] built using [By].
+type Order int
+
+const (
+	// Asc orders keys in ascending order.
+	Asc Order = 1
+	// Desc orders keys in descending order.
+	Desc Order = -1
+)
+
+// Comparator is a comparison function, per [Merge], built from keys, using
+// [By], and combined using [Comparator.Then], e.g.
+//
+//	kway.By(func(v Row) string { return v.Name }, kway.Asc).
+//		Then(kway.By(func(v Row) int { return v.Age }, kway.Desc))
+//
+// A Comparator may be passed directly as the comparison function of a merge.
+type Comparator[T any] func(a, b T) int
+
+// By returns a [Comparator] ordering elements by the key returned by `key`,
+// in the given order, using [cmp.Compare].
+func By[T any, K cmp.Ordered](key func(v T) K, order Order) Comparator[T] {
+	return ByFunc(key, cmp.Compare[K], order)
+}
+
+// ByFunc returns a [Comparator] ordering elements by the key returned by
+// `key`, in the given order, using the comparison function `cmp`, e.g. to
+// order keys that are not [cmp.Ordered], or by [CompareFold].
+func ByFunc[T any, K any](key func(v T) K, cmp func(a, b K) int, order Order) Comparator[T] {
+	if key == nil {
+		panic("kway: nil key function")
+	}
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	switch order {
+	case Asc:
+		return func(a, b T) int { return cmp(key(a), key(b)) }
+	case Desc:
+		return func(a, b T) int { return cmp(key(b), key(a)) }
+	default:
+		panic("kway: invalid order")
+	}
+}
+
+// Then returns a [Comparator] that orders elements by `c`, then, for elements
+// that `c` considers equal, by `next`.
+func (c Comparator[T]) Then(next Comparator[T]) Comparator[T] {
+	if next == nil {
+		panic("kway: nil comparison function")
+	}
+	return func(a, b T) int {
+		if v := c(a, b); v != 0 {
+			return v
+		}
+		return next(a, b)
+	}
+}
+
+// Reverse returns a [Comparator] with the opposite order of `c`.
+func (c Comparator[T]) Reverse() Comparator[T] {
+	return func(a, b T) int { return c(b, a) }
+}
+
+// Compare compares `a` and `b`, per [Merge]. It is equivalent to calling `c`,
+// and is provided for use as a method value.
+func (c Comparator[T]) Compare(a, b T) int { return c(a, b) }
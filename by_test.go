@@ -0,0 +1,88 @@
+package kway
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBy(t *testing.T) {
+	type row struct {
+		name string
+		age  int
+	}
+	byName := func(v row) string { return v.name }
+	byAge := func(v row) int { return v.age }
+
+	rows := []row{{"b", 1}, {"a", 2}, {"B", 3}, {"a", 1}, {"b", 3}}
+
+	tests := []struct {
+		name     string
+		cmp      Comparator[row]
+		expected []row
+	}{
+		{
+			name:     "single key",
+			cmp:      By(byAge, Asc),
+			expected: []row{{"b", 1}, {"a", 1}, {"a", 2}, {"B", 3}, {"b", 3}},
+		},
+		{
+			name:     "asc then desc",
+			cmp:      By(byName, Asc).Then(By(byAge, Desc)),
+			expected: []row{{"B", 3}, {"a", 2}, {"a", 1}, {"b", 3}, {"b", 1}},
+		},
+		{
+			name:     "custom key comparison",
+			cmp:      ByFunc(byName, CompareFold, Asc).Then(By(byAge, Asc)),
+			expected: []row{{"a", 1}, {"a", 2}, {"b", 1}, {"B", 3}, {"b", 3}},
+		},
+		{
+			name:     "reverse",
+			cmp:      By(byName, Asc).Then(By(byAge, Desc)).Reverse(),
+			expected: []row{{"b", 1}, {"b", 3}, {"a", 1}, {"a", 2}, {"B", 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := slices.Clone(rows)
+			slices.SortStableFunc(result, tt.cmp.Compare)
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestBy_Merge(t *testing.T) {
+	type row struct {
+		name string
+		age  int
+	}
+	c := By(func(v row) int { return v.age }, Desc).Then(By(func(v row) string { return v.name }, Asc))
+	result := collectSeq(Merge(c,
+		sliceSeq([]row{{"a", 3}, {"c", 2}}),
+		sliceSeq([]row{{"b", 3}, {"a", 1}}),
+	))
+	if expected := []row{{"a", 3}, {"b", 3}, {"c", 2}, {"a", 1}}; !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestBy_Panics(t *testing.T) {
+	key := func(v int) int { return v }
+	for name, fn := range map[string]func(){
+		"nil key":        func() { By[int, int](nil, Asc) },
+		"nil comparison": func() { ByFunc[int, int](key, nil, Asc) },
+		"invalid order":  func() { By(key, 0) },
+		"nil then":       func() { By(key, Asc).Then(nil) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package kway
+
+import (
+	"bytes"
+	"iter"
+)
+
+// MergeBytes performs a k-way merge of the provided sorted input sequences of
+// byte slices, per [Merge], using [bytes.Compare]. It uses an engine
+// specialized for byte slices, which calls [bytes.Compare] directly, and
+// does not allocate per element, making it suited to merging keys, e.g. of
+// an LSM tree, or index terms.
+//
+// Elements are yielded as-is, and are not copied.
+func MergeBytes(seqs ...iter.Seq[[]byte]) iter.Seq[[]byte] {
+	if !anySeq(seqs) {
+		return emptySeq[[]byte]
+	}
+	return func(yield func([]byte) bool) {
+		x := bytesMergeState{
+			pulls: make([]func() ([]byte, bool), len(seqs)),
+			items: make([]bytesItem, 0, len(seqs)),
+		}
+		for i, seq := range seqs {
+			if seq != nil {
+				next, stop := iter.Pull(seq)
+				defer stop()
+				if v, ok := next(); ok {
+					x.items = append(x.items, bytesItem{v: v, i: i})
+					x.pulls[i] = next
+				}
+			}
+		}
+		x.init()
+		for len(x.items) != 0 {
+			top := &x.items[0]
+			if !yield(top.v) {
+				return
+			}
+			if v, ok := x.pulls[top.i](); ok {
+				// replace the top, avoiding a pop and push
+				top.v = v
+			} else {
+				n := len(x.items) - 1
+				x.items[0] = x.items[n]
+				x.items[n] = bytesItem{}
+				x.items = x.items[:n]
+			}
+			x.down(0)
+		}
+	}
+}
+
+// bytesItem is the current element of source i.
+type bytesItem struct {
+	v []byte
+	i int
+}
+
+// bytesMergeState is a binary heap of bytesItem values, equivalent to
+// mergeState, using [bytes.Compare].
+type bytesMergeState struct {
+	pulls []func() ([]byte, bool)
+	items []bytesItem
+}
+
+func (x *bytesMergeState) less(i, j int) bool {
+	a, b := &x.items[i], &x.items[j]
+	if v := bytes.Compare(a.v, b.v); v != 0 {
+		return v < 0
+	}
+	return a.i < b.i
+}
+
+func (x *bytesMergeState) init() {
+	for i := len(x.items)/2 - 1; i >= 0; i-- {
+		x.down(i)
+	}
+}
+
+func (x *bytesMergeState) down(i int) {
+	n := len(x.items)
+	for {
+		j := 2*i + 1
+		if j >= n {
+			return
+		}
+		if k := j + 1; k < n && x.less(k, j) {
+			j = k
+		}
+		if !x.less(j, i) {
+			return
+		}
+		x.items[i], x.items[j] = x.items[j], x.items[i]
+		i = j
+	}
+}
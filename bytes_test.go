@@ -0,0 +1,96 @@
+package kway
+
+import (
+	"bytes"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestMergeBytes(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for round := range 100 {
+		var seqs []iter.Seq[[]byte]
+		for range r.IntN(6) {
+			if r.IntN(6) == 0 {
+				seqs = append(seqs, nil)
+				continue
+			}
+			var s [][]byte
+			for range r.IntN(20) {
+				s = append(s, []byte(fmt.Sprintf("key%02d", r.IntN(30)))[:3+r.IntN(3)])
+			}
+			slices.SortFunc(s, bytes.Compare)
+			seqs = append(seqs, sliceSeq(s))
+		}
+
+		expected := collectSeq(Merge(bytes.Compare, seqs...))
+		result := collectSeq(MergeBytes(seqs...))
+		if len(result) != len(expected) {
+			t.Fatalf("Round %d: expected %d elements, got %d", round, len(expected), len(result))
+		}
+		for i := range expected {
+			// identical, including the source, for stability
+			if &result[i][0] != &expected[i][0] {
+				t.Fatalf("Round %d: element %d: expected %q, got %q", round, i, expected[i], result[i])
+			}
+		}
+	}
+}
+
+func TestMergeBytes_EarlyTermination(t *testing.T) {
+	var result []string
+	for v := range MergeBytes(
+		sliceSeq([][]byte{[]byte("a"), []byte("c")}),
+		sliceSeq([][]byte{[]byte("b"), []byte("d")}),
+	) {
+		result = append(result, string(v))
+		if len(result) == 3 {
+			break
+		}
+	}
+	if !slices.Equal(result, []string{"a", "b", "c"}) {
+		t.Errorf("Expected [a b c], got %v", result)
+	}
+}
+
+func TestMergeBytes_NoSequences(t *testing.T) {
+	if result := collectSeq(MergeBytes()); result != nil {
+		t.Errorf("Expected nil, got %v", result)
+	}
+	if result := collectSeq(MergeBytes(nil, nil)); result != nil {
+		t.Errorf("Expected nil, got %v", result)
+	}
+}
+
+func BenchmarkMergeBytes(b *testing.B) {
+	var input [][][]byte
+	for i := range 8 {
+		var s [][]byte
+		for j := range 1000 {
+			s = append(s, []byte(fmt.Sprintf("user/%08d/profile", j*8+i)))
+		}
+		input = append(input, s)
+	}
+	seqs := make([]iter.Seq[[]byte], len(input))
+	for i, s := range input {
+		seqs[i] = slices.Values(s)
+	}
+
+	b.Run("Merge", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			for range Merge(bytes.Compare, seqs...) {
+			}
+		}
+	})
+	b.Run("MergeBytes", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			for range MergeBytes(seqs...) {
+			}
+		}
+	})
+}
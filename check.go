@@ -0,0 +1,79 @@
+package kway
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// ComparatorError indicates that a comparison function is inconsistent, as
+// detected by [WithComparatorCheck].
+type ComparatorError struct {
+	// Property is the violated property, either "reflexivity" (cmp(a, a)
+	// must be 0) or "antisymmetry" (cmp(a, b) must have the opposite sign to
+	// cmp(b, a)).
+	Property string
+	// A and B are the compared elements. For sequences of pairs
+	// ([iter.Seq2]), each is a [2]any, holding both values.
+	A, B any
+	// AB and BA are the results of cmp(a, b) and cmp(b, a). For reflexivity
+	// violations, B is A, and AB and BA are both the result of cmp(a, a).
+	AB, BA int
+}
+
+func (e *ComparatorError) Error() string {
+	return fmt.Sprintf("kway: comparison function violates %s: cmp(%v, %v) = %d, cmp(%v, %v) = %d",
+		e.Property, e.A, e.B, e.AB, e.B, e.A, e.BA)
+}
+
+// checkCompare wraps cmp, validating every nth comparison, and panicking
+// with a *ComparatorError on failure.
+func checkCompare[T any](cmp func(a, b T) int, every int) func(a, b T) int {
+	var n atomic.Uint64
+	return func(a, b T) int {
+		ab := cmp(a, b)
+		if n.Add(1)%uint64(every) == 0 {
+			if err := validateCompare(a, b, ab, cmp(b, a), cmp(a, a), cmp(b, b)); err != nil {
+				panic(err)
+			}
+		}
+		return ab
+	}
+}
+
+// checkCompare2 is the [iter.Seq2] equivalent of checkCompare.
+func checkCompare2[T1 any, T2 any](cmp func(a1 T1, a2 T2, b1 T1, b2 T2) int, every int) func(a1 T1, a2 T2, b1 T1, b2 T2) int {
+	var n atomic.Uint64
+	return func(a1 T1, a2 T2, b1 T1, b2 T2) int {
+		ab := cmp(a1, a2, b1, b2)
+		if n.Add(1)%uint64(every) == 0 {
+			if err := validateCompare([2]any{a1, a2}, [2]any{b1, b2}, ab, cmp(b1, b2, a1, a2), cmp(a1, a2, a1, a2), cmp(b1, b2, b1, b2)); err != nil {
+				panic(err)
+			}
+		}
+		return ab
+	}
+}
+
+// validateCompare checks the results of the comparisons between a and b,
+// where ab is cmp(a, b), ba is cmp(b, a), etc.
+func validateCompare(a, b any, ab, ba, aa, bb int) *ComparatorError {
+	switch {
+	case aa != 0:
+		return &ComparatorError{Property: "reflexivity", A: a, B: a, AB: aa, BA: aa}
+	case bb != 0:
+		return &ComparatorError{Property: "reflexivity", A: b, B: b, AB: bb, BA: bb}
+	case sign(ab) != -sign(ba):
+		return &ComparatorError{Property: "antisymmetry", A: a, B: b, AB: ab, BA: ba}
+	}
+	return nil
+}
+
+func sign(v int) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,140 @@
+package kway
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestComparatorError_Error(t *testing.T) {
+	err := &ComparatorError{Property: "antisymmetry", A: 1, B: 2, AB: -1, BA: -1}
+	expected := "kway: comparison function violates antisymmetry: cmp(1, 2) = -1, cmp(2, 1) = -1"
+	if s := err.Error(); s != expected {
+		t.Errorf("Expected %q, got %q", expected, s)
+	}
+}
+
+func TestValidateCompare(t *testing.T) {
+	tests := []struct {
+		name           string
+		ab, ba, aa, bb int
+		expected       *ComparatorError
+	}{
+		{name: "less", ab: -1, ba: 3},
+		{name: "equal", ab: 0, ba: 0},
+		{name: "greater", ab: 2, ba: -1},
+		{
+			name:     "reflexivity a",
+			ab:       -1,
+			ba:       1,
+			aa:       1,
+			expected: &ComparatorError{Property: "reflexivity", A: "a", B: "a", AB: 1, BA: 1},
+		},
+		{
+			name:     "reflexivity b",
+			ab:       -1,
+			ba:       1,
+			bb:       -1,
+			expected: &ComparatorError{Property: "reflexivity", A: "b", B: "b", AB: -1, BA: -1},
+		},
+		{
+			name:     "antisymmetry",
+			ab:       -1,
+			ba:       -1,
+			expected: &ComparatorError{Property: "antisymmetry", A: "a", B: "b", AB: -1, BA: -1},
+		},
+		{
+			name:     "antisymmetry zero",
+			ab:       0,
+			ba:       1,
+			expected: &ComparatorError{Property: "antisymmetry", A: "a", B: "b", AB: 0, BA: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompare("a", "b", tt.ab, tt.ba, tt.aa, tt.bb)
+			if (err == nil) != (tt.expected == nil) || (err != nil && *err != *tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestWithComparatorCheck(t *testing.T) {
+	// always claims a < b, violating antisymmetry
+	broken := func(a, b int) int {
+		if a == b {
+			return 0
+		}
+		return -1
+	}
+
+	result := collectSeq(NewMerger(cmp.Compare[int], WithComparatorCheck(1)).Merge(
+		sliceSeq([]int{1, 3}),
+		sliceSeq([]int{2, 4}),
+	))
+	if !slices.Equal(result, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected [1 2 3 4], got %v", result)
+	}
+
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		for range NewMerger(broken, WithComparatorCheck(1)).Merge(sliceSeq([]int{1, 3}), sliceSeq([]int{2, 4})) {
+		}
+	}()
+	err, ok := recovered.(*ComparatorError)
+	if !ok {
+		t.Fatalf("Expected panic with *ComparatorError, got %v", recovered)
+	}
+	if err.Property != "antisymmetry" {
+		t.Errorf("Expected antisymmetry violation, got %v", err)
+	}
+
+	// disabled
+	_ = collectSeq(NewMerger(broken, WithComparatorCheck(0)).Merge(sliceSeq([]int{1, 3}), sliceSeq([]int{2, 4})))
+}
+
+func TestWithComparatorCheck_Sampling(t *testing.T) {
+	var calls int
+	counting := func(a, b int) int {
+		calls++
+		return cmp.Compare(a, b)
+	}
+	check := checkCompare(counting, 3)
+	for range 6 {
+		check(1, 2)
+	}
+	// 6 comparisons, 2 sampled with an additional 3 calls each
+	if calls != 12 {
+		t.Errorf("Expected 12 calls, got %d", calls)
+	}
+}
+
+func TestCheckCompare2(t *testing.T) {
+	check := checkCompare2(func(a1 int, a2 string, b1 int, b2 string) int {
+		return 1 // violates reflexivity
+	}, 1)
+
+	defer func() {
+		err, ok := recover().(*ComparatorError)
+		if !ok {
+			t.Fatalf("Expected panic with *ComparatorError, got %v", err)
+		}
+		expected := ComparatorError{Property: "reflexivity", A: [2]any{1, "a"}, B: [2]any{1, "a"}, AB: 1, BA: 1}
+		if *err != expected {
+			t.Errorf("Expected %v, got %v", expected, err)
+		}
+	}()
+	check(1, "a", 2, "b")
+}
+
+func TestWithComparatorCheck_Negative(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for negative interval")
+		}
+	}()
+	_ = WithComparatorCheck(-1)
+}
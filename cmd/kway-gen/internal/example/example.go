@@ -0,0 +1,12 @@
+// Package example contains merges generated by kway-gen, used to test it.
+package example
+
+//go:generate go run github.com/joeycumines/go-kway/cmd/kway-gen -type int64
+//go:generate go run github.com/joeycumines/go-kway/cmd/kway-gen -type Event -key Tenant,-Time
+
+// Event is ordered by tenant, then by time, descending.
+type Event struct {
+	Tenant string
+	Time   int64
+	ID     int
+}
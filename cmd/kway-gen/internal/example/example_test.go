@@ -0,0 +1,90 @@
+package example
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/joeycumines/go-kway"
+)
+
+func compareEvents(a, b Event) int {
+	if v := cmp.Compare(a.Tenant, b.Tenant); v != 0 {
+		return v
+	}
+	return cmp.Compare(b.Time, a.Time)
+}
+
+func TestMergeEvent(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 50 {
+		var (
+			s    [][]Event
+			seqs []iter.Seq[Event]
+		)
+		id := 0
+		for range r.IntN(6) {
+			values := make([]Event, r.IntN(30))
+			for j := range values {
+				values[j] = Event{Tenant: string(rune('a' + r.IntN(3))), Time: r.Int64N(5), ID: id}
+				id++
+			}
+			slices.SortStableFunc(values, compareEvents)
+			s = append(s, values)
+			seqs = append(seqs, slices.Values(values))
+		}
+		seqs = append(seqs, nil)
+		expected := slices.Collect(kway.Merge(compareEvents, seqs...))
+		if actual := slices.Collect(MergeEvent(seqs...)); !slices.Equal(actual, expected) {
+			t.Errorf("Expected %v, got %v", expected, actual)
+		}
+		if actual := AppendMergedEvent(nil, s...); !slices.Equal(actual, expected) {
+			t.Errorf("Expected %v, got %v", expected, actual)
+		}
+	}
+}
+
+func TestMergeInt64(t *testing.T) {
+	a, b := []int64{1, 3, 5, 7}, []int64{2, 3, 8}
+	expected := []int64{1, 2, 3, 3, 5, 7, 8}
+	if actual := slices.Collect(MergeInt64(slices.Values(a), slices.Values(b))); !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+	if actual := AppendMergedInt64([]int64{0}, a, nil, b); !slices.Equal(actual, append([]int64{0}, expected...)) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+	for v := range MergeInt64(slices.Values(a), slices.Values(b)) {
+		if v != 1 {
+			t.Errorf("Expected 1, got %d", v)
+		}
+		break
+	}
+}
+
+func BenchmarkAppendMergedInt64(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 2))
+	srcs := make([][]int64, 16)
+	for i := range srcs {
+		srcs[i] = make([]int64, 4096)
+		for j := range srcs[i] {
+			srcs[i][j] = r.Int64N(1 << 20)
+		}
+		slices.Sort(srcs[i])
+	}
+	dst := make([]int64, 0, 16*4096)
+	b.Run("generated", func(b *testing.B) {
+		for b.Loop() {
+			dst = AppendMergedInt64(dst[:0], srcs...)
+		}
+	})
+	b.Run("MergeSliceRuns", func(b *testing.B) {
+		for b.Loop() {
+			dst = dst[:0]
+			for run := range kway.MergeSliceRuns(cmp.Compare[int64], srcs...) {
+				dst = append(dst, run...)
+			}
+		}
+	})
+}
@@ -0,0 +1,325 @@
+// Command kway-gen generates non-generic k-way merge functions, for a
+// specific element type, with the comparison inlined, for hot paths where
+// the generic merges of package kway, which call the comparison function
+// indirectly, and may access elements via generic dictionaries, are
+// significant in profiles.
+//
+// Usage:
+//
+//	kway-gen -type T [flags]
+//
+// It is intended to be run via go generate, e.g.
+//
+//	//go:generate go run github.com/joeycumines/go-kway/cmd/kway-gen -type Event -key Tenant,-Time
+//
+// The generated file declares, for the element type T, and a name N:
+//
+//	func MergeN(seqs ...iter.Seq[T]) iter.Seq[T]
+//	func AppendMergedN(dst []T, srcs ...[]T) []T
+//
+// which are equivalent to Merge, and AppendMerged, of the elements of the
+// slices, of package kway, including their stability, for the order
+// described by the flags.
+//
+// The flags are:
+//
+//	-type T
+//		The element type, which must be a predeclared type, or a type
+//		declared in the package.
+//	-key F[,F...]
+//		Order elements by the fields F, in order, each a dot-separated path
+//		of struct fields, optionally prefixed by "-", for descending order.
+//		Each field must be ordered, per the < operator. If no keys are
+//		provided, elements are ordered directly, per the < operator.
+//	-name N
+//		The name of the generated functions, after the Merge or
+//		AppendMerged prefix. Defaults to the type, capitalized.
+//	-package name
+//		The package of the generated file. Defaults to $GOPACKAGE, as set by
+//		go generate.
+//	-o file
+//		Write the generated file to file, or standard output, for "-".
+//		Defaults to kway_n.go, where n is the lowercase name.
+//
+// As per the < operator, NaN floating-point keys compare equal to all
+// values, and must not be present.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// config is the configuration of the generated code, per the flags.
+type config struct {
+	typ    string
+	keys   []key
+	name   string
+	pkg    string
+	output string
+	// args are the arguments, recorded in the generated file
+	args []string
+}
+
+// key is a field, per the -key flag.
+type key struct {
+	path string
+	desc bool
+}
+
+// run runs the command, returning the exit code: 0 on success, 1 if
+// generation failed, or 2 if the arguments are invalid.
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("kway-gen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: kway-gen -type T [flags]\n\nGenerates k-way merge functions for the element type T.\n\n")
+		flags.PrintDefaults()
+	}
+	c := config{args: args}
+	flags.StringVar(&c.typ, "type", "", "the element `type`, which must be predeclared, or declared in the package")
+	flags.Func("key", "order elements by the fields `F[,F...]`, each prefixed by - for descending order", func(s string) error {
+		for path := range strings.SplitSeq(s, ",") {
+			var k key
+			k.path, k.desc = strings.CutPrefix(path, "-")
+			c.keys = append(c.keys, k)
+		}
+		return nil
+	})
+	flags.StringVar(&c.name, "name", "", "the `name` of the generated functions, after the prefix")
+	flags.StringVar(&c.pkg, "package", os.Getenv("GOPACKAGE"), "the package `name` of the generated file")
+	flags.StringVar(&c.output, "o", "", "write the generated file to `file`, or - for standard output")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if err := c.validate(flags.NArg()); err != nil {
+		fmt.Fprintf(stderr, "kway-gen: %v\n", err)
+		flags.Usage()
+		return 2
+	}
+
+	src, err := c.generate()
+	if err == nil {
+		if c.output == "-" {
+			_, err = stdout.Write(src)
+		} else {
+			err = os.WriteFile(c.output, src, 0o644)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "kway-gen: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// validate validates the configuration, and applies the defaults.
+func (c *config) validate(narg int) error {
+	if narg != 0 {
+		return errors.New("unexpected arguments")
+	}
+	if !token.IsIdentifier(c.typ) {
+		return fmt.Errorf("invalid type %q", c.typ)
+	}
+	for _, k := range c.keys {
+		for field := range strings.SplitSeq(k.path, ".") {
+			if !token.IsIdentifier(field) {
+				return fmt.Errorf("invalid key %q", k.path)
+			}
+		}
+	}
+	if c.name == "" {
+		r, n := utf8.DecodeRuneInString(c.typ)
+		c.name = string(unicode.ToUpper(r)) + c.typ[n:]
+	} else if !token.IsIdentifier(c.name) {
+		return fmt.Errorf("invalid name %q", c.name)
+	}
+	if !token.IsIdentifier(c.pkg) {
+		return fmt.Errorf("invalid package %q, which defaults to $GOPACKAGE", c.pkg)
+	}
+	if c.output == "" {
+		c.output = "kway_" + strings.ToLower(c.name) + ".go"
+	}
+	return nil
+}
+
+// generate returns the generated file, formatted per gofmt.
+func (c *config) generate() ([]byte, error) {
+	var b bytes.Buffer
+	if err := fileTemplate.Execute(&b, c); err != nil {
+		return nil, err
+	}
+	return format.Source(b.Bytes())
+}
+
+// Command returns the command line, recorded in the generated file.
+func (c *config) Command() string {
+	return strings.Join(append([]string{"kway-gen"}, c.args...), " ")
+}
+
+// Type returns the element type.
+func (c *config) Type() string { return c.typ }
+
+// Name returns the name of the generated functions.
+func (c *config) Name() string { return c.name }
+
+// Package returns the package name.
+func (c *config) Package() string { return c.pkg }
+
+// Less returns the body of the function reporting whether a orders before b.
+func (c *config) Less() string {
+	if len(c.keys) == 0 {
+		return "return a < b"
+	}
+	var b strings.Builder
+	for i, k := range c.keys {
+		x, y := "a."+k.path, "b."+k.path
+		if k.desc {
+			x, y = y, x
+		}
+		if i == len(c.keys)-1 {
+			fmt.Fprintf(&b, "return %s < %s", x, y)
+		} else {
+			fmt.Fprintf(&b, "if %s != %s {\nreturn %s < %s\n}\n", x, y, x, y)
+		}
+	}
+	return b.String()
+}
+
+var fileTemplate = template.Must(template.New("file").Parse(`// Code generated by {{.Command}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"iter"
+	"slices"
+)
+
+// kwayLess{{.Name}} reports whether a orders before b.
+func kwayLess{{.Name}}(a, b {{.Type}}) bool {
+	{{.Less}}
+}
+
+// kwayItem{{.Name}} is the current element of the source with index i.
+type kwayItem{{.Name}} struct {
+	v {{.Type}}
+	i int
+}
+
+// kwayLessItem{{.Name}} orders the current elements of the sources, falling
+// back to comparison by index, for stability.
+func kwayLessItem{{.Name}}(x, y kwayItem{{.Name}}) bool {
+	if kwayLess{{.Name}}(x.v, y.v) {
+		return true
+	}
+	return !kwayLess{{.Name}}(y.v, x.v) && x.i < y.i
+}
+
+// kwayDown{{.Name}} moves the element at index i down the binary heap h, to
+// restore the heap invariant.
+func kwayDown{{.Name}}(h []kwayItem{{.Name}}, i int) {
+	for {
+		l := 2*i + 1
+		if l >= len(h) {
+			return
+		}
+		j := l
+		if r := l + 1; r < len(h) && kwayLessItem{{.Name}}(h[r], h[l]) {
+			j = r
+		}
+		if !kwayLessItem{{.Name}}(h[j], h[i]) {
+			return
+		}
+		h[i], h[j] = h[j], h[i]
+		i = j
+	}
+}
+
+// kwayHeapify{{.Name}} establishes the heap invariant of h.
+func kwayHeapify{{.Name}}(h []kwayItem{{.Name}}) {
+	for i := len(h)/2 - 1; i >= 0; i-- {
+		kwayDown{{.Name}}(h, i)
+	}
+}
+
+// Merge{{.Name}} performs a k-way merge of the provided sorted input
+// sequences, per kway.Merge, with the comparison inlined.
+func Merge{{.Name}}(seqs ...iter.Seq[{{.Type}}]) iter.Seq[{{.Type}}] {
+	return func(yield func({{.Type}}) bool) {
+		h := make([]kwayItem{{.Name}}, 0, len(seqs))
+		pulls := make([]func() ({{.Type}}, bool), len(seqs))
+		for i, seq := range seqs {
+			if seq == nil {
+				continue
+			}
+			next, stop := iter.Pull(seq)
+			defer stop()
+			if v, ok := next(); ok {
+				h = append(h, kwayItem{{.Name}}{v, i})
+				pulls[i] = next
+			}
+		}
+		kwayHeapify{{.Name}}(h)
+		for len(h) != 0 {
+			if !yield(h[0].v) {
+				return
+			}
+			var ok bool
+			if h[0].v, ok = pulls[h[0].i](); !ok {
+				n := len(h) - 1
+				h[0] = h[n]
+				h = h[:n]
+			}
+			kwayDown{{.Name}}(h, 0)
+		}
+	}
+}
+
+// AppendMerged{{.Name}} performs a k-way merge of the provided sorted slices,
+// per kway.AppendMerged, with the comparison inlined, appending the output to
+// dst, and returning the extended slice.
+func AppendMerged{{.Name}}(dst []{{.Type}}, srcs ...[]{{.Type}}) []{{.Type}} {
+	h := make([]kwayItem{{.Name}}, 0, len(srcs))
+	pos := make([]int, len(srcs))
+	var n int
+	for i, src := range srcs {
+		if len(src) != 0 {
+			h = append(h, kwayItem{{.Name}}{src[0], i})
+			n += len(src)
+		}
+	}
+	dst = slices.Grow(dst, n)
+	kwayHeapify{{.Name}}(h)
+	for len(h) != 0 {
+		top := &h[0]
+		dst = append(dst, top.v)
+		src := srcs[top.i]
+		if pos[top.i]++; pos[top.i] < len(src) {
+			top.v = src[pos[top.i]]
+		} else {
+			m := len(h) - 1
+			h[0] = h[m]
+			h = h[:m]
+		}
+		kwayDown{{.Name}}(h, 0)
+	}
+	return dst
+}
+`))
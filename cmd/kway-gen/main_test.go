@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun_Generated(t *testing.T) {
+	// the generated files must be up to date, per their go:generate directives
+	for _, tc := range []struct {
+		file string
+		args []string
+	}{
+		{"kway_int64.go", []string{"-type", "int64"}},
+		{"kway_event.go", []string{"-type", "Event", "-key", "Tenant,-Time"}},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			expected, err := os.ReadFile(filepath.Join("internal", "example", tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			output := filepath.Join(t.TempDir(), tc.file)
+			var stderr bytes.Buffer
+			if code := run(append(tc.args, "-package", "example", "-o", output), nil, &stderr); code != 0 {
+				t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+			}
+			actual, err := os.ReadFile(output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the command line differs
+			strip := func(b []byte) string {
+				_, rest, _ := strings.Cut(string(b), "\n")
+				return rest
+			}
+			if strip(actual) != strip(expected) {
+				t.Errorf("Expected %s to match, got:\n%s", tc.file, actual)
+			}
+		})
+	}
+}
+
+func TestRun_Stdout(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-type", "row", "-key", "A.B,-C", "-name", "Rows", "-package", "p", "-o", "-"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	s := stdout.String()
+	for _, expected := range []string{
+		"// Code generated by kway-gen -type row -key A.B,-C -name Rows -package p -o -; DO NOT EDIT.\n",
+		"\npackage p\n",
+		"func MergeRows(seqs ...iter.Seq[row]) iter.Seq[row] {",
+		"func AppendMergedRows(dst []row, srcs ...[]row) []row {",
+		"\tif a.A.B != b.A.B {\n\t\treturn a.A.B < b.A.B\n\t}\n\treturn b.C < a.C\n}",
+	} {
+		if !strings.Contains(s, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, s)
+		}
+	}
+}
+
+func TestRun_Defaults(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("GOPACKAGE", "defaults")
+	var stderr bytes.Buffer
+	if code := run([]string{"-type", "uint32"}, nil, &stderr); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	b, err := os.ReadFile("kway_uint32.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(b); !strings.Contains(s, "\npackage defaults\n") || !strings.Contains(s, "func MergeUint32(") {
+		t.Errorf("Unexpected output:\n%s", s)
+	}
+}
+
+func TestRun_Invalid(t *testing.T) {
+	t.Setenv("GOPACKAGE", "p")
+	for _, tc := range []struct {
+		name    string
+		args    []string
+		code    int
+		message string
+	}{
+		{"no type", nil, 2, "kway-gen: invalid type \"\"\n"},
+		{"qualified type", []string{"-type", "time.Duration"}, 2, "kway-gen: invalid type \"time.Duration\"\n"},
+		{"key", []string{"-type", "T", "-key", "A..B"}, 2, "kway-gen: invalid key \"A..B\"\n"},
+		{"name", []string{"-type", "T", "-name", "1x"}, 2, "kway-gen: invalid name \"1x\"\n"},
+		{"package", []string{"-type", "T", "-package", ""}, 2, "kway-gen: invalid package \"\", which defaults to $GOPACKAGE\n"},
+		{"arguments", []string{"-type", "T", "x"}, 2, "kway-gen: unexpected arguments\n"},
+		{"flag", []string{"-unknown"}, 2, "flag provided but not defined: -unknown\n"},
+		{"output", []string{"-type", "T", "-o", filepath.Join(t.TempDir(), "missing", "x.go")}, 1, "kway-gen: open "},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			if code := run(tc.args, nil, &stderr); code != tc.code {
+				t.Errorf("Expected exit code %d, got %d", tc.code, code)
+			}
+			if !strings.HasPrefix(stderr.String(), tc.message) {
+				t.Errorf("Expected %q, got %q", tc.message, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRun_Help(t *testing.T) {
+	var stderr bytes.Buffer
+	if code := run([]string{"-h"}, nil, &stderr); code != 0 {
+		t.Errorf("Expected exit code 0, got %d", code)
+	}
+	if !strings.HasPrefix(stderr.String(), "usage: kway-gen") {
+		t.Errorf("Unexpected usage: %s", stderr.String())
+	}
+}
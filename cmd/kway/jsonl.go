@@ -0,0 +1,211 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// valueKind is the kind of a value, in the order in which kinds compare.
+type valueKind int
+
+const (
+	// kindNull is a null or missing value
+	kindNull valueKind = iota
+	kindBool
+	kindNumber
+	kindTime
+	kindString
+)
+
+// value is a key, parsed, such that it may be compared per its type.
+type value struct {
+	kind valueKind
+	b    bool
+	// i is the value of an integer number, if isInt, and f the value of any
+	// number
+	i     int64
+	isInt bool
+	f     float64
+	t     time.Time
+	s     string
+}
+
+// keyType constrains the kind of a value, see keyTypes.
+type keyType int
+
+const (
+	keyAuto keyType = iota
+	keyString
+	keyNumber
+	keyTime
+)
+
+// keyTypes are the values of the -key-type flag.
+var keyTypes = map[string]keyType{
+	"auto":   keyAuto,
+	"string": keyString,
+	"number": keyNumber,
+	"time":   keyTime,
+}
+
+// compareValues compares values of different kinds by kind, and values of
+// the same kind per their type.
+func compareValues(a, b value) int {
+	if a.kind != b.kind {
+		return cmp.Compare(a.kind, b.kind)
+	}
+	switch a.kind {
+	case kindBool:
+		switch {
+		case a.b == b.b:
+			return 0
+		case b.b:
+			return -1
+		}
+		return 1
+	case kindNumber:
+		switch {
+		case a.isInt && b.isInt:
+			return cmp.Compare(a.i, b.i)
+		case a.isInt:
+			return compareIntFloat(a.i, b.f)
+		case b.isInt:
+			return -compareIntFloat(b.i, a.f)
+		}
+		return cmp.Compare(a.f, b.f)
+	case kindTime:
+		return a.t.Compare(b.t)
+	case kindString:
+		return strings.Compare(a.s, b.s)
+	}
+	return 0
+}
+
+// compareIntFloat compares i and f exactly, as converting integers beyond
+// 2^53 to float64 loses precision, such that the comparison of mixed numbers
+// would not be transitive. As for [cmp.Compare], NaN is less than any number.
+func compareIntFloat(i int64, f float64) int {
+	switch {
+	case math.IsNaN(f):
+		return 1
+	case f >= math.MaxInt64:
+		// as float64, math.MaxInt64 rounds up to 2^63
+		return -1
+	case f < math.MinInt64:
+		return 1
+	}
+	t := math.Trunc(f)
+	if c := cmp.Compare(i, int64(t)); c != 0 {
+		return c
+	}
+	// i equals the integer part of f
+	return cmp.Compare(t, f)
+}
+
+// parseJSONL parses a line of newline-delimited JSON, extracting the key at
+// path. Blank lines are skipped.
+func parseJSONL(line string, path []string, keyType keyType) (record, bool, error) {
+	if strings.TrimSpace(line) == "" {
+		return record{}, false, nil
+	}
+	dec := json.NewDecoder(strings.NewReader(line))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return record{}, false, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return record{}, false, errors.New("invalid JSON: unexpected data after value")
+	}
+	key, err := parseValue(lookup(v, path), keyType)
+	if err != nil {
+		return record{}, false, fmt.Errorf("key %s: %w", strings.Join(path, "."), err)
+	}
+	return record{line: line, keys: []value{key}}, true, nil
+}
+
+// lookup returns the value at path, within v, or nil if it is missing. Path
+// components index objects by name, and arrays by position.
+func lookup(v any, path []string) any {
+	for _, name := range path {
+		switch x := v.(type) {
+		case map[string]any:
+			v = x[name]
+		case []any:
+			i, err := strconv.Atoi(name)
+			if err != nil || i < 0 || i >= len(x) {
+				return nil
+			}
+			v = x[i]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// parseValue converts a decoded JSON value to a value, per keyType. Null
+// values are permitted for any type. For keyAuto, strings are parsed as
+// times, if they are formatted per RFC 3339.
+func parseValue(v any, keyType keyType) (value, error) {
+	switch x := v.(type) {
+	case nil:
+		return value{kind: kindNull}, nil
+	case bool:
+		if keyType == keyAuto {
+			return value{kind: kindBool, b: x}, nil
+		}
+	case json.Number:
+		if keyType == keyAuto || keyType == keyNumber {
+			return parseNumber(string(x))
+		}
+	case string:
+		switch keyType {
+		case keyAuto:
+			if t, err := time.Parse(time.RFC3339Nano, x); err == nil {
+				return value{kind: kindTime, t: t}, nil
+			}
+			return value{kind: kindString, s: x}, nil
+		case keyString:
+			return value{kind: kindString, s: x}, nil
+		case keyNumber:
+			return parseNumber(x)
+		case keyTime:
+			t, err := time.Parse(time.RFC3339Nano, x)
+			if err != nil {
+				return value{}, err
+			}
+			return value{kind: kindTime, t: t}, nil
+		}
+	default:
+		return value{}, errors.New("not a string, number or boolean")
+	}
+	return value{}, fmt.Errorf("%v is not a %s", v, keyTypeNames[keyType])
+}
+
+var keyTypeNames = [...]string{
+	keyAuto:   "scalar",
+	keyString: "string",
+	keyNumber: "number",
+	keyTime:   "time",
+}
+
+// parseNumber parses s as a number, exactly, if it is an integer.
+func parseNumber(s string) (value, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return value{}, fmt.Errorf("%q is not a number", s)
+	}
+	v := value{kind: kindNumber, f: f}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		v.i, v.isInt = i, true
+	}
+	return v, nil
+}
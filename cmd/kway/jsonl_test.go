@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun_jsonl(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    []string
+		contents []string
+		expected string
+	}{
+		{
+			name:  "string",
+			flags: []string{"-key", "name"},
+			contents: []string{
+				`{"name":"a"}` + "\n" + `{"name":"c"}` + "\n",
+				`{"name":"b"}` + "\n",
+			},
+			expected: `{"name":"a"}` + "\n" + `{"name":"b"}` + "\n" + `{"name":"c"}` + "\n",
+		},
+		{
+			name:  "number",
+			flags: []string{"-key", "n"},
+			contents: []string{
+				`{"n":2}` + "\n" + `{"n":10}` + "\n",
+				`{"n":-1.5}` + "\n" + `{"n":9}` + "\n",
+			},
+			expected: `{"n":-1.5}` + "\n" + `{"n":2}` + "\n" + `{"n":9}` + "\n" + `{"n":10}` + "\n",
+		},
+		{
+			name:  "large integers",
+			flags: []string{"-key", "id"},
+			contents: []string{
+				`{"id":9007199254740993}` + "\n",
+				`{"id":9007199254740992}` + "\n",
+			},
+			expected: `{"id":9007199254740992}` + "\n" + `{"id":9007199254740993}` + "\n",
+		},
+		{
+			name:  "time",
+			flags: []string{"-key", "meta.time"},
+			contents: []string{
+				`{"meta":{"time":"2024-01-01T10:00:00Z"}}` + "\n" + `{"meta":{"time":"2024-01-01T10:00:00.5Z"}}` + "\n",
+				`{"meta":{"time":"2024-01-01T11:30:00+02:00"}}` + "\n",
+			},
+			expected: `{"meta":{"time":"2024-01-01T11:30:00+02:00"}}` + "\n" + `{"meta":{"time":"2024-01-01T10:00:00Z"}}` + "\n" + `{"meta":{"time":"2024-01-01T10:00:00.5Z"}}` + "\n",
+		},
+		{
+			name:  "array index",
+			flags: []string{"-key", "v.1"},
+			contents: []string{
+				`{"v":[9,1]}` + "\n" + `{"v":[0,3]}` + "\n",
+				`{"v":[5,2]}` + "\n",
+			},
+			expected: `{"v":[9,1]}` + "\n" + `{"v":[5,2]}` + "\n" + `{"v":[0,3]}` + "\n",
+		},
+		{
+			name:  "missing and mixed",
+			flags: []string{"-key", "k"},
+			contents: []string{
+				`{}` + "\n" + `{"k":true}` + "\n" + `{"k":1}` + "\n" + `{"k":"x"}` + "\n",
+				`{"k":null}` + "\n" + `{"k":false}` + "\n" + `{"k":"2024-01-01T00:00:00Z"}` + "\n",
+			},
+			expected: `{}` + "\n" + `{"k":null}` + "\n" + `{"k":false}` + "\n" + `{"k":true}` + "\n" + `{"k":1}` + "\n" + `{"k":"2024-01-01T00:00:00Z"}` + "\n" + `{"k":"x"}` + "\n",
+		},
+		{
+			name:  "forced string",
+			flags: []string{"-key", "k", "-key-type", "string"},
+			contents: []string{
+				`{"k":"2024-01-01T00:00:00Z"}` + "\n",
+				`{"k":"10"}` + "\n",
+			},
+			expected: `{"k":"10"}` + "\n" + `{"k":"2024-01-01T00:00:00Z"}` + "\n",
+		},
+		{
+			name:  "forced number",
+			flags: []string{"-key", "k", "-key-type", "number"},
+			contents: []string{
+				`{"k":"9"}` + "\n" + `{"k":"10"}` + "\n",
+				`{"k":9.5}` + "\n",
+			},
+			expected: `{"k":"9"}` + "\n" + `{"k":9.5}` + "\n" + `{"k":"10"}` + "\n",
+		},
+		{
+			name:  "unique reverse",
+			flags: []string{"-key", "k", "-u", "-r"},
+			contents: []string{
+				`{"k":2,"src":0}` + "\n" + `{"k":1,"src":0}` + "\n",
+				`{"k":2,"src":1}` + "\n" + "\n",
+			},
+			expected: `{"k":2,"src":0}` + "\n" + `{"k":1,"src":0}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"-jsonl", "-c"}, tt.flags...)
+			args = append(args, writeFiles(t, tt.contents...)...)
+			var stdout, stderr bytes.Buffer
+			if code := run(args, nil, &stdout, &stderr); code != 0 {
+				t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+			}
+			if s := stdout.String(); s != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, s)
+			}
+		})
+	}
+}
+
+func TestRun_jsonlErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		flags  []string
+		stdin  string
+		code   int
+		stderr string
+	}{
+		{name: "no key", flags: []string{"-jsonl"}, code: 2, stderr: "-jsonl requires -key"},
+		{name: "no jsonl", flags: []string{"-key", "k"}, code: 2, stderr: "-key requires -jsonl"},
+		{name: "key type", flags: []string{"-jsonl", "-key", "k", "-key-type", "uuid"}, code: 2, stderr: `invalid -key-type "uuid"`},
+		{name: "invalid", flags: []string{"-jsonl", "-key", "k"}, stdin: "{}\n{\n", code: 1, stderr: "kway: standard input: line 2: invalid JSON"},
+		{name: "trailing", flags: []string{"-jsonl", "-key", "k"}, stdin: "{} {}\n", code: 1, stderr: "unexpected data after value"},
+		{name: "object", flags: []string{"-jsonl", "-key", "k"}, stdin: `{"k":{}}`, code: 1, stderr: "key k: not a string, number or boolean"},
+		{name: "not number", flags: []string{"-jsonl", "-key", "k", "-key-type", "number"}, stdin: `{"k":"x"}`, code: 1, stderr: `key k: "x" is not a number`},
+		{name: "not string", flags: []string{"-jsonl", "-key", "k", "-key-type", "string"}, stdin: `{"k":1}`, code: 1, stderr: "key k: 1 is not a string"},
+		{name: "not time", flags: []string{"-jsonl", "-key", "k", "-key-type", "time"}, stdin: `{"k":"today"}`, code: 1, stderr: "key k: parsing time"},
+		{name: "unsorted", flags: []string{"-jsonl", "-key", "k", "-c"}, stdin: `{"k":2}` + "\n\n" + `{"k":1}` + "\n", code: 1, stderr: `standard input: line 3 is out of order: "{\"k\":1}" follows "{\"k\":2}"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.flags, strings.NewReader(tt.stdin), &stdout, &stderr); code != tt.code {
+				t.Errorf("Expected exit code %d, got %d", tt.code, code)
+			}
+			if s := stderr.String(); !strings.Contains(s, tt.stderr) {
+				t.Errorf("Expected stderr containing %q, got %q", tt.stderr, s)
+			}
+		})
+	}
+}
+
+func TestCompareValues_numbers(t *testing.T) {
+	number := func(s string) value {
+		v, err := parseNumber(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1", "2", -1},
+		{"2", "1.5", 1},
+		{"1.5", "2", -1},
+		{"2", "2.0", 0},
+		{"2.0", "2", 0},
+		{"-2", "-1.5", -1},
+		{"-1", "-1.5", 1},
+		{"9007199254740993", "9007199254740992.0", 1},
+		{"9007199254740992.0", "9007199254740993", -1},
+		{"9007199254740992", "9007199254740992.0", 0},
+		{"9223372036854775807", "9223372036854775807.0", -1},
+		{"-9223372036854775808", "-9223372036854775808.0", 0},
+		{"-9223372036854775808", "-1e19", 1},
+		{"1", "NaN", 1},
+		{"NaN", "1", -1},
+		{"1", "+Inf", -1},
+		{"1", "-Inf", 1},
+	}
+	for _, tt := range tests {
+		if actual := compareValues(number(tt.a), number(tt.b)); actual != tt.expected {
+			t.Errorf("%s vs %s: expected %d, got %d", tt.a, tt.b, tt.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// keySpec is a key specification, per the -k flag, in the form accepted by
+// GNU sort: F[.C][OPTS][,F[.C][OPTS]], where F is a field number, and C a
+// character position within the field, both 1-based, and OPTS is any of b
+// (ignore leading blanks), n (compare numerically) and r (reverse).
+type keySpec struct {
+	// startField and startChar are the 0-based start position
+	startField, startChar int
+	// endField is the number of fields to skip, then endChar the number
+	// of characters, to find the end position, or the end of the line, if
+	// endField is -1, or the end of the field, if endChar is 0
+	endField, endChar int
+
+	startBlanks, endBlanks bool
+	numeric                bool
+	reverse                bool
+	// options is true if any options were specified, in which case the
+	// global options are not inherited
+	options bool
+}
+
+// parseKeySpec parses a key specification, per keySpec.
+func parseKeySpec(s string) (keySpec, error) {
+	k := keySpec{endField: -1}
+	start, end, hasEnd := strings.Cut(s, ",")
+
+	field, char, opts, err := parseKeyPosition(start)
+	if err != nil {
+		return k, fmt.Errorf("invalid key %q: %w", s, err)
+	}
+	if field == 0 || (char == 0 && strings.Contains(start, ".")) {
+		return k, fmt.Errorf("invalid key %q: start position must be positive", s)
+	}
+	k.startField, k.startChar = field-1, max(char, 1)-1
+	if err := k.setOptions(opts, &k.startBlanks); err != nil {
+		return k, fmt.Errorf("invalid key %q: %w", s, err)
+	}
+
+	if hasEnd {
+		field, char, opts, err := parseKeyPosition(end)
+		if err != nil {
+			return k, fmt.Errorf("invalid key %q: %w", s, err)
+		}
+		if field == 0 {
+			return k, fmt.Errorf("invalid key %q: end field must be positive", s)
+		}
+		k.endField, k.endChar = field, char
+		if char != 0 {
+			k.endField--
+		}
+		if err := k.setOptions(opts, &k.endBlanks); err != nil {
+			return k, fmt.Errorf("invalid key %q: %w", s, err)
+		}
+	}
+	return k, nil
+}
+
+// parseKeyPosition parses F[.C][OPTS], returning 0 for a missing C.
+func parseKeyPosition(s string) (field, char int, opts string, err error) {
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i < 0 {
+		i = len(s)
+	}
+	pos, opts := s[:i], s[i:]
+	f, c, hasChar := strings.Cut(pos, ".")
+	if field, err = strconv.Atoi(f); err != nil {
+		return 0, 0, "", fmt.Errorf("invalid field %q", f)
+	}
+	if hasChar {
+		if char, err = strconv.Atoi(c); err != nil {
+			return 0, 0, "", fmt.Errorf("invalid character position %q", c)
+		}
+	}
+	return field, char, opts, nil
+}
+
+func (k *keySpec) setOptions(opts string, blanks *bool) error {
+	for _, opt := range opts {
+		switch opt {
+		case 'b':
+			*blanks = true
+		case 'n':
+			k.numeric = true
+		case 'r':
+			k.reverse = true
+		default:
+			return fmt.Errorf("unsupported option %q", opt)
+		}
+		k.options = true
+	}
+	return nil
+}
+
+// extract returns the key of the line, where tab is the field separator, or
+// -1 if fields are separated by transitions from blank to non-blank
+// characters, in which case leading blanks are part of each field.
+func (k *keySpec) extract(line string, tab int) string {
+	beg := k.begin(line, tab)
+	lim := len(line)
+	if k.endField >= 0 {
+		lim = k.limit(line, tab)
+	}
+	if lim < beg {
+		return ""
+	}
+	return line[beg:lim]
+}
+
+func (k *keySpec) begin(line string, tab int) int {
+	p := skipFields(line, 0, k.startField, tab, false)
+	if k.startBlanks {
+		p = skipBlanks(line, p)
+	}
+	return min(p+k.startChar, len(line))
+}
+
+func (k *keySpec) limit(line string, tab int) int {
+	p := skipFields(line, 0, k.endField, tab, k.endChar == 0)
+	if k.endChar != 0 {
+		if k.endBlanks {
+			p = skipBlanks(line, p)
+		}
+		p = min(p+k.endChar, len(line))
+	}
+	return p
+}
+
+// skipFields skips n fields, from p, including the separator following the
+// final field, unless atEnd.
+func skipFields(line string, p, n, tab int, atEnd bool) int {
+	for ; p < len(line) && n > 0; n-- {
+		if tab >= 0 {
+			for p < len(line) && int(line[p]) != tab {
+				p++
+			}
+			if p < len(line) && (n > 1 || !atEnd) {
+				p++
+			}
+		} else {
+			p = skipBlanks(line, p)
+			for p < len(line) && !isBlank(line[p]) {
+				p++
+			}
+		}
+	}
+	return p
+}
+
+func skipBlanks(s string, p int) int {
+	for p < len(s) && isBlank(s[p]) {
+		p++
+	}
+	return p
+}
+
+func isBlank(c byte) bool { return c == ' ' || c == '\t' }
+
+// compare compares keys extracted per the spec, applying reverse, if the
+// key specifies it, or inherits it, per the global reverse.
+func (k *keySpec) compare(a, b string, reverse bool) int {
+	var v int
+	if k.numeric {
+		v = compareNumericText(a, b)
+	} else {
+		v = strings.Compare(a, b)
+	}
+	if k.reverse || (!k.options && reverse) {
+		return -v
+	}
+	return v
+}
+
+// compareNumericText compares the numeric prefixes of a and b, per GNU sort
+// -n, exactly, following any leading blanks: an optional minus sign, digits,
+// and an optional fraction. Strings without a numeric prefix compare as 0.
+func compareNumericText(a, b string) int {
+	negA, intA, fracA := parseNumericText(a)
+	negB, intB, fracB := parseNumericText(b)
+	if intA == "" && fracA == "" {
+		negA = false
+	}
+	if intB == "" && fracB == "" {
+		negB = false
+	}
+	if negA != negB {
+		if negA {
+			return -1
+		}
+		return 1
+	}
+	v := cmp.Compare(len(intA), len(intB))
+	if v == 0 {
+		v = strings.Compare(intA, intB)
+	}
+	if v == 0 {
+		v = strings.Compare(fracA, fracB)
+	}
+	if negA {
+		return -v
+	}
+	return v
+}
+
+// parseNumericText returns the sign, and the significant digits of the
+// integer and fractional parts, of the numeric prefix of s.
+func parseNumericText(s string) (neg bool, integer, fraction string) {
+	s = s[skipBlanks(s, 0):]
+	if strings.HasPrefix(s, "-") {
+		neg, s = true, s[1:]
+	}
+	i := 0
+	for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+		i++
+	}
+	integer, s = strings.TrimLeft(s[:i], "0"), s[i:]
+	if strings.HasPrefix(s, ".") {
+		s = s[1:]
+		i = 0
+		for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+			i++
+		}
+		fraction = strings.TrimRight(s[:i], "0")
+	}
+	return neg, integer, fraction
+}
+
+// parseTab parses the -t flag, which must be a single byte.
+func parseTab(s string) (int, error) {
+	switch {
+	case s == "":
+		return -1, nil
+	case s == `\0`:
+		return 0, nil
+	case len(s) != 1:
+		return 0, errors.New("-t requires a single character")
+	}
+	return int(s[0]), nil
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseKeySpec(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected keySpec
+		err      string
+	}{
+		{spec: "2", expected: keySpec{startField: 1, endField: -1}},
+		{spec: "2,2", expected: keySpec{startField: 1, endField: 2}},
+		{spec: "2.3,4.5", expected: keySpec{startField: 1, startChar: 2, endField: 3, endChar: 5}},
+		{spec: "1,1.0", expected: keySpec{endField: 1}},
+		{spec: "3n", expected: keySpec{startField: 2, endField: -1, numeric: true, options: true}},
+		{spec: "1b,2br", expected: keySpec{endField: 2, startBlanks: true, endBlanks: true, reverse: true, options: true}},
+		{spec: "0", err: "start position must be positive"},
+		{spec: "1.0", err: "start position must be positive"},
+		{spec: "1,0", err: "end field must be positive"},
+		{spec: "x", err: `invalid field ""`},
+		{spec: "1.x", err: `invalid character position ""`},
+		{spec: "1f", err: `unsupported option 'f'`},
+		{spec: "1,2z", err: `unsupported option 'z'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			k, err := parseKeySpec(tt.spec)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("Expected error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil || k != tt.expected {
+				t.Errorf("Expected %+v, got %+v, %v", tt.expected, k, err)
+			}
+		})
+	}
+}
+
+func TestKeySpec_extract(t *testing.T) {
+	tests := []struct {
+		spec     string
+		tab      int
+		line     string
+		expected string
+	}{
+		{spec: "1", tab: -1, line: "a b c", expected: "a b c"},
+		{spec: "2", tab: -1, line: "a  b c", expected: "  b c"},
+		{spec: "2,2", tab: -1, line: "a  b c", expected: "  b"},
+		{spec: "2b,2", tab: -1, line: "a  b c", expected: "b"},
+		{spec: "2.2,2", tab: -1, line: "a  b c", expected: " b"},
+		{spec: "2.2b,2", tab: -1, line: "a  bcd e", expected: "cd"},
+		{spec: "1,1.2", tab: -1, line: "abc d", expected: "ab"},
+		{spec: "2,3", tab: -1, line: "a b c d", expected: " b c"},
+		{spec: "4", tab: -1, line: "a b", expected: ""},
+		{spec: "2", tab: ',', line: "a,b,c", expected: "b,c"},
+		{spec: "2,2", tab: ',', line: "a,b,c", expected: "b"},
+		{spec: "2,2", tab: ',', line: "a,,c", expected: ""},
+		{spec: "3,3", tab: ',', line: "a,b", expected: ""},
+		{spec: "1.2,2.1", tab: ',', line: "ab,cd", expected: "b,c"},
+		{spec: "2.5,2", tab: ',', line: "a,bc,d", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec+"/"+tt.line, func(t *testing.T) {
+			k, err := parseKeySpec(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v := k.extract(tt.line, tt.tab); v != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, v)
+			}
+		})
+	}
+}
+
+func TestCompareNumericText(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1", "1", 0},
+		{"1", "2", -1},
+		{"9", "10", -1},
+		{"007", "7", 0},
+		{"1.5", "1.50", 0},
+		{"1.5", "1.45", 1},
+		{"-1", "1", -1},
+		{"-2", "-1", -1},
+		{"-0", "0", 0},
+		{"", "0", 0},
+		{"abc", "0", 0},
+		{"-", "0", 0},
+		{"  12", "3", 1},
+		{"12abc", "12", 0},
+		{".5", "0.5", 0},
+		{"-.5", "0", -1},
+		{"123456789012345678901234567890", "123456789012345678901234567891", -1},
+	}
+
+	for _, tt := range tests {
+		if v := compareNumericText(tt.a, tt.b); v != tt.expected {
+			t.Errorf("compareNumericText(%q, %q) = %d, want %d", tt.a, tt.b, v, tt.expected)
+		}
+		if v := compareNumericText(tt.b, tt.a); v != -tt.expected {
+			t.Errorf("compareNumericText(%q, %q) = %d, want %d", tt.b, tt.a, v, -tt.expected)
+		}
+	}
+}
+
+func TestRun_keys(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    []string
+		contents []string
+		expected string
+	}{
+		{
+			name:     "field",
+			flags:    []string{"-k", "2"},
+			contents: []string{"x a\nw c\n", "z b\n"},
+			expected: "x a\nz b\nw c\n",
+		},
+		{
+			name:     "numeric",
+			flags:    []string{"-t", ",", "-k", "2,2n"},
+			contents: []string{"a,2\nb,10\n", "c,-1\nd,9\n"},
+			expected: "c,-1\na,2\nd,9\nb,10\n",
+		},
+		{
+			name:     "numeric reverse",
+			flags:    []string{"-t", ",", "-k", "2,2nr"},
+			contents: []string{"a,10\nb,2\n", "c,9\n"},
+			expected: "a,10\nc,9\nb,2\n",
+		},
+		{
+			name:     "multiple keys",
+			flags:    []string{"-t", ",", "-k", "2,2n", "-k", "1,1r"},
+			contents: []string{"b,1\na,1\nc,2\n", "c,1\nb,2\n"},
+			expected: "c,1\nb,1\na,1\nc,2\nb,2\n",
+		},
+		{
+			name:     "last resort",
+			flags:    []string{"-k", "2,2"},
+			contents: []string{"b x\nd y\n", "a x\nc y\n"},
+			expected: "a x\nb x\nc y\nd y\n",
+		},
+		{
+			name:     "last resort reverse",
+			flags:    []string{"-r", "-k", "2,2"},
+			contents: []string{"d y\nb x\n", "c y\na x\n"},
+			expected: "d y\nc y\nb x\na x\n",
+		},
+		{
+			name:     "stable",
+			flags:    []string{"-s", "-k", "2,2"},
+			contents: []string{"b x\nd y\n", "a x\nc y\n"},
+			expected: "b x\na x\nd y\nc y\n",
+		},
+		{
+			name:     "unique",
+			flags:    []string{"-u", "-t", ":", "-k", "1,1"},
+			contents: []string{"a:2\nb:1\n", "a:1\nc:3\n"},
+			expected: "a:2\nb:1\nc:3\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"-c"}, tt.flags...)
+			args = append(args, writeFiles(t, tt.contents...)...)
+			var stdout, stderr bytes.Buffer
+			if code := run(args, nil, &stdout, &stderr); code != 0 {
+				t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+			}
+			if s := stdout.String(); s != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, s)
+			}
+		})
+	}
+}
+
+func TestRun_keysErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		flags  []string
+		stderr string
+	}{
+		{name: "tab", flags: []string{"-t", "ab"}, stderr: "-t requires a single character"},
+		{name: "key", flags: []string{"-k", "0"}, stderr: "start position must be positive"},
+		{name: "jsonl", flags: []string{"-jsonl", "-key", "k", "-k", "1"}, stderr: "-jsonl may not be combined with -k or -t"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.flags, strings.NewReader(""), &stdout, &stderr); code != 2 {
+				t.Errorf("Expected exit code 2, got %d", code)
+			}
+			if s := stderr.String(); !strings.Contains(s, tt.stderr) {
+				t.Errorf("Expected stderr containing %q, got %q", tt.stderr, s)
+			}
+		})
+	}
+}
@@ -0,0 +1,368 @@
+// Command kway merges sorted inputs, like `sort -m`, but streaming, using a
+// k-way merge, such that the output begins before the inputs are fully read,
+// and memory use is independent of their size.
+//
+// Usage:
+//
+//	kway [flags] [file ...]
+//
+// Each file is a sorted input, of newline-terminated lines, with "-"
+// denoting standard input, which is also the sole input if no files are
+// provided. Lines are compared bytewise. Equal lines are output in the order
+// of the inputs.
+//
+// The flags are:
+//
+//	-u
+//		Output only the first of each run of equal lines.
+//	-r
+//		Merge inputs sorted in descending order.
+//	-c
+//		Check that each input is sorted, failing otherwise.
+//	-o file
+//		Write the output to file, instead of standard output.
+//	-t char
+//		Separate fields by char, instead of by transitions from blank to
+//		non-blank characters.
+//	-k F[.C][OPTS][,F[.C][OPTS]]
+//		Sort by the key starting at field F, character C, and ending at the
+//		end of the line, or the given position. May be repeated.
+//	-s
+//		Compare lines only by their keys, disabling the last-resort
+//		comparison of whole lines.
+//	-jsonl
+//		Merge newline-delimited JSON, sorted by the field selected by -key.
+//	-key path
+//		The dot-separated path of the field to sort JSON by, e.g. meta.time,
+//		indexing objects by name, and arrays by position.
+//	-key-type type
+//		The type of the JSON field: auto (the default), string, number or
+//		time.
+//
+// Keys are specified as for GNU sort, with fields and characters numbered
+// from 1, and an end character of 0, or none, denoting the end of the field.
+// The OPTS of each key are any of b, to ignore leading blanks, n, to compare
+// numerically, and r, to reverse the comparison. Keys without options
+// inherit -r. As for GNU sort, lines with equal keys are compared whole, as
+// a last resort, unless -s or -u is specified. Consequently, kway may
+// replace `sort -m`, for inputs sorted per the same flags, e.g.
+//
+//	kway -t , -k 2,2n -k 1,1r a.csv b.csv
+//
+// In JSONL mode, the field is compared per its type. Numbers are compared
+// numerically, and times, which are strings formatted per RFC 3339, are
+// compared chronologically. For the auto type, strings are compared as times
+// if they are formatted as such, and values of different types are ordered
+// null, boolean, number, time, then string. Missing fields compare as null,
+// and blank lines are skipped. With -u, only the first of each run of lines
+// with equal fields is output.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"strings"
+
+	"github.com/joeycumines/go-kway"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// config is the configuration of a merge, per the flags.
+type config struct {
+	unique  bool
+	reverse bool
+	check   bool
+	output  string
+	jsonl   bool
+	key     string
+	keyType string
+	stable  bool
+	tab     int
+	keys    []keySpec
+}
+
+// run runs the command, returning the exit code: 0 on success, 1 if the
+// merge failed, or 2 if the arguments are invalid.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("kway", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: kway [flags] [file ...]\n\nMerges sorted inputs, reading standard input if no files are provided.\n\n")
+		flags.PrintDefaults()
+	}
+	c := config{tab: -1}
+	flags.BoolVar(&c.unique, "u", false, "output only the first of each run of equal lines")
+	flags.BoolVar(&c.reverse, "r", false, "merge inputs sorted in descending order")
+	flags.BoolVar(&c.check, "c", false, "check that each input is sorted, failing otherwise")
+	flags.StringVar(&c.output, "o", "", "write the output to `file`, instead of standard output")
+	flags.BoolVar(&c.stable, "s", false, "compare lines only by their keys, disabling the last-resort comparison of whole lines")
+	flags.Func("t", "separate fields by `char`, instead of by transitions from blank to non-blank characters", func(s string) (err error) {
+		c.tab, err = parseTab(s)
+		return err
+	})
+	flags.Func("k", "sort by the key `F[.C][OPTS][,F[.C][OPTS]]`, which may be repeated, see GNU sort", func(s string) error {
+		k, err := parseKeySpec(s)
+		c.keys = append(c.keys, k)
+		return err
+	})
+	flags.BoolVar(&c.jsonl, "jsonl", false, "merge newline-delimited JSON, sorted by the field selected by -key")
+	flags.StringVar(&c.key, "key", "", "the dot-separated `path` of the field to sort JSON by, e.g. meta.time")
+	flags.StringVar(&c.keyType, "key-type", "auto", "the `type` of the JSON field: auto, string, number or time")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if err := c.validate(); err != nil {
+		fmt.Fprintf(stderr, "kway: %v\n", err)
+		flags.Usage()
+		return 2
+	}
+
+	if err := c.run(flags.Args(), stdin, stdout); err != nil {
+		fmt.Fprintf(stderr, "kway: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// input is an open input.
+type input struct {
+	name string
+	r    io.Reader
+	err  error
+}
+
+// record is a line of an input, and its keys, if it is parsed.
+type record struct {
+	line string
+	// n is the line number
+	n    int
+	keys []value
+	// fields are the keys extracted per the -k flags
+	fields []string
+}
+
+// records returns the records of the input, parsed from each line, without
+// its terminator, stopping at the first read or parse error, which is
+// recorded. Lines for which parse returns false are skipped.
+func (x *input) records(parse func(line string) (record, bool, error)) iter.Seq[record] {
+	return func(yield func(record) bool) {
+		br := bufio.NewReader(x.r)
+		for n := 1; ; n++ {
+			line, err := br.ReadString('\n')
+			if line != "" {
+				r, ok, err := parse(strings.TrimSuffix(line, "\n"))
+				if err != nil {
+					x.err = fmt.Errorf("%s: line %d: %w", x.name, n, err)
+					return
+				}
+				r.n = n
+				if ok && !yield(r) {
+					return
+				}
+			}
+			if err != nil {
+				if err != io.EOF {
+					x.err = fmt.Errorf("%s: %w", x.name, err)
+				}
+				return
+			}
+		}
+	}
+}
+
+func (c *config) run(names []string, stdin io.Reader, stdout io.Writer) (err error) {
+	if len(names) == 0 {
+		names = []string{"-"}
+	}
+	inputs := make([]*input, len(names))
+	files := make([]*os.File, 0, len(names))
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	var stdinUsed bool
+	for i, name := range names {
+		if name == "-" {
+			if stdinUsed {
+				return errors.New("standard input may only be read once")
+			}
+			stdinUsed = true
+			inputs[i] = &input{name: "standard input", r: stdin}
+			continue
+		}
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+		inputs[i] = &input{name: name, r: f}
+	}
+
+	out := stdout
+	if c.output != "" {
+		if err := checkOutput(c.output, files); err != nil {
+			return err
+		}
+		f, err := os.Create(c.output)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if e := f.Close(); err == nil {
+				err = e
+			}
+		}()
+		out = f
+	}
+
+	w := bufio.NewWriter(out)
+	if err := c.merge(w, inputs); err != nil {
+		w.Flush()
+		return err
+	}
+	return w.Flush()
+}
+
+// checkOutput returns an error if the output file is also an input, which
+// would be truncated before it is read.
+func checkOutput(name string, files []*os.File) error {
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil
+	}
+	for _, f := range files {
+		if v, err := f.Stat(); err == nil && os.SameFile(info, v) {
+			return fmt.Errorf("output file %s is also an input", name)
+		}
+	}
+	return nil
+}
+
+// validate returns an error if the combination of flags is invalid.
+func (c *config) validate() error {
+	switch {
+	case c.jsonl && c.key == "":
+		return errors.New("-jsonl requires -key")
+	case !c.jsonl && c.key != "":
+		return errors.New("-key requires -jsonl")
+	case c.jsonl && (len(c.keys) != 0 || c.tab >= 0):
+		return errors.New("-jsonl may not be combined with -k or -t")
+	}
+	if _, ok := keyTypes[c.keyType]; !ok {
+		return fmt.Errorf("invalid -key-type %q", c.keyType)
+	}
+	return nil
+}
+
+// parser returns the function to parse the lines of the inputs.
+func (c *config) parser() func(line string) (record, bool, error) {
+	if c.jsonl {
+		path, keyType := strings.Split(c.key, "."), keyTypes[c.keyType]
+		return func(line string) (record, bool, error) {
+			return parseJSONL(line, path, keyType)
+		}
+	}
+	if len(c.keys) != 0 {
+		return func(line string) (record, bool, error) {
+			fields := make([]string, len(c.keys))
+			for i := range c.keys {
+				fields[i] = c.keys[i].extract(line, c.tab)
+			}
+			return record{line: line, fields: fields}, true, nil
+		}
+	}
+	return func(line string) (record, bool, error) {
+		return record{line: line}, true, nil
+	}
+}
+
+// compare compares records by their keys, if parsed, or by line. Lines with
+// equal -k keys are compared as a last resort, as by GNU sort, unless
+// disabled by -s or -u.
+func (c *config) compare(a, b record) int {
+	if len(c.keys) != 0 {
+		for i := range c.keys {
+			if v := c.keys[i].compare(a.fields[i], b.fields[i], c.reverse); v != 0 {
+				return v
+			}
+		}
+		if c.stable || c.unique {
+			return 0
+		}
+	}
+	var v int
+	if c.jsonl {
+		v = compareValues(a.keys[0], b.keys[0])
+	} else {
+		v = strings.Compare(a.line, b.line)
+	}
+	if c.reverse {
+		return -v
+	}
+	return v
+}
+
+// merge merges the lines of the inputs, writing them to w.
+func (c *config) merge(w *bufio.Writer, inputs []*input) error {
+	parse := c.parser()
+	seqs := make([]iter.Seq[record], len(inputs))
+	for i, in := range inputs {
+		seqs[i] = in.records(parse)
+	}
+	m := kway.NewMerger(c.compare)
+	merged := func(yield func(record, error) bool) {
+		for r := range m.Merge(seqs...) {
+			if !yield(r, nil) {
+				return
+			}
+		}
+	}
+	if c.check {
+		merged = m.MergeChecked(seqs...)
+	}
+
+	var (
+		prev  record
+		first = true
+	)
+	for r, err := range merged {
+		if err != nil {
+			var orderErr *kway.OrderError
+			if errors.As(err, &orderErr) {
+				next, prev := orderErr.Next.(record), orderErr.Prev.(record)
+				err = fmt.Errorf("%s: line %d is out of order: %q follows %q",
+					inputs[orderErr.Source].name, next.n, next.line, prev.line)
+			}
+			return err
+		}
+		if c.unique && !first && c.compare(prev, r) == 0 {
+			continue
+		}
+		prev, first = r, false
+		if _, err := w.WriteString(r.line); err != nil {
+			return err
+		}
+		if err := w.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+
+	for _, in := range inputs {
+		if in.err != nil {
+			return in.err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeFiles writes each of the contents to a file in a temporary directory,
+// returning their names.
+func writeFiles(t *testing.T, contents ...string) []string {
+	t.Helper()
+	dir := t.TempDir()
+	names := make([]string, len(contents))
+	for i, content := range contents {
+		names[i] = filepath.Join(dir, string(rune('a'+i)))
+		if err := os.WriteFile(names[i], []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return names
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    []string
+		contents []string
+		stdin    string
+		expected string
+	}{
+		{
+			name:     "files",
+			contents: []string{"a\nc\ne\n", "b\nd\n"},
+			expected: "a\nb\nc\nd\ne\n",
+		},
+		{
+			name:     "missing terminator",
+			contents: []string{"a\nc", "b"},
+			expected: "a\nb\nc\n",
+		},
+		{
+			name:     "stdin",
+			stdin:    "a\nb\n",
+			expected: "a\nb\n",
+		},
+		{
+			name:     "unique",
+			flags:    []string{"-u"},
+			contents: []string{"a\na\nb\n", "a\nb\nc\n"},
+			expected: "a\nb\nc\n",
+		},
+		{
+			name:     "reverse",
+			flags:    []string{"-r"},
+			contents: []string{"e\nc\na\n", "d\nb\n"},
+			expected: "e\nd\nc\nb\na\n",
+		},
+		{
+			name:     "reverse unique",
+			flags:    []string{"-r", "-u"},
+			contents: []string{"b\na\n", "b\n"},
+			expected: "b\na\n",
+		},
+		{
+			name:     "check",
+			flags:    []string{"-c"},
+			contents: []string{"a\nb\n", "a\n"},
+			expected: "a\na\nb\n",
+		},
+		{
+			name:     "empty lines",
+			contents: []string{"\nb\n", "\n\n"},
+			expected: "\n\n\nb\n",
+		},
+		{
+			name:     "empty",
+			contents: []string{"", ""},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := slices.Concat(tt.flags, writeFiles(t, tt.contents...))
+			var stdout, stderr bytes.Buffer
+			if code := run(args, strings.NewReader(tt.stdin), &stdout, &stderr); code != 0 {
+				t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+			}
+			if s := stdout.String(); s != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, s)
+			}
+		})
+	}
+}
+
+func TestRun_stdinArgument(t *testing.T) {
+	names := writeFiles(t, "a\nc\n")
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{names[0], "-"}, strings.NewReader("b\n"), &stdout, &stderr); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	if s := stdout.String(); s != "a\nb\nc\n" {
+		t.Errorf("Unexpected output: %q", s)
+	}
+}
+
+func TestRun_output(t *testing.T) {
+	names := writeFiles(t, "a\nc\n", "b\n")
+	output := filepath.Join(filepath.Dir(names[0]), "out")
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-o", output, names[0], names[1]}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	if b, err := os.ReadFile(output); err != nil || string(b) != "a\nb\nc\n" {
+		t.Errorf("Unexpected output: %q, %v", b, err)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("Unexpected stdout: %q", stdout.String())
+	}
+}
+
+func TestRun_errors(t *testing.T) {
+	names := writeFiles(t, "a\nc\nb\n", "b\n")
+
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  string
+		code   int
+		stderr string
+	}{
+		{name: "unsorted", args: []string{"-c", names[0], names[1]}, code: 1, stderr: "kway: " + names[0] + `: line 3 is out of order: "b" follows "c"` + "\n"},
+		{name: "missing", args: []string{filepath.Join(filepath.Dir(names[0]), "missing")}, code: 1, stderr: "no such file"},
+		{name: "output is input", args: []string{"-o", names[1], names[0], names[1]}, code: 1, stderr: "is also an input"},
+		{name: "stdin twice", args: []string{"-", "-"}, code: 1, stderr: "standard input may only be read once"},
+		{name: "unknown flag", args: []string{"-z"}, code: 2, stderr: "flag provided but not defined"},
+		{name: "help", args: []string{"-h"}, code: 0, stderr: "usage: kway"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr); code != tt.code {
+				t.Errorf("Expected exit code %d, got %d", tt.code, code)
+			}
+			if s := stderr.String(); !strings.Contains(s, tt.stderr) {
+				t.Errorf("Expected stderr containing %q, got %q", tt.stderr, s)
+			}
+		})
+	}
+
+	if b, err := os.ReadFile(names[1]); err != nil || string(b) != "b\n" {
+		t.Errorf("Expected input to be preserved, got %q, %v", b, err)
+	}
+}
+
+func TestRun_readError(t *testing.T) {
+	stdin := &errorReader{r: strings.NewReader("a\nb\n"), err: errors.New("failed")}
+	var stdout, stderr bytes.Buffer
+	if code := run(nil, stdin, &stdout, &stderr); code != 1 {
+		t.Errorf("Expected exit code 1, got %d", code)
+	}
+	if s := stderr.String(); s != "kway: standard input: failed\n" {
+		t.Errorf("Unexpected stderr: %q", s)
+	}
+	if s := stdout.String(); s != "a\nb\n" {
+		t.Errorf("Unexpected output: %q", s)
+	}
+}
+
+type errorReader struct {
+	r   *strings.Reader
+	err error
+}
+
+func (x *errorReader) Read(p []byte) (int, error) {
+	if x.r.Len() == 0 {
+		return 0, x.err
+	}
+	return x.r.Read(p)
+}
@@ -0,0 +1,101 @@
+package kway
+
+import (
+	"iter"
+)
+
+// AppendMerged performs a k-way merge of the provided sorted input sequences,
+// per [Merge], appending the output to `dst`, and returning the extended
+// slice. If the total number of elements is known, `dst` may be preallocated
+// with sufficient capacity, avoiding any growth of the result.
+func AppendMerged[T any](dst []T, cmp func(a, b T) int, seqs ...iter.Seq[T]) []T {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	for v := range Merge(cmp, seqs...) {
+		dst = append(dst, v)
+	}
+	return dst
+}
+
+// MergeInto performs a k-way merge of the provided sorted input sequences,
+// per [Merge], writing the output to `dst`, starting at index 0, and
+// returning the resulting slice. The capacity of `dst` is reused, and the
+// slice is grown only if the output does not fit. It is equivalent to
+// AppendMerged(dst[:0], cmp, seqs...).
+func MergeInto[T any](dst []T, cmp func(a, b T) int, seqs ...iter.Seq[T]) []T {
+	return AppendMerged(dst[:0], cmp, seqs...)
+}
+
+// MergeToSlice performs a k-way merge of the provided sorted input sequences,
+// per [Merge], returning the output as a slice, which is nil if there are no
+// elements. Elements that compare equal are ordered by input sequence, as the
+// merge is stable.
+func MergeToSlice[T any](cmp func(a, b T) int, seqs ...iter.Seq[T]) []T {
+	return AppendMerged(nil, cmp, seqs...)
+}
+
+// DuplicatePolicy determines how [Merge2ToMap] and [Merge2Dedup] handle keys
+// that occur more than once.
+type DuplicatePolicy int
+
+const (
+	// KeepFirst keeps the value of the first occurrence of each key, in
+	// merged order. As the merge is stable, for keys that compare equal, this
+	// is the value from the lowest-indexed input sequence.
+	KeepFirst DuplicatePolicy = iota
+	// KeepLast keeps the value of the last occurrence of each key, in merged
+	// order, i.e. from the highest-indexed input sequence, for keys that
+	// compare equal.
+	KeepLast
+	// RejectDuplicates stops at the second occurrence of any key, reporting a
+	// [*DuplicateKeyError].
+	RejectDuplicates
+)
+
+// Merge2ToMap performs a k-way merge of the provided sorted input sequences,
+// per [Merge2], collecting the output into a map, with duplicate keys handled
+// according to `policy`. Keys are duplicates if they are equal, per the ==
+// operator, regardless of `cmp`, though they are typically expected to
+// compare equal. An error is only returned for [RejectDuplicates], in which
+// case the map contains the elements merged prior to the duplicate.
+func Merge2ToMap[K comparable, V any](cmp func(a1 K, a2 V, b1 K, b2 V) int, policy DuplicatePolicy, seqs ...iter.Seq2[K, V]) (map[K]V, error) {
+	if policy < KeepFirst || policy > RejectDuplicates {
+		panic("kway: invalid duplicate policy")
+	}
+	m := NewMerger2(cmp)
+	result := make(map[K]V)
+	var sources map[K]int
+	if policy == RejectDuplicates {
+		sources = make(map[K]int)
+	}
+	for v := range m.merge(seqs, false, panicError) {
+		switch policy {
+		case KeepFirst:
+			if _, ok := result[v.v1]; ok {
+				continue
+			}
+		case KeepLast:
+		case RejectDuplicates:
+			if first, ok := sources[v.v1]; ok {
+				return result, &DuplicateKeyError{Key: v.v1, First: first, Second: v.i}
+			}
+			sources[v.v1] = v.i
+		}
+		result[v.v1] = v.v2
+	}
+	return result, nil
+}
+
+// Merge2ToGroupedMap performs a k-way merge of the provided sorted input
+// sequences, per [Merge2], grouping the values by key. The values of each key
+// are in merged order, which, as the merge is stable, orders values that
+// compare equal by input sequence. Keys are grouped if they are equal, per
+// the == operator.
+func Merge2ToGroupedMap[K comparable, V any](cmp func(a1 K, a2 V, b1 K, b2 V) int, seqs ...iter.Seq2[K, V]) map[K][]V {
+	result := make(map[K][]V)
+	for k, v := range Merge2(cmp, seqs...) {
+		result[k] = append(result[k], v)
+	}
+	return result
+}
@@ -0,0 +1,169 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestAppendMerged(t *testing.T) {
+	tests := []struct {
+		name     string
+		dst      []int
+		input    [][]int
+		expected []int
+	}{
+		{
+			name:     "nil destination",
+			dst:      nil,
+			input:    [][]int{{1, 3}, {2}},
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:     "existing elements",
+			dst:      []int{9, 9},
+			input:    [][]int{{1, 3}, nil, {2}},
+			expected: []int{9, 9, 1, 2, 3},
+		},
+		{
+			name:     "no sequences",
+			dst:      []int{9},
+			input:    nil,
+			expected: []int{9},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seqs []iter.Seq[int]
+			for _, s := range tt.input {
+				if s == nil {
+					seqs = append(seqs, nil)
+				} else {
+					seqs = append(seqs, sliceSeq(s))
+				}
+			}
+			if result := AppendMerged(tt.dst, cmp.Compare[int], seqs...); !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestAppendMerged_Preallocated(t *testing.T) {
+	dst := make([]int, 0, 5)
+	result := AppendMerged(dst, cmp.Compare[int], sliceSeq([]int{1, 4}), sliceSeq([]int{2, 3, 5}))
+	if !slices.Equal(result, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Expected [1 2 3 4 5], got %v", result)
+	}
+	if &result[0] != &dst[:1][0] {
+		t.Error("Expected the destination to be reused")
+	}
+}
+
+func TestMergeInto(t *testing.T) {
+	dst := []int{7, 8, 9, 10}
+	result := MergeInto(dst, cmp.Compare[int], sliceSeq([]int{1, 3}), sliceSeq([]int{2}))
+	if !slices.Equal(result, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", result)
+	}
+	if !slices.Equal(dst, []int{1, 2, 3, 10}) {
+		t.Errorf("Expected the destination to be overwritten, got %v", dst)
+	}
+
+	if result := MergeInto(nil, cmp.Compare[int], sliceSeq([]int{1})); !slices.Equal(result, []int{1}) {
+		t.Errorf("Expected [1], got %v", result)
+	}
+}
+
+func TestAppendMerged_NilCompareFunction(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil comparison function")
+		}
+	}()
+	AppendMerged[int](nil, nil)
+}
+
+func TestMergeToSlice(t *testing.T) {
+	if result := MergeToSlice(cmp.Compare[int], sliceSeq([]int{1, 3}), sliceSeq([]int{2, 3})); !slices.Equal(result, []int{1, 2, 3, 3}) {
+		t.Errorf("Expected [1 2 3 3], got %v", result)
+	}
+	if result := MergeToSlice[int](cmp.Compare[int]); result != nil {
+		t.Errorf("Expected nil, got %v", result)
+	}
+}
+
+func TestMerge2ToMap(t *testing.T) {
+	cmpFunc := func(a1 string, a2 int, b1 string, b2 int) int { return cmp.Compare(a1, b1) }
+	input := func() []iter.Seq2[string, int] {
+		return []iter.Seq2[string, int]{
+			sliceSeq2([]string{"a", "b", "d"}, []int{1, 2, 4}),
+			nil,
+			sliceSeq2([]string{"b", "c"}, []int{20, 30}),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		policy   DuplicatePolicy
+		expected map[string]int
+		err      *DuplicateKeyError
+	}{
+		{
+			name:     "keep first",
+			policy:   KeepFirst,
+			expected: map[string]int{"a": 1, "b": 2, "c": 30, "d": 4},
+		},
+		{
+			name:     "keep last",
+			policy:   KeepLast,
+			expected: map[string]int{"a": 1, "b": 20, "c": 30, "d": 4},
+		},
+		{
+			name:     "reject duplicates",
+			policy:   RejectDuplicates,
+			expected: map[string]int{"a": 1, "b": 2},
+			err:      &DuplicateKeyError{Key: "b", First: 0, Second: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Merge2ToMap(cmpFunc, tt.policy, input()...)
+			if !maps.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+			if tt.err == nil {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			} else if e, ok := err.(*DuplicateKeyError); !ok || *e != *tt.err {
+				t.Errorf("Expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestMerge2ToMap_InvalidPolicy(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for invalid policy")
+		}
+	}()
+	Merge2ToMap(func(a1, a2, b1, b2 int) int { return 0 }, DuplicatePolicy(-1))
+}
+
+func TestMerge2ToGroupedMap(t *testing.T) {
+	cmpFunc := func(a1 string, a2 int, b1 string, b2 int) int { return cmp.Compare(a1, b1) }
+	result := Merge2ToGroupedMap(cmpFunc,
+		sliceSeq2([]string{"a", "b", "b"}, []int{1, 2, 3}),
+		sliceSeq2([]string{"b", "c"}, []int{20, 30}),
+	)
+	expected := map[string][]int{"a": {1}, "b": {2, 3, 20}, "c": {30}}
+	if !maps.EqualFunc(result, expected, slices.Equal) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
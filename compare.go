@@ -0,0 +1,242 @@
+package kway
+
+import (
+	"cmp"
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Float is a constraint permitting any floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// ErrNaN is the panic value of [CompareFloatNoNaN], when either operand is
+// NaN.
+var ErrNaN = errors.New("kway: NaN compared")
+
+// CompareFloatNaNFirst is a comparison function for floating-point values,
+// implementing a total order in which NaN values are less than any other
+// value, and equal to each other. It is equivalent to [cmp.Compare].
+//
+// As with [cmp.Compare], -0.0 and 0.0 are equal. Note that the comparison
+// operators, e.g. <, do not implement a total order, on account of NaN
+// values, and must not be used to implement comparison functions for input
+// sequences that may contain NaN.
+func CompareFloatNaNFirst[F Float](a, b F) int {
+	return cmp.Compare(a, b)
+}
+
+// CompareFloatNaNLast is a comparison function for floating-point values,
+// implementing a total order in which NaN values are greater than any other
+// value, and equal to each other.
+//
+// As with [cmp.Compare], -0.0 and 0.0 are equal.
+func CompareFloatNaNLast[F Float](a, b F) int {
+	aNaN, bNaN := a != a, b != b
+	switch {
+	case aNaN && bNaN:
+		return 0
+	case aNaN:
+		return 1
+	case bNaN:
+		return -1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// CompareFloatNoNaN is a comparison function for floating-point values, which
+// panics with [ErrNaN] if either value is NaN. Otherwise, it is equivalent to
+// [cmp.Compare].
+func CompareFloatNoNaN[F Float](a, b F) int {
+	if a != a || b != b {
+		panic(ErrNaN)
+	}
+	return cmp.Compare(a, b)
+}
+
+// CompareFloatEpsilon returns a comparison function for floating-point
+// values, in which values that differ by no more than `epsilon` are equal,
+// e.g. to merge streams of measurements, for which exact equality is not
+// meaningful. Otherwise, values are ordered per [CompareFloatNaNLast].
+//
+// Values within epsilon of each other are equal, so their relative order in
+// the output of a merge is determined by input sequence, per the stability
+// of [Merge], unless a tie break is configured, e.g. using [WithTieBreak].
+// Note that such equality is not transitive: for an epsilon of 1, 0 and 2 are
+// each equal to 1, but not to each other. Consequently, the output of a
+// merge is sorted per [cmp.Compare] only to within epsilon, and such a
+// comparator may fail the checks of [WithComparatorCheck].
+func CompareFloatEpsilon[F Float](epsilon F) func(a, b F) int {
+	if epsilon < 0 || epsilon != epsilon {
+		panic("kway: invalid epsilon")
+	}
+	return func(a, b F) int {
+		if a != a || b != b {
+			return CompareFloatNaNLast(a, b)
+		}
+		if d := a - b; d == 0 || (d <= epsilon && d >= -epsilon) {
+			return 0
+		}
+		return cmp.Compare(a, b)
+	}
+}
+
+// CompareASCIIFold is a comparison function for strings, ignoring the case
+// of ASCII letters, comparing bytes as if each upper-case ASCII letter were
+// lower-case. Other bytes, including those of non-ASCII characters, are
+// compared as-is.
+//
+// Strings that differ only in the case of ASCII letters are equal, so their
+// relative order in the output of a merge is determined by input sequence,
+// per the stability of [Merge]. This keeps the merge stable for input
+// sequences sorted case-insensitively, in which the order of such strings is
+// arbitrary. To instead order them consistently, e.g. upper-case first,
+// configure a tie break, such as [WithTieBreak]([strings.Compare]), in which
+// case the input sequences must be sorted accordingly.
+func CompareASCIIFold(a, b string) int {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		ca, cb := a[i], b[i]
+		if ca == cb {
+			continue
+		}
+		if 'A' <= ca && ca <= 'Z' {
+			ca += 'a' - 'A'
+		}
+		if 'A' <= cb && cb <= 'Z' {
+			cb += 'a' - 'A'
+		}
+		if ca != cb {
+			return cmp.Compare(ca, cb)
+		}
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+// CompareFold is a comparison function for UTF-8 strings, ignoring case, per
+// Unicode simple case folding, as used by [strings.EqualFold]. Characters are
+// compared by a canonical member of their case folding orbit: the lower-case
+// letter, for orbits that contain an ASCII letter, otherwise the member with
+// the lowest code point. For ASCII strings, it is equivalent to
+// [CompareASCIIFold]. Invalid UTF-8 bytes order after all characters, by
+// byte value.
+//
+// As with [CompareASCIIFold], strings that are equal under folding compare
+// equal, leaving their relative order to the stability of the merge.
+func CompareFold(a, b string) int {
+	for a != "" && b != "" {
+		if ca, cb := a[0], b[0]; ca|cb < utf8.RuneSelf {
+			if ca != cb {
+				if 'A' <= ca && ca <= 'Z' {
+					ca += 'a' - 'A'
+				}
+				if 'A' <= cb && cb <= 'Z' {
+					cb += 'a' - 'A'
+				}
+				if ca != cb {
+					return cmp.Compare(ca, cb)
+				}
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		ra, na := foldKey(a)
+		rb, nb := foldKey(b)
+		if ra != rb {
+			return cmp.Compare(ra, rb)
+		}
+		a, b = a[na:], b[nb:]
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+// foldKey returns the canonical folded form of the first character of s, and
+// its length in bytes.
+func foldKey(s string) (rune, int) {
+	if c := s[0]; c < utf8.RuneSelf {
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		return rune(c), 1
+	}
+	r, n := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError && n == 1 {
+		return unicode.MaxRune + 1 + rune(s[0]), 1
+	}
+	key := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if 'a' <= f && f <= 'z' {
+			return f, n
+		}
+		key = min(key, f)
+	}
+	return key, n
+}
+
+// CompareNatural is a comparison function for strings, implementing natural
+// order, in which runs of ASCII digits are compared numerically, e.g.
+// "file2" < "file10", and other bytes are compared as-is. Numbers may be of
+// any length, as they are compared without conversion, ignoring leading
+// zeros.
+//
+// Strings that are equal apart from leading zeros, e.g. "v01" and "v1", are
+// ordered by [strings.Compare], such that CompareNatural only returns 0 for
+// identical strings. It does not allocate.
+func CompareNatural(a, b string) int {
+	// skip the common prefix, backing up to the start of any digit run
+	var k int
+	for n := min(len(a), len(b)); k < n && a[k] == b[k]; k++ {
+	}
+	for k > 0 && isDigit(a[k-1]) {
+		k--
+	}
+	i, j := k, k
+	for i < len(a) && j < len(b) {
+		ca, cb := a[i], b[j]
+		if !isDigit(ca) || !isDigit(cb) {
+			if ca != cb {
+				return cmp.Compare(ca, cb)
+			}
+			i++
+			j++
+			continue
+		}
+
+		// skip leading zeros
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+		// find the end of each number
+		ei, ej := i, j
+		for ei < len(a) && isDigit(a[ei]) {
+			ei++
+		}
+		for ej < len(b) && isDigit(b[ej]) {
+			ej++
+		}
+		// longer numbers are larger, otherwise compare digit by digit
+		if v := cmp.Compare(ei-i, ej-j); v != 0 {
+			return v
+		}
+		if v := strings.Compare(a[i:ei], b[j:ej]); v != 0 {
+			return v
+		}
+		i, j = ei, ej
+	}
+	if v := cmp.Compare(len(a)-i, len(b)-j); v != 0 {
+		return v
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
@@ -0,0 +1,269 @@
+package kway
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestCompareFloat(t *testing.T) {
+	nan := math.NaN()
+	inf := math.Inf(1)
+
+	tests := []struct {
+		name     string
+		a, b     float64
+		nanFirst int
+		nanLast  int
+	}{
+		{name: "less", a: 1, b: 2, nanFirst: -1, nanLast: -1},
+		{name: "greater", a: 2, b: 1, nanFirst: 1, nanLast: 1},
+		{name: "equal", a: 1, b: 1, nanFirst: 0, nanLast: 0},
+		{name: "signed zeros", a: math.Copysign(0, -1), b: 0, nanFirst: 0, nanLast: 0},
+		{name: "nan vs number", a: nan, b: 1, nanFirst: -1, nanLast: 1},
+		{name: "number vs nan", a: 1, b: nan, nanFirst: 1, nanLast: -1},
+		{name: "nan vs inf", a: nan, b: inf, nanFirst: -1, nanLast: 1},
+		{name: "nan vs -inf", a: nan, b: -inf, nanFirst: -1, nanLast: 1},
+		{name: "nan vs nan", a: nan, b: nan, nanFirst: 0, nanLast: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if v := CompareFloatNaNFirst(tt.a, tt.b); v != tt.nanFirst {
+				t.Errorf("CompareFloatNaNFirst(%v, %v) = %d, want %d", tt.a, tt.b, v, tt.nanFirst)
+			}
+			if v := CompareFloatNaNLast(tt.a, tt.b); v != tt.nanLast {
+				t.Errorf("CompareFloatNaNLast(%v, %v) = %d, want %d", tt.a, tt.b, v, tt.nanLast)
+			}
+			if v := CompareFloatNaNLast(float32(tt.a), float32(tt.b)); v != tt.nanLast {
+				t.Errorf("CompareFloatNaNLast[float32](%v, %v) = %d, want %d", tt.a, tt.b, v, tt.nanLast)
+			}
+		})
+	}
+}
+
+func TestCompareFloat_Merge(t *testing.T) {
+	nan := math.NaN()
+	seq1 := sliceSeq([]float64{1, 3, nan})
+	seq2 := sliceSeq([]float64{2, nan})
+
+	result := collectSeq(Merge(CompareFloatNaNLast[float64], seq1, seq2))
+	if len(result) != 5 || !slices.Equal(result[:3], []float64{1, 2, 3}) || !math.IsNaN(result[3]) || !math.IsNaN(result[4]) {
+		t.Errorf("Expected [1 2 3 NaN NaN], got %v", result)
+	}
+}
+
+func TestCompareFloatNoNaN(t *testing.T) {
+	if v := CompareFloatNoNaN(1.0, 2.0); v != -1 {
+		t.Errorf("Expected -1, got %d", v)
+	}
+	for _, args := range [][2]float64{{math.NaN(), 1}, {1, math.NaN()}} {
+		func() {
+			defer func() {
+				if r := recover(); r != ErrNaN {
+					t.Errorf("Expected panic with ErrNaN, got %v", r)
+				}
+			}()
+			CompareFloatNoNaN(args[0], args[1])
+		}()
+	}
+}
+
+func TestCompareFloatEpsilon(t *testing.T) {
+	nan := math.NaN()
+	inf := math.Inf(1)
+	compare := CompareFloatEpsilon(0.5)
+
+	tests := []struct {
+		name     string
+		a, b     float64
+		expected int
+	}{
+		{name: "equal", a: 1, b: 1, expected: 0},
+		{name: "within epsilon", a: 1, b: 1.25, expected: 0},
+		{name: "at epsilon", a: 1, b: 1.5, expected: 0},
+		{name: "beyond epsilon", a: 1, b: 1.75, expected: -1},
+		{name: "negative", a: -3, b: -1, expected: -1},
+		{name: "infinities", a: inf, b: inf, expected: 0},
+		{name: "inf vs number", a: inf, b: 1e300, expected: 1},
+		{name: "nan vs nan", a: nan, b: nan, expected: 0},
+		{name: "nan vs inf", a: nan, b: inf, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if v := compare(tt.a, tt.b); v != tt.expected {
+				t.Errorf("compare(%v, %v) = %d, want %d", tt.a, tt.b, v, tt.expected)
+			}
+			if v := compare(tt.b, tt.a); v != -tt.expected {
+				t.Errorf("compare(%v, %v) = %d, want %d", tt.b, tt.a, v, -tt.expected)
+			}
+		})
+	}
+
+	for _, epsilon := range []float64{-1, nan} {
+		func() {
+			defer func() {
+				if r := recover(); r != "kway: invalid epsilon" {
+					t.Errorf("Expected panic for epsilon %v, got %v", epsilon, r)
+				}
+			}()
+			CompareFloatEpsilon(epsilon)
+		}()
+	}
+}
+
+func TestCompareFloatEpsilon_Merge(t *testing.T) {
+	result := collectSeq(Merge(CompareFloatEpsilon(0.01),
+		sliceSeq([]float64{1.000, 2.005, 3}),
+		sliceSeq([]float64{1.001, 2.000, 4}),
+	))
+	// ties within epsilon are broken by source order
+	expected := []float64{1.000, 1.001, 2.005, 2.000, 3, 4}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestCompareFold(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        string
+		asciiFold   int
+		unicodeFold int
+	}{
+		{name: "equal", a: "abc", b: "abc", asciiFold: 0, unicodeFold: 0},
+		{name: "case", a: "ABC", b: "abc", asciiFold: 0, unicodeFold: 0},
+		{name: "less", a: "Apple", b: "banana", asciiFold: -1, unicodeFold: -1},
+		{name: "greater", a: "b", b: "A", asciiFold: 1, unicodeFold: 1},
+		{name: "prefix", a: "ab", b: "ABC", asciiFold: -1, unicodeFold: -1},
+		{name: "empty", a: "", b: "a", asciiFold: -1, unicodeFold: -1},
+		{name: "punctuation after letters", a: "_", b: "A", asciiFold: -1, unicodeFold: -1},
+		{name: "non-ascii case", a: "ÉCOLE", b: "école", asciiFold: -1, unicodeFold: 0},
+		{name: "kelvin sign", a: "\u212a", b: "k", asciiFold: 1, unicodeFold: 0},
+		{name: "long s", a: "\u017f", b: "S", asciiFold: 1, unicodeFold: 0},
+		{name: "sigma forms", a: "σ", b: "ς", asciiFold: 1, unicodeFold: 0},
+		{name: "non-ascii order", a: "é", b: "z", asciiFold: 1, unicodeFold: 1},
+		{name: "invalid utf-8", a: "\xff", b: "\u00e9", asciiFold: 1, unicodeFold: 1},
+		{name: "invalid utf-8 bytes", a: "\xfe", b: "\xff", asciiFold: -1, unicodeFold: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if v := CompareASCIIFold(tt.a, tt.b); v != tt.asciiFold {
+				t.Errorf("CompareASCIIFold(%q, %q) = %d, want %d", tt.a, tt.b, v, tt.asciiFold)
+			}
+			if v := CompareASCIIFold(tt.b, tt.a); v != -tt.asciiFold {
+				t.Errorf("CompareASCIIFold(%q, %q) = %d, want %d", tt.b, tt.a, v, -tt.asciiFold)
+			}
+			if v := CompareFold(tt.a, tt.b); v != tt.unicodeFold {
+				t.Errorf("CompareFold(%q, %q) = %d, want %d", tt.a, tt.b, v, tt.unicodeFold)
+			}
+			if v := CompareFold(tt.b, tt.a); v != -tt.unicodeFold {
+				t.Errorf("CompareFold(%q, %q) = %d, want %d", tt.b, tt.a, v, -tt.unicodeFold)
+			}
+			if eq := strings.EqualFold(tt.a, tt.b); utf8.ValidString(tt.a) && utf8.ValidString(tt.b) && eq != (tt.unicodeFold == 0) {
+				t.Errorf("Expected CompareFold equality to match strings.EqualFold (%v)", eq)
+			}
+		})
+	}
+}
+
+func TestCompareFold_Merge(t *testing.T) {
+	for name, compare := range map[string]func(a, b string) int{
+		"ascii":   CompareASCIIFold,
+		"unicode": CompareFold,
+	} {
+		t.Run(name, func(t *testing.T) {
+			result := collectSeq(Merge(compare,
+				sliceSeq([]string{"apple", "Banana", "CHERRY"}),
+				sliceSeq([]string{"Apple", "banana", "cherry"}),
+			))
+			// ties are ordered by input sequence
+			expected := []string{"apple", "Apple", "Banana", "banana", "CHERRY", "cherry"}
+			if !slices.Equal(result, expected) {
+				t.Errorf("Expected %v, got %v", expected, result)
+			}
+
+			result = collectSeq(NewMerger(compare, WithTieBreak(strings.Compare)).Merge(
+				sliceSeq([]string{"apple", "Banana"}),
+				sliceSeq([]string{"Apple", "banana"}),
+			))
+			if expected := []string{"Apple", "apple", "Banana", "banana"}; !slices.Equal(result, expected) {
+				t.Errorf("Expected %v, got %v", expected, result)
+			}
+		})
+	}
+}
+
+func BenchmarkCompareFold(b *testing.B) {
+	for name, compare := range map[string]func(a, b string) int{
+		"strings.Compare":  strings.Compare,
+		"CompareASCIIFold": CompareASCIIFold,
+		"CompareFold":      CompareFold,
+	} {
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				compare("The Quick Brown Fox", "the quick brown fog")
+			}
+		})
+	}
+}
+
+func TestCompareNatural(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "a", -1},
+		{"file2", "file10", -1},
+		{"file10", "file10", 0},
+		{"file10a", "file10b", -1},
+		{"file1", "file1a", -1},
+		{"a1b2", "a1b10", -1},
+		{"v1.10", "v1.9", 1},
+		{"v01", "v1", -1},
+		{"v001", "v01", -1},
+		{"x0", "x00", -1},
+		{"a", "1", 1},
+		{"10", "9", 1},
+		{"99999999999999999999999", "100000000000000000000000", -1},
+		{"abc", "abd", -1},
+	}
+
+	for _, tt := range tests {
+		if v := CompareNatural(tt.a, tt.b); v != tt.expected {
+			t.Errorf("CompareNatural(%q, %q) = %d, want %d", tt.a, tt.b, v, tt.expected)
+		}
+		if v := CompareNatural(tt.b, tt.a); v != -tt.expected {
+			t.Errorf("CompareNatural(%q, %q) = %d, want %d", tt.b, tt.a, v, -tt.expected)
+		}
+	}
+}
+
+func TestCompareNatural_Merge(t *testing.T) {
+	result := collectSeq(Merge(CompareNatural,
+		sliceSeq([]string{"file1", "file10", "file100"}),
+		sliceSeq([]string{"file2", "file20"}),
+	))
+	expected := []string{"file1", "file2", "file10", "file20", "file100"}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func BenchmarkCompareNatural(b *testing.B) {
+	for name, compare := range map[string]func(a, b string) int{
+		"strings.Compare": strings.Compare,
+		"CompareNatural":  CompareNatural,
+	} {
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				compare("photo-2024-06-15-00123.jpg", "photo-2024-06-15-00124.jpg")
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package kway
+
+import (
+	"iter"
+	"sort"
+)
+
+// Cursor is a sorted input sequence with a direct pull interface. Unlike
+// sequences, which are pulled via [iter.Pull], merging cursors does not
+// create any goroutines or coroutines. See [MergeCursors].
+type Cursor[T any] interface {
+	// Next returns the next element and true, or false if there are no more
+	// elements. Once it has returned false, it is not called again by the
+	// merge.
+	Next() (T, bool)
+}
+
+// BidiCursor is a [Cursor] that may also step backward. Like a list
+// iterator, a BidiCursor is positioned between elements: Next returns the
+// element after the position, and Prev the element before it, each moving
+// the position past the returned element. Alternating calls to Next and Prev
+// therefore return the same element.
+type BidiCursor[T any] interface {
+	Cursor[T]
+	// Prev returns the previous element and true, or false if the cursor is
+	// positioned at the start.
+	Prev() (T, bool)
+}
+
+// SeekCursor is a [BidiCursor] that may be repositioned by key, in either
+// direction, e.g. to support [MergedCursor.Seek] efficiently.
+type SeekCursor[T any] interface {
+	BidiCursor[T]
+	// Seek positions the cursor before the first element greater than or
+	// equal to key, such that Next returns it, and Prev returns the last
+	// element less than key.
+	Seek(key T)
+}
+
+// CloneCursor is a [BidiCursor] that may be cloned, e.g. to support
+// [MergedCursor.Fork].
+type CloneCursor[T any] interface {
+	BidiCursor[T]
+	// Clone returns an independent cursor, over the same elements, at the
+	// same position.
+	Clone() BidiCursor[T]
+}
+
+// SliceCursor is a [SeekCursor] over a sorted slice, see
+// [SortedSlice.Cursor].
+type SliceCursor[T any] struct {
+	cmp func(a, b T) int
+	s   []T
+	i   int
+}
+
+var (
+	_ SeekCursor[any]  = (*SliceCursor[any])(nil)
+	_ CloneCursor[any] = (*SliceCursor[any])(nil)
+)
+
+// Next returns the next element of the slice.
+func (x *SliceCursor[T]) Next() (v T, ok bool) {
+	if x.i == len(x.s) {
+		return v, false
+	}
+	v = x.s[x.i]
+	x.i++
+	return v, true
+}
+
+// Prev returns the previous element of the slice.
+func (x *SliceCursor[T]) Prev() (v T, ok bool) {
+	if x.i == 0 {
+		return v, false
+	}
+	x.i--
+	return x.s[x.i], true
+}
+
+// Seek positions the cursor before the first element greater than or equal
+// to key, located by exponential search, from the current position, then
+// binary search, such that seeking a distance d costs O(log d) comparisons.
+func (x *SliceCursor[T]) Seek(key T) {
+	x.i = gallop(x.cmp, x.s, x.i, key)
+}
+
+// gallop returns the index of the first element of s greater than or equal
+// to key, searching exponentially outward from i, in either direction.
+func gallop[T any](cmp func(a, b T) int, s []T, i int, key T) int {
+	return gallopIndex(cmp, len(s), func(i int) T { return s[i] }, i, key)
+}
+
+// gallopIndex is the equivalent of gallop, for the n elements accessed by
+// at.
+func gallopIndex[T any](cmp func(a, b T) int, n int, at func(i int) T, i int, key T) int {
+	var lo, hi int
+	if i < n && cmp(at(i), key) < 0 {
+		bound := 1
+		for i+bound < n && cmp(at(i+bound), key) < 0 {
+			bound *= 2
+		}
+		lo, hi = i+bound/2+1, min(i+bound, n)
+	} else {
+		bound := 1
+		for i-bound >= 0 && cmp(at(i-bound), key) >= 0 {
+			bound *= 2
+		}
+		lo, hi = max(i-bound+1, 0), i-bound/2
+	}
+	return lo + sort.Search(hi-lo, func(j int) bool { return cmp(at(lo+j), key) >= 0 })
+}
+
+// Len returns the number of remaining elements, after the position.
+func (x *SliceCursor[T]) Len() int { return len(x.s) - x.i }
+
+// Clone returns a *SliceCursor over the same slice, at the same position.
+func (x *SliceCursor[T]) Clone() BidiCursor[T] {
+	c := *x
+	return &c
+}
+
+// MergeCursors performs a k-way merge of the provided sorted cursors, per
+// [Merger.Merge], without creating any goroutines or coroutines. Nil
+// cursors are ignored.
+//
+// The cursors are consumed by iteration, so the returned sequence is
+// intended to be iterated once. Subsequent iterations continue from the
+// cursors' current positions.
+func (m *Merger[T]) MergeCursors(cursors ...Cursor[T]) iter.Seq[T] {
+	srcs := make([]pullSource[T], len(cursors))
+	var n int
+	for i, c := range cursors {
+		if c != nil {
+			srcs[i] = cursorSource(c)
+			n++
+		}
+	}
+	if n == 0 {
+		return emptySeq[T]
+	}
+	return func(yield func(T) bool) {
+		m, _, err := m.bounded(n, m.opts.verifySorted, 0)
+		if err != nil {
+			panic(err)
+		}
+		for v := range m.merge(srcs, m.opts.verifySorted, panicError) {
+			if !yield(v.v) {
+				return
+			}
+		}
+	}
+}
+
+// MergeCursors performs a k-way merge of the provided sorted cursors, without
+// creating any goroutines or coroutines. It is equivalent to
+// [Merger.MergeCursors], using a [Merger] without options.
+func MergeCursors[T any](cmp func(a, b T) int, cursors ...Cursor[T]) iter.Seq[T] {
+	return NewMerger(cmp).MergeCursors(cursors...)
+}
@@ -0,0 +1,157 @@
+package kway
+
+import (
+	"cmp"
+	"errors"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+func TestMergeCursors(t *testing.T) {
+	type stableValue struct {
+		value int
+		seqID int
+	}
+	cmpFunc := func(a, b stableValue) int { return cmp.Compare(a.value, b.value) }
+
+	tests := []struct {
+		name     string
+		input    [][]stableValue
+		expected []stableValue
+	}{
+		{
+			name:     "no cursors",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:     "single cursor",
+			input:    [][]stableValue{{{1, 0}, {2, 0}}},
+			expected: []stableValue{{1, 0}, {2, 0}},
+		},
+		{
+			name:     "ties by index",
+			input:    [][]stableValue{{{1, 0}, {3, 0}}, {{1, 1}, {2, 1}}, nil, {{3, 3}}},
+			expected: []stableValue{{1, 0}, {1, 1}, {2, 1}, {3, 0}, {3, 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cursors []Cursor[stableValue]
+			for _, s := range tt.input {
+				cursors = append(cursors, NewSortedSlice(cmpFunc, s).Cursor())
+			}
+			if result := collectSeq(MergeCursors(cmpFunc, cursors...)); !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMergeCursors_NilCursors(t *testing.T) {
+	if result := collectSeq(MergeCursors[int](cmp.Compare[int], nil, nil)); result != nil {
+		t.Errorf("Expected nil, got %v", result)
+	}
+	c := NewSortedSlice(cmp.Compare[int], []int{1, 2}).Cursor()
+	if result := collectSeq(MergeCursors(cmp.Compare[int], nil, c, nil)); !slices.Equal(result, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", result)
+	}
+}
+
+func TestMergeCursors_NoGoroutines(t *testing.T) {
+	var cursors []Cursor[int]
+	for range 8 {
+		cursors = append(cursors, NewSortedSlice(cmp.Compare[int], []int{1, 2, 3}).Cursor())
+	}
+	before := runtime.NumGoroutine()
+	for range MergeCursors(cmp.Compare[int], cursors...) {
+		if n := runtime.NumGoroutine(); n != before {
+			t.Fatalf("Expected %d goroutines, got %d", before, n)
+		}
+	}
+}
+
+func TestMergeCursors_Consumed(t *testing.T) {
+	c := NewSortedSlice(cmp.Compare[int], []int{1, 2, 3}).Cursor()
+	seq := MergeCursors(cmp.Compare[int], c)
+	for range seq {
+		break
+	}
+	if c.Len() != 2 {
+		t.Errorf("Expected Len() = 2, got %d", c.Len())
+	}
+	if result := collectSeq(seq); !slices.Equal(result, []int{2, 3}) {
+		t.Errorf("Expected [2 3], got %v", result)
+	}
+}
+
+func TestMergerMergeCursors_Options(t *testing.T) {
+	var stats Stats
+	m := NewMerger(cmp.Compare[int], WithVerifySorted(), WithStats(&stats))
+	a := NewSortedSlice(cmp.Compare[int], []int{1, 3}).Cursor()
+	b := &SliceCursor[int]{s: []int{2, 4, 0}}
+
+	var result []int
+	func() {
+		defer func() {
+			r := recover()
+			var err *OrderError
+			if e, ok := r.(error); !ok || !errors.As(e, &err) {
+				t.Fatalf("Expected *OrderError panic, got %v", r)
+			}
+			if err.Source != 1 || err.Position != 2 {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+		for v := range m.MergeCursors(a, b) {
+			result = append(result, v)
+		}
+	}()
+
+	if !slices.Equal(result, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected [1 2 3 4], got %v", result)
+	}
+	if stats.Sources[0].Pulled != 2 || stats.Sources[1].Pulled != 2 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestSliceCursor_Seek(t *testing.T) {
+	s := []int{1, 1, 2, 3, 3, 3, 5, 8, 8, 13}
+	c := NewSortedSlice(cmp.Compare[int], s).Cursor()
+	for from := 0; from <= len(s); from++ {
+		for key := 0; key <= 14; key++ {
+			c.i = from
+			c.Seek(key)
+			if expected, _ := slices.BinarySearch(s, key); c.i != expected {
+				t.Errorf("Seek(%d) from %d: expected position %d, got %d", key, from, expected, c.i)
+			}
+		}
+	}
+
+	c.i = 0
+	c.Seek(4)
+	if v, ok := c.Next(); !ok || v != 5 {
+		t.Errorf("Expected 5, got %d, %v", v, ok)
+	}
+	if v, ok := c.Prev(); !ok || v != 5 {
+		t.Errorf("Expected 5, got %d, %v", v, ok)
+	}
+	if v, ok := c.Prev(); !ok || v != 3 {
+		t.Errorf("Expected 3, got %d, %v", v, ok)
+	}
+}
+
+func TestSliceCursor_Clone(t *testing.T) {
+	c := NewSortedSlice(cmp.Compare[int], []int{1, 2, 3}).Cursor()
+	c.Next()
+	clone := c.Clone()
+	if v, ok := clone.Next(); !ok || v != 2 {
+		t.Errorf("Expected 2, got %v", v)
+	}
+	if c.Len() != 2 {
+		t.Errorf("Expected the original to be unaffected, got %d remaining", c.Len())
+	}
+}
@@ -0,0 +1,97 @@
+package kway
+
+import (
+	"slices"
+)
+
+// heapArity is the number of children of each node of the d-ary heap used
+// by [StrategyHeap4].
+const heapArity = 4
+
+// dAryHeap is a min-heap of the current elements of the sources, in which
+// node n is the parent of nodes heapArity*n+1 through heapArity*n+heapArity.
+// Compared to a binary heap, it has half as many levels, and the children
+// of each node are contiguous, at the cost of more comparisons per level.
+type dAryHeap[T interface{ index() int }] struct {
+	cmp   func(a, b T) int
+	items []T
+	moves *int64
+}
+
+func (x *dAryHeap[T]) less(a, b T) bool {
+	if v := x.cmp(a, b); v != 0 {
+		return v < 0
+	}
+	// fall back to comparison by index (documented behavior)
+	return a.index() < b.index()
+}
+
+// down restores the heap property, for the element at node n.
+func (x *dAryHeap[T]) down(n int) {
+	items := x.items
+	v := items[n]
+	for {
+		first := heapArity*n + 1
+		if first >= len(items) {
+			break
+		}
+		last := min(first+heapArity, len(items))
+		c := first
+		for i := first + 1; i < last; i++ {
+			if x.less(items[i], items[c]) {
+				c = i
+			}
+		}
+		if !x.less(items[c], v) {
+			break
+		}
+		items[n] = items[c]
+		n = c
+		if x.moves != nil {
+			*x.moves++
+		}
+	}
+	items[n] = v
+}
+
+// mergeDAryHeap implements [mergeState.merge], using a [dAryHeap], with the
+// buffers of s. The top is replaced, in place, by the next element of its
+// source, requiring a single pass down the heap, per element.
+func mergeDAryHeap[T interface{ index() int }](s *mergeState[T], pulls []func() (T, bool), yield func(T) bool) {
+	x := dAryHeap[T]{cmp: s.cmp, items: slices.Grow(s.items[:0], len(pulls)), moves: s.moves}
+	s.items = x.items
+	for i, next := range pulls {
+		if next != nil {
+			if v, ok := next(); ok {
+				x.items = append(x.items, v)
+			} else {
+				pulls[i] = nil
+			}
+		}
+	}
+	if len(x.items) == 0 {
+		return
+	}
+	for n := (len(x.items) - 2) / heapArity; n >= 0; n-- {
+		x.down(n)
+	}
+	for {
+		v := x.items[0]
+		if !yield(v) {
+			return
+		}
+		if next, ok := pulls[v.index()](); ok {
+			x.items[0] = next
+		} else {
+			pulls[v.index()] = nil
+			last := len(x.items) - 1
+			x.items[0] = x.items[last]
+			x.items[last] = *new(T)
+			x.items = x.items[:last]
+			if last == 0 {
+				return
+			}
+		}
+		x.down(0)
+	}
+}
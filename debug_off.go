@@ -0,0 +1,8 @@
+//go:build !kwaydebug
+
+package kway
+
+// debugCheckComparator is the default sampling interval for comparator
+// validation, see [WithComparatorCheck]. Building with the kwaydebug tag
+// enables validation of every comparison, by default.
+const debugCheckComparator = 0
@@ -0,0 +1,5 @@
+//go:build kwaydebug
+
+package kway
+
+const debugCheckComparator = 1
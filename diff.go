@@ -0,0 +1,71 @@
+package kway
+
+import (
+	"iter"
+)
+
+// DiffEntry is the value of a key, from a single input sequence, see
+// [Diff].
+type DiffEntry[V any] struct {
+	// Value is the value, if present.
+	Value V
+	// Present indicates the key is present in the input sequence.
+	Present bool
+}
+
+// Diff performs a k-way merge of the provided sequences, each sorted by key,
+// per `cmp`, yielding each distinct key once, with an entry for each
+// sequence, by index, indicating whether the key is present in it, and with
+// which value, e.g. to check the consistency of N replicas, in a single
+// pass, rather than by pairwise comparison. See also [DiffConsistent].
+//
+// If a key occurs more than once in a sequence, the entry has its first
+// value. The yielded key is the first of those that compare equal, per
+// [Merge2]. The entries are reused, and must be cloned (e.g. via
+// [slices.Clone]) to be retained beyond each iteration.
+func Diff[K any, V any](cmp func(a, b K) int, seqs ...iter.Seq2[K, V]) iter.Seq2[K, []DiffEntry[V]] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	if !anySeq(seqs) {
+		return emptySeq2[K, []DiffEntry[V]]
+	}
+	m := NewMerger2(func(a1 K, _ V, b1 K, _ V) int { return cmp(a1, b1) })
+	return func(yield func(K, []DiffEntry[V]) bool) {
+		var (
+			key K
+			ok  bool
+		)
+		entries := make([]DiffEntry[V], len(seqs))
+		for v := range m.merge(seqs, false, panicError) {
+			if ok && cmp(key, v.v1) != 0 {
+				if !yield(key, entries) {
+					return
+				}
+				clear(entries)
+				ok = false
+			}
+			if !ok {
+				key, ok = v.v1, true
+			}
+			if e := &entries[v.i]; !e.Present {
+				*e = DiffEntry[V]{Value: v.v2, Present: true}
+			}
+		}
+		if ok {
+			yield(key, entries)
+		}
+	}
+}
+
+// DiffConsistent returns true if the key of `entries`, as yielded by
+// [Diff], is present in every input sequence, with values equal per
+// `equal`.
+func DiffConsistent[V any](entries []DiffEntry[V], equal func(a, b V) bool) bool {
+	for _, e := range entries {
+		if !e.Present || !equal(entries[0].Value, e.Value) {
+			return false
+		}
+	}
+	return true
+}
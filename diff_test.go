@@ -0,0 +1,117 @@
+package kway
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"testing"
+)
+
+// formatDiff formats the keys and entries yielded by seq, absent entries
+// as "-".
+func formatDiff(seq iter.Seq2[string, []DiffEntry[int]]) []string {
+	var result []string
+	for k, entries := range seq {
+		s := k + ":"
+		for _, e := range entries {
+			if e.Present {
+				s += fmt.Sprintf(" %d", e.Value)
+			} else {
+				s += " -"
+			}
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []map[string]int
+		expected []string
+	}{
+		{
+			name:     "no sequences",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:     "identical",
+			input:    []map[string]int{{"a": 1, "b": 2}, {"a": 1, "b": 2}},
+			expected: []string{"a: 1 1", "b: 2 2"},
+		},
+		{
+			name: "replicas",
+			input: []map[string]int{
+				{"a": 1, "b": 2, "c": 3},
+				{"a": 1, "c": 4},
+				{"b": 2, "c": 3, "d": 5},
+			},
+			expected: []string{"a: 1 1 -", "b: 2 - 2", "c: 3 4 3", "d: - - 5"},
+		},
+		{
+			name:     "empty sequence",
+			input:    []map[string]int{{"a": 1}, {}},
+			expected: []string{"a: 1 -"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seqs []iter.Seq2[string, int]
+			for _, m := range tt.input {
+				seqs = append(seqs, MapEntries(m, slices.Sorted(maps.Keys(m))))
+			}
+			if result := formatDiff(Diff(cmp.Compare[string], seqs...)); !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDiff_Duplicates(t *testing.T) {
+	a := sliceSeq2([]string{"a", "a", "b"}, []int{1, 2, 3})
+	b := sliceSeq2([]string{"b", "b"}, []int{4, 5})
+	expected := []string{"a: 1 - -", "b: 3 - 4"}
+	if result := formatDiff(Diff(cmp.Compare[string], a, nil, b)); !slices.Equal(result, expected) {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
+
+func TestDiff_EarlyExit(t *testing.T) {
+	a := sliceSeq2([]string{"a", "b", "c"}, []int{1, 2, 3})
+	var keys []string
+	for k := range Diff(cmp.Compare[string], a) {
+		keys = append(keys, k)
+		if len(keys) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(keys, []string{"a", "b"}) {
+		t.Errorf("Expected [a b], got %v", keys)
+	}
+}
+
+func TestDiffConsistent(t *testing.T) {
+	equal := func(a, b int) bool { return a == b }
+	tests := []struct {
+		name     string
+		entries  []DiffEntry[int]
+		expected bool
+	}{
+		{"consistent", []DiffEntry[int]{{1, true}, {1, true}}, true},
+		{"different", []DiffEntry[int]{{1, true}, {2, true}}, false},
+		{"absent", []DiffEntry[int]{{1, true}, {}}, false},
+		{"absent first", []DiffEntry[int]{{}, {0, true}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := DiffConsistent(tt.entries, equal); result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
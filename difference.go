@@ -0,0 +1,51 @@
+package kway
+
+import (
+	"iter"
+)
+
+// Difference yields the elements of the sorted sequence `a` not present in
+// any of the sorted `subtrahends`, per `cmp`, i.e. `a \ (b1 ∪ b2 ∪ ...)`, in
+// a single pass, e.g. to apply blocklists to a sorted export. Duplicates
+// within `a` are retained, if not present in any subtrahend.
+//
+// The inputs are merged, per [MergeIndexed], with `a` last, such that the
+// subtrahends' elements equal to each of `a`'s precede it. Iteration stops
+// once `a` is exhausted, without consuming the rest of the subtrahends. Nil
+// subtrahends are ignored, and a nil `a` is empty.
+func Difference[T any](cmp func(a, b T) int, a iter.Seq[T], subtrahends ...iter.Seq[T]) iter.Seq[T] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	if a == nil {
+		return emptySeq[T]
+	}
+	if !anySeq(subtrahends) {
+		return a
+	}
+	return func(yield func(T) bool) {
+		var done bool
+		seqs := append(subtrahends[:len(subtrahends):len(subtrahends)], func(yield func(T) bool) {
+			for v := range a {
+				if !yield(v) {
+					return
+				}
+			}
+			done = true
+		})
+		var (
+			excluded T
+			ok       bool
+		)
+		for i, v := range MergeIndexed(cmp, seqs...) {
+			if done {
+				return
+			}
+			if i != len(subtrahends) {
+				excluded, ok = v, true
+			} else if (!ok || cmp(excluded, v) != 0) && !yield(v) {
+				return
+			}
+		}
+	}
+}
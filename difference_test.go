@@ -0,0 +1,151 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestDifference(t *testing.T) {
+	tests := []struct {
+		name        string
+		a           []int
+		subtrahends [][]int
+		expected    []int
+	}{
+		{
+			name:     "no subtrahends",
+			a:        []int{1, 2, 2, 3},
+			expected: []int{1, 2, 2, 3},
+		},
+		{
+			name:        "empty a",
+			a:           nil,
+			subtrahends: [][]int{{1, 2}},
+			expected:    nil,
+		},
+		{
+			name:        "single subtrahend",
+			a:           []int{1, 2, 3, 4, 5},
+			subtrahends: [][]int{{2, 4, 6}},
+			expected:    []int{1, 3, 5},
+		},
+		{
+			name:        "multiple subtrahends",
+			a:           []int{1, 2, 3, 4, 5, 6, 7},
+			subtrahends: [][]int{{0, 2}, {5, 9}, {2, 3, 3}},
+			expected:    []int{1, 4, 6, 7},
+		},
+		{
+			name:        "duplicates",
+			a:           []int{1, 1, 2, 2, 3, 3},
+			subtrahends: [][]int{{2}, {2, 2, 2}},
+			expected:    []int{1, 1, 3, 3},
+		},
+		{
+			name:        "everything excluded",
+			a:           []int{1, 2},
+			subtrahends: [][]int{{1}, {2}},
+			expected:    nil,
+		},
+		{
+			name:        "nil subtrahends",
+			a:           []int{1, 2},
+			subtrahends: [][]int{nil, {2}, nil},
+			expected:    []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a iter.Seq[int]
+			if tt.a != nil {
+				a = slices.Values(tt.a)
+			}
+			var subtrahends []iter.Seq[int]
+			for _, s := range tt.subtrahends {
+				if s != nil {
+					subtrahends = append(subtrahends, slices.Values(s))
+				} else {
+					subtrahends = append(subtrahends, nil)
+				}
+			}
+			if result := collectSeq(Difference(cmp.Compare[int], a, subtrahends...)); !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDifference_Random(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	random := func() []int {
+		s := make([]int, rng.IntN(30))
+		for i := range s {
+			s[i] = rng.IntN(40)
+		}
+		slices.Sort(s)
+		return s
+	}
+	for range 500 {
+		a := random()
+		subtrahends := make([][]int, rng.IntN(4))
+		var seqs []iter.Seq[int]
+		for i := range subtrahends {
+			subtrahends[i] = random()
+			seqs = append(seqs, slices.Values(subtrahends[i]))
+		}
+		var expected []int
+		for _, v := range a {
+			if !slices.ContainsFunc(subtrahends, func(s []int) bool { return slices.Contains(s, v) }) {
+				expected = append(expected, v)
+			}
+		}
+		if result := collectSeq(Difference(cmp.Compare[int], slices.Values(a), seqs...)); !slices.Equal(result, expected) {
+			t.Fatalf("%v \\ %v: Expected %v, got %v", a, subtrahends, expected, result)
+		}
+	}
+}
+
+func TestDifference_StopsWithA(t *testing.T) {
+	var n int
+	subtrahend := func(yield func(int) bool) {
+		for i := 0; ; i += 2 {
+			n++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	result := collectSeq(Difference(cmp.Compare[int], slices.Values([]int{1, 2, 3}), subtrahend))
+	if !slices.Equal(result, []int{1, 3}) {
+		t.Errorf("Expected [1 3], got %v", result)
+	}
+	if n > 4 {
+		t.Errorf("Expected at most 4 subtrahend elements, got %d", n)
+	}
+}
+
+func TestDifference_EarlyExit(t *testing.T) {
+	var result []int
+	for v := range Difference(cmp.Compare[int], slices.Values([]int{1, 2, 3, 4}), slices.Values([]int{2})) {
+		result = append(result, v)
+		if len(result) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(result, []int{1, 3}) {
+		t.Errorf("Expected [1 3], got %v", result)
+	}
+}
+
+func TestDifference_NilCompare(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "kway: nil comparison function" {
+			t.Errorf("Expected panic, got %v", r)
+		}
+	}()
+	Difference[int](nil, nil)
+}
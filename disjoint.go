@@ -0,0 +1,101 @@
+package kway
+
+import (
+	"iter"
+	"slices"
+)
+
+// RangeSource is a sorted input sequence of [MergeDisjoint], with optional
+// bounds, e.g. per the metadata of a time partition. See also [LazySource].
+type RangeSource[T any] struct {
+	Seq iter.Seq[T]
+	// Min and Max are the least and greatest elements of Seq, inclusive, if
+	// Bounded. The elements of a bounded source must lie within its bounds.
+	Min, Max T
+	Bounded  bool
+}
+
+// MergeDisjoint performs a k-way merge of the provided sorted sources, per
+// [Merge], using their bounds to plan the merge: sources are grouped into
+// sets whose ranges overlap, which are concatenated, in order, with each set
+// merged separately, and a set of a single source yielded directly, without
+// comparing its elements. This is a large win for data partitioned by key,
+// e.g. by time, where most partitions are disjoint.
+//
+// Sources whose ranges only touch, at the Max of one and the Min of another,
+// are treated as overlapping, such that the output is identical to that of
+// [Merge], including the order of elements that compare equal. Any source
+// without bounds may overlap every other, in which case every source is
+// merged together. Sources with a nil Seq are ignored.
+func MergeDisjoint[T any](cmp func(a, b T) int, srcs ...RangeSource[T]) iter.Seq[T] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	sets := planDisjoint(cmp, srcs)
+	if len(sets) == 0 {
+		return emptySeq[T]
+	}
+	seqs := make([]iter.Seq[T], len(sets))
+	for i, set := range sets {
+		s := make([]iter.Seq[T], len(set))
+		for j, src := range set {
+			s[j] = srcs[src].Seq
+		}
+		if len(s) == 1 {
+			seqs[i] = s[0]
+		} else {
+			seqs[i] = Merge(cmp, s...)
+		}
+	}
+	if len(seqs) == 1 {
+		return seqs[0]
+	}
+	return func(yield func(T) bool) {
+		for _, seq := range seqs {
+			for v := range seq {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// planDisjoint returns the indexes of the sources of each set of overlapping
+// ranges, in order, with the sources of each set in order of index.
+func planDisjoint[T any](cmp func(a, b T) int, srcs []RangeSource[T]) [][]int {
+	order := make([]int, 0, len(srcs))
+	bounded := true
+	for i, src := range srcs {
+		if src.Seq != nil {
+			order = append(order, i)
+			bounded = bounded && src.Bounded
+		}
+	}
+	if len(order) == 0 {
+		return nil
+	}
+	if !bounded {
+		return [][]int{order}
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return cmp(srcs[a].Min, srcs[b].Min) })
+	var (
+		sets [][]int
+		hi   T
+	)
+	for _, i := range order {
+		if len(sets) != 0 && cmp(srcs[i].Min, hi) <= 0 {
+			sets[len(sets)-1] = append(sets[len(sets)-1], i)
+			if cmp(srcs[i].Max, hi) > 0 {
+				hi = srcs[i].Max
+			}
+			continue
+		}
+		sets = append(sets, []int{i})
+		hi = srcs[i].Max
+	}
+	for _, set := range sets {
+		slices.Sort(set)
+	}
+	return sets
+}
@@ -0,0 +1,115 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestMergeDisjoint(t *testing.T) {
+	type value struct{ key, src int }
+	cmpFunc := func(a, b value) int { return cmp.Compare(a.key, b.key) }
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 100 {
+		var (
+			srcs []RangeSource[value]
+			seqs []iter.Seq[value]
+		)
+		for i := range r.IntN(8) {
+			values := make([]value, 1+r.IntN(10))
+			offset := r.IntN(200)
+			for j := range values {
+				values[j] = value{offset + r.IntN(30), i}
+			}
+			slices.SortFunc(values, cmpFunc)
+			src := RangeSource[value]{Seq: slices.Values(values)}
+			if r.IntN(10) != 0 {
+				src.Min, src.Max, src.Bounded = values[0], values[len(values)-1], true
+			}
+			if r.IntN(10) == 0 {
+				src.Seq = nil
+			}
+			srcs = append(srcs, src)
+			seqs = append(seqs, src.Seq)
+		}
+		expected := collectSeq(Merge(cmpFunc, seqs...))
+		if actual := collectSeq(MergeDisjoint(cmpFunc, srcs...)); !slices.Equal(actual, expected) {
+			t.Errorf("Expected %v, got %v", expected, actual)
+		}
+	}
+}
+
+func TestMergeDisjoint_Plan(t *testing.T) {
+	src := func(lo, hi int) RangeSource[int] {
+		return RangeSource[int]{Seq: slices.Values([]int{lo, hi}), Min: lo, Max: hi, Bounded: true}
+	}
+	tests := []struct {
+		name     string
+		srcs     []RangeSource[int]
+		expected [][]int
+	}{
+		{"none", nil, nil},
+		{"disjoint", []RangeSource[int]{src(20, 29), src(0, 9), src(10, 19)}, [][]int{{1}, {2}, {0}}},
+		{"overlapping", []RangeSource[int]{src(5, 15), src(0, 9), src(20, 29), src(14, 16), src(17, 19)}, [][]int{{0, 1, 3}, {4}, {2}}},
+		{"touching", []RangeSource[int]{src(10, 19), src(0, 10)}, [][]int{{0, 1}}},
+		{"unbounded", []RangeSource[int]{src(0, 9), {Seq: slices.Values([]int{1})}, src(20, 29)}, [][]int{{0, 1, 2}}},
+		{"nil sequence", []RangeSource[int]{{}, src(0, 9)}, [][]int{{1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := planDisjoint(cmp.Compare[int], tt.srcs); !slices.EqualFunc(actual, tt.expected, slices.Equal) {
+				t.Errorf("Expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMergeDisjoint_Comparisons(t *testing.T) {
+	var calls int
+	cmpFunc := func(a, b int) int {
+		calls++
+		return cmp.Compare(a, b)
+	}
+	var srcs []RangeSource[int]
+	for i := range 10 {
+		values := make([]int, 100)
+		for j := range values {
+			values[j] = i*100 + j
+		}
+		srcs = append(srcs, RangeSource[int]{Seq: slices.Values(values), Min: values[0], Max: values[99], Bounded: true})
+	}
+	if actual := collectSeq(MergeDisjoint(cmpFunc, srcs...)); len(actual) != 1000 || !slices.IsSorted(actual) {
+		t.Errorf("Expected 1000 sorted elements, got %v", actual)
+	}
+	if calls > 50 {
+		t.Errorf("Expected at most 50 comparisons, got %d", calls)
+	}
+}
+
+func TestMergeDisjoint_earlyExit(t *testing.T) {
+	seq := MergeDisjoint(cmp.Compare[int],
+		RangeSource[int]{Seq: slices.Values([]int{1, 2}), Min: 1, Max: 2, Bounded: true},
+		RangeSource[int]{Seq: slices.Values([]int{3, 4}), Min: 3, Max: 4, Bounded: true},
+	)
+	var result []int
+	for v := range seq {
+		result = append(result, v)
+		if len(result) == 3 {
+			break
+		}
+	}
+	if !slices.Equal(result, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", result)
+	}
+}
+
+func TestMergeDisjoint_NilComparison(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "kway: nil comparison function" {
+			t.Errorf("Expected panic, got %v", r)
+		}
+	}()
+	MergeDisjoint[int](nil)
+}
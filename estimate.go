@@ -0,0 +1,103 @@
+package kway
+
+import (
+	"math/bits"
+	"unsafe"
+)
+
+// Approximate sizes, in bytes, used to estimate memory usage.
+const (
+	// estimatePull is the cost of an iter.Pull coroutine, dominated by the
+	// initial goroutine stack.
+	estimatePull = 8<<10 + 512
+	// estimateClosure is the cost of a small closure, or similar allocation.
+	estimateClosure = 64
+	// estimateMerge is the fixed cost of a merge.
+	estimateMerge = 256
+)
+
+// EstimateMemory estimates the steady-state memory usage, in bytes, of a
+// single iteration of a merge of `k` input sequences, using the Merger's
+// options. The size of an element (including memory it references) may be
+// provided as `elemSize`, or 0 to use the size of T.
+//
+// The estimate excludes memory retained by the input sequences themselves,
+// and by the consumer of the merge, and is intended for admission control,
+// rather than precise accounting. Options degraded to fit within
+// [WithMaxBuffered] are estimated as degraded.
+func (m *Merger[T]) EstimateMemory(k int, elemSize int) int64 {
+	if elemSize <= 0 {
+		elemSize = int(unsafe.Sizeof(*new(T)))
+	}
+	return m.opts.estimateMemory(k, elemSize)
+}
+
+// EstimateMemory estimates the steady-state memory usage of a merge, see
+// [Merger.EstimateMemory], with the default element size being the combined
+// size of T1 and T2.
+func (m *Merger2[T1, T2]) EstimateMemory(k int, elemSize int) int64 {
+	if elemSize <= 0 {
+		elemSize = int(unsafe.Sizeof(*new(T1)) + unsafe.Sizeof(*new(T2)))
+	}
+	return m.opts.estimateMemory(k, elemSize)
+}
+
+func (o *options) estimateMemory(k int, elemSize int) int64 {
+	if k < 0 {
+		panic("kway: negative k")
+	}
+	if o.maxBuffered > 0 {
+		// the options as degraded to fit, unless the merge would fail
+		if b, _, err := o.bounded(k, o.verifySorted, 0); err == nil {
+			o = &b
+		}
+	}
+	// the current element of each source, wrapped with its index, plus its
+	// heap slot, and the source's pull functions (next and stop)
+	perSource := int64(estimatePull) + estimateClosure + roundAlloc(elemSize+int(unsafe.Sizeof(0))) + 8 + 16
+	if o.strategy == StrategyLoserTree {
+		// the loser cached at the source's node, and its exhausted flag
+		perSource += 8 + 1
+	}
+	if o.verifySorted {
+		// the previous element, retained for comparison
+		perSource += estimateClosure + int64(elemSize)
+	}
+	if o.prefetch > 0 {
+		// the producer goroutine, and its ring buffer, rounded up to a power
+		// of two
+		perSource += estimatePull + roundAlloc(int(unsafe.Sizeof(spscRing[struct{}]{}))) + roundAlloc(elemSize<<bits.Len(uint(o.prefetch-1)))
+	}
+	if o.stats != nil {
+		perSource += int64(unsafe.Sizeof(SourceStats{})) + estimateClosure
+	}
+	if o.metrics != nil {
+		perSource += estimateClosure
+	}
+	if o.trace != nil {
+		perSource += estimateClosure
+	}
+	if o.hooks.OnExhausted != nil {
+		perSource += estimateClosure
+	}
+	total := int64(estimateMerge) + int64(k)*perSource
+	if o.uniqueKeys {
+		// the previously yielded element
+		total += estimateClosure + int64(elemSize)
+	}
+	if o.maxPerKey > 0 {
+		// the first element of the current key
+		total += estimateClosure + int64(elemSize)
+	}
+	if o.yieldBatch > 1 {
+		// the batch, of wrapped elements, which are each retained
+		total += estimateClosure + roundAlloc(o.yieldBatch*int(unsafe.Sizeof(uintptr(0)))) + int64(o.yieldBatch)*roundAlloc(elemSize+int(unsafe.Sizeof(0)))
+	}
+	return total
+}
+
+// roundAlloc rounds n up to a multiple of 16, approximating allocation size
+// classes.
+func roundAlloc(n int) int64 {
+	return int64((n + 15) &^ 15)
+}
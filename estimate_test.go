@@ -0,0 +1,77 @@
+package kway
+
+import (
+	"cmp"
+	"testing"
+)
+
+func TestMerger_EstimateMemory(t *testing.T) {
+	m := NewMerger(cmp.Compare[int])
+
+	base := m.EstimateMemory(0, 0)
+	if base <= 0 {
+		t.Errorf("Expected a positive fixed cost, got %d", base)
+	}
+
+	one, ten := m.EstimateMemory(1, 0), m.EstimateMemory(10, 0)
+	if one <= base || ten-base != 10*(one-base) {
+		t.Errorf("Expected linear growth in k, got %d, %d, %d", base, one, ten)
+	}
+
+	if large := m.EstimateMemory(10, 1024); large < ten+10*(1024-8) {
+		t.Errorf("Expected the element size to be accounted for, got %d vs %d", large, ten)
+	}
+
+	var stats Stats
+	if v := NewMerger(cmp.Compare[int], WithVerifySorted(), WithStats(&stats), WithUniqueKeys()).EstimateMemory(10, 0); v <= ten {
+		t.Errorf("Expected options to increase the estimate, got %d vs %d", v, ten)
+	}
+
+	if small, large := NewMerger(cmp.Compare[int], WithPrefetch(1)).EstimateMemory(10, 0), NewMerger(cmp.Compare[int], WithPrefetch(1000)).EstimateMemory(10, 0); small <= ten || large < small+10*8*1000 {
+		t.Errorf("Expected prefetch to account for the buffers, got %d, %d vs %d", small, large, ten)
+	}
+
+	if v := NewMerger(cmp.Compare[int], WithStrategy(StrategyLoserTree)).EstimateMemory(10, 0); v <= ten {
+		t.Errorf("Expected the loser tree to account for its nodes, got %d vs %d", v, ten)
+	}
+
+	if v := NewMerger(cmp.Compare[int], WithYieldBatching(100)).EstimateMemory(10, 0); v < ten+100*8 {
+		t.Errorf("Expected yield batching to account for the batch, got %d vs %d", v, ten)
+	}
+
+	if v := NewMerger(cmp.Compare[int], WithMaxPerKey(2)).EstimateMemory(10, 1024); v <= NewMerger(cmp.Compare[int]).EstimateMemory(10, 1024)+1024 {
+		t.Errorf("Expected the per-key limit to account for the retained element, got %d", v)
+	}
+
+	if v := NewMerger(cmp.Compare[int], WithSourceHooks(SourceHooks{OnExhausted: func(int) {}})).EstimateMemory(10, 0); v <= ten {
+		t.Errorf("Expected source hooks to account for the wrapper, got %d vs %d", v, ten)
+	}
+
+	if bounded, unbounded := NewMerger(cmp.Compare[int], WithPrefetch(1000), WithMaxBuffered(100)).EstimateMemory(10, 0), NewMerger(cmp.Compare[int], WithPrefetch(8)).EstimateMemory(10, 0); bounded != unbounded {
+		t.Errorf("Expected the prefetch to be estimated as degraded, got %d vs %d", bounded, unbounded)
+	}
+}
+
+func TestMerger2_EstimateMemory(t *testing.T) {
+	m := NewMerger2(func(a1 int64, a2 [64]byte, b1 int64, b2 [64]byte) int { return cmp.Compare(a1, b1) })
+	if v, small := m.EstimateMemory(4, 0), m.EstimateMemory(4, 8); v <= small {
+		t.Errorf("Expected the default element size to include both values, got %d vs %d", v, small)
+	}
+}
+
+func TestEstimateMemory_NegativeK(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for negative k")
+		}
+	}()
+	NewMerger(cmp.Compare[int]).EstimateMemory(-1, 0)
+}
+
+func TestRoundAlloc(t *testing.T) {
+	for n, expected := range map[int]int64{0: 0, 1: 16, 16: 16, 17: 32} {
+		if v := roundAlloc(n); v != expected {
+			t.Errorf("roundAlloc(%d) = %d, want %d", n, v, expected)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+)
+
+// MergeEvents performs a k-way merge of the provided event streams, each
+// sorted by partition, then sequence number, per `key`, suppressing the
+// redelivered events of at-least-once delivery, i.e. those whose partition
+// and sequence number have already been yielded, to produce an exactly-once
+// ordered stream.
+//
+// For each partition, the sequence numbers yielded within `window` of its
+// high-water mark, the greatest yielded so far, are tracked, such that a
+// late event, within the window, is yielded once, even if out of order, e.g.
+// if replayed by a consumer restarting from an earlier checkpoint, while
+// events at or below the window are suppressed, as already yielded, or too
+// late. A window of 0 suppresses every event at or below the high-water
+// mark, as is sufficient for sorted streams. The memory used is proportional
+// to `window`, for each partition.
+func MergeEvents[T any, P cmp.Ordered](key func(v T) (partition P, sequence uint64), window int, seqs ...iter.Seq[T]) iter.Seq[T] {
+	if key == nil {
+		panic("kway: nil key function")
+	}
+	if window < 0 {
+		panic("kway: negative window")
+	}
+	merged := Merge(func(a, b T) int {
+		pa, sa := key(a)
+		pb, sb := key(b)
+		if c := cmp.Compare(pa, pb); c != 0 {
+			return c
+		}
+		return cmp.Compare(sa, sb)
+	}, seqs...)
+	return func(yield func(T) bool) {
+		partitions := make(map[P]*eventWindow)
+		for v := range merged {
+			p, s := key(v)
+			w := partitions[p]
+			if w == nil {
+				w = &eventWindow{seen: make([]bool, window), hwm: s}
+				partitions[p] = w
+				w.mark(s)
+			} else if !w.add(s) {
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// eventWindow tracks the sequence numbers yielded for a partition, within
+// the window below its high-water mark, in a ring, indexed by sequence
+// number, modulo the window.
+type eventWindow struct {
+	seen []bool
+	hwm  uint64
+}
+
+// add returns true, marking s as yielded, if it has not been.
+func (x *eventWindow) add(s uint64) bool {
+	window := uint64(len(x.seen))
+	switch {
+	case s > x.hwm:
+		for i := x.hwm + 1; i < s && i-x.hwm <= window; i++ {
+			x.seen[i%window] = false
+		}
+		x.hwm = s
+	case x.hwm-s >= window || x.seen[s%window]:
+		return false
+	}
+	x.mark(s)
+	return true
+}
+
+func (x *eventWindow) mark(s uint64) {
+	if len(x.seen) != 0 {
+		x.seen[s%uint64(len(x.seen))] = true
+	}
+}
@@ -0,0 +1,115 @@
+package kway
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+type event struct {
+	partition string
+	sequence  uint64
+	source    int
+}
+
+func eventKey(e event) (string, uint64) { return e.partition, e.sequence }
+
+func TestMergeEvents(t *testing.T) {
+	tests := []struct {
+		name     string
+		window   int
+		input    [][]event
+		expected []event
+	}{
+		{
+			name:     "no streams",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:   "redelivered across streams",
+			window: 0,
+			input: [][]event{
+				{{"a", 1, 0}, {"a", 2, 0}, {"b", 1, 0}},
+				{{"a", 2, 1}, {"a", 3, 1}, {"b", 1, 1}, {"b", 2, 1}},
+			},
+			expected: []event{{"a", 1, 0}, {"a", 2, 0}, {"a", 3, 1}, {"b", 1, 0}, {"b", 2, 1}},
+		},
+		{
+			name:   "replayed within stream",
+			window: 0,
+			input: [][]event{
+				{{"a", 1, 0}, {"a", 2, 0}, {"a", 3, 0}, {"a", 2, 0}, {"a", 3, 0}, {"a", 4, 0}},
+				{{"a", 3, 1}, {"a", 4, 1}, {"a", 5, 1}},
+			},
+			expected: []event{{"a", 1, 0}, {"a", 2, 0}, {"a", 3, 0}, {"a", 4, 0}, {"a", 5, 1}},
+		},
+		{
+			name:     "late event suppressed",
+			window:   0,
+			input:    [][]event{{{"a", 1, 0}, {"a", 3, 0}, {"a", 2, 0}}},
+			expected: []event{{"a", 1, 0}, {"a", 3, 0}},
+		},
+		{
+			name:     "late event within window",
+			window:   2,
+			input:    [][]event{{{"a", 1, 0}, {"a", 3, 0}, {"a", 2, 0}, {"a", 2, 0}, {"a", 3, 0}}},
+			expected: []event{{"a", 1, 0}, {"a", 3, 0}, {"a", 2, 0}},
+		},
+		{
+			name:     "late event beyond window",
+			window:   2,
+			input:    [][]event{{{"a", 1, 0}, {"a", 10, 0}, {"a", 8, 0}, {"a", 9, 0}, {"a", 9, 0}}},
+			expected: []event{{"a", 1, 0}, {"a", 10, 0}, {"a", 9, 0}},
+		},
+		{
+			name:     "window reused",
+			window:   3,
+			input:    [][]event{{{"a", 1, 0}, {"a", 2, 0}, {"a", 4, 0}, {"a", 8, 0}, {"a", 7, 0}, {"a", 6, 0}, {"a", 7, 0}}},
+			expected: []event{{"a", 1, 0}, {"a", 2, 0}, {"a", 4, 0}, {"a", 8, 0}, {"a", 7, 0}, {"a", 6, 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seqs []iter.Seq[event]
+			for _, s := range tt.input {
+				seqs = append(seqs, slices.Values(s))
+			}
+			if result := collectSeq(MergeEvents(eventKey, tt.window, seqs...)); !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMergeEvents_earlyExit(t *testing.T) {
+	var result []event
+	for e := range MergeEvents(eventKey, 0, slices.Values([]event{{"a", 1, 0}, {"a", 2, 0}})) {
+		result = append(result, e)
+		break
+	}
+	if !slices.Equal(result, []event{{"a", 1, 0}}) {
+		t.Errorf("Expected [{a 1 0}], got %v", result)
+	}
+}
+
+func TestMergeEvents_panics(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		f     func()
+		panic string
+	}{
+		{"nil key", func() { MergeEvents[event, string](nil, 0) }, "kway: nil key function"},
+		{"negative window", func() { MergeEvents(eventKey, -1) }, "kway: negative window"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tt.panic {
+					t.Errorf("Expected %q, got %v", tt.panic, r)
+				}
+			}()
+			tt.f()
+		})
+	}
+}
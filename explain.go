@@ -0,0 +1,94 @@
+package kway
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Explain describes the plan used by the Merger's merges, including the
+// engine and the enabled options, as human-readable text. The format is not
+// stable, and is intended for verifying and tuning behavior.
+func (m *Merger[T]) Explain() string {
+	return m.opts.explain()
+}
+
+// Explain describes the plan used by the Merger2's merges, see
+// [Merger.Explain].
+func (m *Merger2[T1, T2]) Explain() string {
+	return m.opts.explain()
+}
+
+func (o *options) explain() string {
+	var b strings.Builder
+	b.WriteString("merge\n")
+	line := func(format string, args ...any) {
+		b.WriteString("  ")
+		fmt.Fprintf(&b, format, args...)
+		b.WriteByte('\n')
+	}
+	line("engine: %s, pulling sequences via iter.Pull, and cursors directly", o.strategy)
+	ties := "source index"
+	if o.priority != nil {
+		ties = "source priority, then " + ties
+	}
+	if o.tieBreak != nil {
+		ties = "secondary comparison function, then " + ties
+	}
+	line("ties: %s", ties)
+	if o.verifySorted {
+		line("verify: sources sorted")
+	}
+	if o.checkComparator > 0 {
+		line("verify: comparator, every %d comparisons", o.checkComparator)
+	}
+	if o.uniqueKeys {
+		line("verify: unique keys across sources")
+	}
+	if o.maxPerKey > 0 {
+		line("filter: at most %d elements per key", o.maxPerKey)
+	}
+	if o.stats != nil {
+		line("instrument: stats")
+	}
+	if o.metrics != nil {
+		line("instrument: metrics")
+	}
+	if o.trace != nil {
+		line("instrument: trace")
+	}
+	if !o.hooks.empty() {
+		line("instrument: source hooks")
+	}
+	if o.summary != nil {
+		line("instrument: summary")
+	}
+	if o.recording != nil {
+		line("instrument: recording")
+	}
+	if o.parallelism > 0 {
+		line("partitioned: up to %d partitions concurrently", o.parallelism)
+	}
+	if o.prefetch > 0 {
+		line("prefetch: up to %d elements per sequence, via goroutine", o.prefetch)
+	}
+	if o.limiter != nil {
+		line("limit: shared limiter, %d slots", o.limiter.Size())
+	}
+	if o.maxBuffered > 0 {
+		line("limit: up to %d buffered elements", o.maxBuffered)
+	}
+	if o.writeBuffer > 0 {
+		if o.flushEvery > 0 {
+			line("write: buffered, %d bytes, flushed every %d elements", o.writeBuffer, o.flushEvery)
+		} else {
+			line("write: buffered, %d bytes", o.writeBuffer)
+		}
+	}
+	if o.yieldBatch > 1 {
+		line("output: batched, %d elements per batch", o.yieldBatch)
+	}
+	if o.progress != nil {
+		line("instrument: progress, every %d elements", o.progressEvery)
+	}
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package kway
+
+import (
+	"cmp"
+	"strings"
+	"testing"
+)
+
+func TestMerger_Explain(t *testing.T) {
+	if s := NewMerger(cmp.Compare[int], WithComparatorCheck(0)).Explain(); s != "merge\n  engine: binary heap, pulling sequences via iter.Pull, and cursors directly\n  ties: source index\n" {
+		t.Errorf("Unexpected plan:\n%s", s)
+	}
+
+	var stats Stats
+	var metrics Metrics
+	s := NewMerger(cmp.Compare[int],
+		WithTieBreak(cmp.Compare[int]),
+		WithPriority(1),
+		WithVerifySorted(),
+		WithComparatorCheck(10),
+		WithUniqueKeys(),
+		WithStats(&stats),
+		WithMetrics(&metrics),
+		WithTrace(func(TraceEvent) {}),
+		WithProgress(100, func(int64) {}),
+		WithParallelism(4),
+		WithLimiter(NewLimiter(16)),
+		WithPrefetch(8),
+		WithWriteBuffer(4096, 100),
+		WithYieldBatching(64),
+		WithStrategy(StrategyLoserTree),
+		WithRecording(new(Recording)),
+		WithMaxBuffered(1000),
+		WithSourceHooks(SourceHooks{OnStop: func(int) {}}),
+		WithMaxPerKey(3),
+		WithSummary(new(Summary[int])),
+	).Explain()
+	for _, expected := range []string{
+		"ties: secondary comparison function, then source priority, then source index",
+		"verify: sources sorted",
+		"verify: comparator, every 10 comparisons",
+		"verify: unique keys across sources",
+		"filter: at most 3 elements per key",
+		"instrument: stats",
+		"instrument: metrics",
+		"instrument: trace",
+		"instrument: recording",
+		"instrument: source hooks",
+		"instrument: summary",
+		"instrument: progress, every 100 elements",
+		"partitioned: up to 4 partitions concurrently",
+		"limit: shared limiter, 16 slots",
+		"limit: up to 1000 buffered elements",
+		"prefetch: up to 8 elements per sequence, via goroutine",
+		"write: buffered, 4096 bytes, flushed every 100 elements",
+		"output: batched, 64 elements per batch",
+		"engine: loser tree, pulling sequences via iter.Pull, and cursors directly",
+	} {
+		if !strings.Contains(s, "\n  "+expected+"\n") {
+			t.Errorf("Expected plan to contain %q, got:\n%s", expected, s)
+		}
+	}
+}
+
+func TestMerger2_Explain(t *testing.T) {
+	m := NewMerger2(func(a1, a2, b1, b2 int) int { return cmp.Compare(a1, b1) }, WithUniqueKeys())
+	if s := m.Explain(); !strings.Contains(s, "unique keys") {
+		t.Errorf("Unexpected plan:\n%s", s)
+	}
+}
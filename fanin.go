@@ -0,0 +1,158 @@
+package kway
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+)
+
+// Producer is a function producing sorted elements, by calling emit, for use
+// with [FanIn]. Producers must stop, returning promptly, once either their
+// context is done, or emit returns an error. A non-nil error returned by emit
+// should normally be returned by the producer.
+type Producer[T any] func(ctx context.Context, emit func(v T) error) error
+
+// fanInBuffer is the number of elements buffered per producer.
+const fanInBuffer = 64
+
+// errFanInStopped is the cause of the producer context being canceled, once
+// the merge is finished, or stopped by the consumer.
+var errFanInStopped = errors.New("kway: fan-in stopped")
+
+// FanIn runs each producer in its own goroutine, on iteration of the returned
+// sequence, which yields the merged output of all producers, per
+// [Merger.Merge]. Each producer must emit elements in sorted order.
+//
+// The returned function reports the errors returned by producers, joined by
+// [errors.Join], and should be called after iteration. If a producer fails,
+// all producers are canceled, and the merged sequence ends. Cancellation
+// errors that result from stopping the producers, because the consumer
+// stopped iteration or another producer failed, are not reported. All
+// producers have returned by the time iteration of the merged sequence ends.
+//
+// If the merge would exceed the limit configured by [WithMaxBuffered], the
+// [*BufferLimitError] is reported, and the merged sequence is empty.
+//
+// If configured, using [WithLimiter], a slot is acquired for each producer,
+// before any are started, and released once all have returned. If `ctx` is
+// done first, its error is reported, and the merged sequence is empty, as it
+// is if there are more producers than the size of the limiter, in which case
+// [ErrLimiterExceeded] is reported.
+//
+// The returned sequence is intended to be iterated once. Each iteration runs
+// the producers, and resets the reported error.
+func (m *Merger[T]) FanIn(ctx context.Context, producers ...Producer[T]) (iter.Seq[T], func() error) {
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	seq := func(yield func(T) bool) {
+		mu.Lock()
+		errs = nil
+		mu.Unlock()
+
+		var n int
+		for _, producer := range producers {
+			if producer != nil {
+				n++
+			}
+		}
+		m, buffer, err := m.bounded(n, m.opts.verifySorted, fanInBuffer)
+		if err != nil {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+			return
+		}
+
+		parent := ctx
+		ctx, cancel := context.WithCancelCause(parent)
+		if limiter := m.opts.limiter; limiter != nil {
+			if n > limiter.Size() {
+				cancel(nil)
+				mu.Lock()
+				errs = append(errs, ErrLimiterExceeded)
+				mu.Unlock()
+				return
+			}
+			if !limiter.acquire(ctx.Done(), n) {
+				cancel(nil)
+				mu.Lock()
+				errs = append(errs, ctx.Err())
+				mu.Unlock()
+				return
+			}
+			defer limiter.release(n)
+		}
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		defer cancel(errFanInStopped)
+
+		var failed bool
+		fail := func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			// ignore cancellations we caused
+			if ctx.Err() != nil && parent.Err() == nil && errors.Is(err, context.Canceled) {
+				return
+			}
+			failed = true
+			errs = append(errs, err)
+			cancel(err)
+		}
+
+		srcs := make([]pullSource[T], len(producers))
+		for i, producer := range producers {
+			if producer == nil {
+				continue
+			}
+			ch := make(chan T, buffer)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer close(ch)
+				if err := producer(ctx, func(v T) error {
+					select {
+					case ch <- v:
+						return nil
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}); err != nil {
+					fail(err)
+				}
+			}()
+			srcs[i] = cursorSource[T](chanCursor[T](ch))
+		}
+
+		for v := range m.merge(srcs, m.opts.verifySorted, panicError) {
+			mu.Lock()
+			stop := failed
+			mu.Unlock()
+			if stop || !yield(v.v) {
+				return
+			}
+		}
+	}
+	return seq, func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		return errors.Join(errs...)
+	}
+}
+
+// FanIn runs producers of sorted elements concurrently, merging their
+// output. It is equivalent to [Merger.FanIn], using a [Merger] without
+// options.
+func FanIn[T any](ctx context.Context, cmp func(a, b T) int, producers ...Producer[T]) (iter.Seq[T], func() error) {
+	return NewMerger(cmp).FanIn(ctx, producers...)
+}
+
+// chanCursor receives elements from a channel, until it is closed.
+type chanCursor[T any] <-chan T
+
+func (c chanCursor[T]) Next() (v T, ok bool) {
+	v, ok = <-c
+	return v, ok
+}
@@ -0,0 +1,139 @@
+package kway
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+func sliceProducer[T any](s []T) Producer[T] {
+	return func(ctx context.Context, emit func(v T) error) error {
+		for _, v := range s {
+			if err := emit(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestFanIn(t *testing.T) {
+	seq, wait := FanIn(context.Background(), cmp.Compare[int],
+		sliceProducer([]int{1, 4, 7}),
+		nil,
+		sliceProducer([]int{2, 5, 8}),
+		sliceProducer([]int{3, 6, 9}),
+	)
+	result := collectSeq(seq)
+	if err := wait(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(result, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("Expected [1 ... 9], got %v", result)
+	}
+}
+
+func TestFanIn_LargeProducers(t *testing.T) {
+	producer := func(offset int) Producer[int] {
+		return func(ctx context.Context, emit func(v int) error) error {
+			for i := 0; i < 1000; i++ {
+				if err := emit(i*3 + offset); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	seq, wait := FanIn(context.Background(), cmp.Compare[int], producer(0), producer(1), producer(2))
+	result := collectSeq(seq)
+	if err := wait(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(result) != 3000 || !slices.IsSorted(result) {
+		t.Errorf("Expected 3000 sorted elements, got %d", len(result))
+	}
+}
+
+func TestFanIn_ProducerError(t *testing.T) {
+	errBoom := errors.New("boom")
+	var canceled atomic.Bool
+	seq, wait := FanIn(context.Background(), cmp.Compare[int],
+		func(ctx context.Context, emit func(v int) error) error {
+			for i := 0; ; i++ {
+				if err := emit(i); err != nil {
+					canceled.Store(true)
+					return err
+				}
+			}
+		},
+		func(ctx context.Context, emit func(v int) error) error {
+			if err := emit(0); err != nil {
+				return err
+			}
+			return errBoom
+		},
+	)
+	_ = collectSeq(seq)
+	if err := wait(); !errors.Is(err, errBoom) || errors.Is(err, context.Canceled) {
+		t.Errorf("Expected only the producer error, got %v", err)
+	}
+	if !canceled.Load() {
+		t.Error("Expected the other producer to be canceled")
+	}
+}
+
+func TestFanIn_EarlyTermination(t *testing.T) {
+	var returned atomic.Int32
+	infinite := func(ctx context.Context, emit func(v int) error) error {
+		defer returned.Add(1)
+		for i := 0; ; i++ {
+			if err := emit(i); err != nil {
+				return err
+			}
+		}
+	}
+	seq, wait := FanIn(context.Background(), cmp.Compare[int], infinite, infinite)
+	var result []int
+	for v := range seq {
+		result = append(result, v)
+		if len(result) == 4 {
+			break
+		}
+	}
+	if !slices.Equal(result, []int{0, 0, 1, 1}) {
+		t.Errorf("Expected [0 0 1 1], got %v", result)
+	}
+	if n := returned.Load(); n != 2 {
+		t.Errorf("Expected both producers to have returned, got %d", n)
+	}
+	if err := wait(); err != nil {
+		t.Errorf("Expected no error after stopping early, got %v", err)
+	}
+}
+
+func TestFanIn_ParentCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	seq, wait := FanIn(ctx, cmp.Compare[int], func(ctx context.Context, emit func(v int) error) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	_ = collectSeq(seq)
+	if err := wait(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestMerger_FanIn_Options(t *testing.T) {
+	m := NewMerger(cmp.Compare[int], WithVerifySorted())
+	seq, _ := m.FanIn(context.Background(), sliceProducer([]int{2, 1}))
+	defer func() {
+		if _, ok := recover().(*OrderError); !ok {
+			t.Error("Expected panic with *OrderError")
+		}
+	}()
+	_ = collectSeq(seq)
+}
@@ -0,0 +1,204 @@
+package kway
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidField is returned by [ByFields], for a field that does not exist,
+// is unexported, or whose type is not ordered.
+var ErrInvalidField = errors.New("kway: invalid field")
+
+// ByFields returns a [Comparator] ordering structs, or pointers to structs,
+// by the named fields, in order, as resolved via reflection, e.g. for
+// pipelines configured at runtime, where the key is not known at compile
+// time. Each field may be prefixed by "-", to order it in descending order,
+// or "+", the default, for ascending order. Fields of nested structs may be
+// named using a path, e.g. "Address.City", fields of embedded structs may be
+// named directly, and fields may be named by their tag, per [ComparatorFor].
+//
+// Fields must be exported, and of a boolean, integer, floating-point (per
+// [cmp.Compare]), string, or byte slice kind, or of a type with a method
+// Compare(T) int, such as [time.Time]. Fields behind nil pointers, including
+// nil embedded structs, order before all values, if ascending. The resolved
+// fields are cached, per type, and the returned Comparator is safe for
+// concurrent use.
+//
+// An error wrapping [ErrInvalidField] is returned if any field is invalid.
+func ByFields[T any](fields ...string) (Comparator[T], error) {
+	keys, err := cachedFieldKeys(reflect.TypeFor[T](), fields)
+	if err != nil {
+		return nil, err
+	}
+	return func(a, b T) int {
+		return compareFields(keys, reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem())
+	}, nil
+}
+
+// MergeByFields performs a k-way merge of the provided sorted input
+// sequences, per [Merge], ordered by the named fields, per [ByFields]. It
+// panics if any field is invalid.
+func MergeByFields[T any](fields []string, seqs ...iter.Seq[T]) iter.Seq[T] {
+	c, err := ByFields[T](fields...)
+	if err != nil {
+		panic(err)
+	}
+	return Merge(c, seqs...)
+}
+
+// fieldKey is a resolved field, of a [ByFields] comparator.
+type fieldKey struct {
+	// path is the index of each segment of the field's name, per
+	// [reflect.Value.FieldByIndex]
+	path  [][]int
+	cmp   func(a, b reflect.Value) int
+	order Order
+}
+
+// fieldsCacheKey identifies the fields of a type, joined by NUL.
+type fieldsCacheKey struct {
+	typ    reflect.Type
+	fields string
+}
+
+// fieldsCache holds the []fieldKey for each fieldsCacheKey.
+var fieldsCache sync.Map
+
+func cachedFieldKeys(typ reflect.Type, fields []string) ([]fieldKey, error) {
+	key := fieldsCacheKey{typ, strings.Join(fields, "\x00")}
+	if keys, ok := fieldsCache.Load(key); ok {
+		return keys.([]fieldKey), nil
+	}
+	keys := make([]fieldKey, 0, len(fields))
+	for _, field := range fields {
+		order := Asc
+		if name, ok := strings.CutPrefix(field, "-"); ok {
+			field, order = name, Desc
+		} else {
+			field = strings.TrimPrefix(field, "+")
+		}
+		k, err := resolveField(typ, field)
+		if err != nil {
+			return nil, err
+		}
+		k.order = order
+		keys = append(keys, k)
+	}
+	fieldsCache.Store(key, keys)
+	return keys, nil
+}
+
+// resolveField resolves the field named by the path `name`, of typ.
+func resolveField(typ reflect.Type, name string) (fieldKey, error) {
+	var k fieldKey
+	t := typ
+	for segment := range strings.SplitSeq(name, ".") {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return k, invalidField(typ, name, "not a struct field")
+		}
+		f, ok := taggedField(t, segment)
+		if !ok {
+			f, ok = t.FieldByName(segment)
+		}
+		if !ok {
+			return k, invalidField(typ, name, "not found")
+		}
+		if !f.IsExported() {
+			return k, invalidField(typ, name, "unexported")
+		}
+		k.path = append(k.path, f.Index)
+		t = f.Type
+	}
+	if k.cmp = fieldCompare(t); k.cmp == nil {
+		return k, invalidField(typ, name, "type "+t.String()+" is not ordered")
+	}
+	return k, nil
+}
+
+func invalidField(typ reflect.Type, name, reason string) error {
+	return fmt.Errorf("%w: %s of %s: %s", ErrInvalidField, name, typ, reason)
+}
+
+// fieldCompare returns the comparison function for values of type t, or nil,
+// if t is not ordered.
+func fieldCompare(t reflect.Type) func(a, b reflect.Value) int {
+	if m, ok := t.MethodByName("Compare"); ok && m.Type.NumIn() == 2 && m.Type.In(1) == t && m.Type.NumOut() == 1 && m.Type.Out(0).Kind() == reflect.Int {
+		return func(a, b reflect.Value) int {
+			return int(m.Func.Call([]reflect.Value{a, b})[0].Int())
+		}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return func(a, b reflect.Value) int {
+			return cmp.Compare(boolRank(a.Bool()), boolRank(b.Bool()))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b reflect.Value) int { return cmp.Compare(a.Int(), b.Int()) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b reflect.Value) int { return cmp.Compare(a.Uint(), b.Uint()) }
+	case reflect.Float32, reflect.Float64:
+		return func(a, b reflect.Value) int { return cmp.Compare(a.Float(), b.Float()) }
+	case reflect.String:
+		return func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) }
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return func(a, b reflect.Value) int { return bytes.Compare(a.Bytes(), b.Bytes()) }
+		}
+	}
+	return nil
+}
+
+func boolRank(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// value returns the field of v, or false, if it is behind a nil pointer.
+func (x *fieldKey) value(v reflect.Value) (reflect.Value, bool) {
+	for _, index := range x.path {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return v, false
+			}
+			v = v.Elem()
+		}
+		var err error
+		if v, err = v.FieldByIndexErr(index); err != nil {
+			return v, false
+		}
+	}
+	return v, true
+}
+
+// compareFields compares a and b by each of the keys, in order.
+func compareFields(keys []fieldKey, a, b reflect.Value) int {
+	for i := range keys {
+		k := &keys[i]
+		x, xok := k.value(a)
+		y, yok := k.value(b)
+		if k.order == Desc {
+			x, xok, y, yok = y, yok, x, xok
+		}
+		var v int
+		if xok && yok {
+			v = k.cmp(x, y)
+		} else {
+			v = cmp.Compare(boolRank(xok), boolRank(yok))
+		}
+		if v != 0 {
+			return v
+		}
+	}
+	return 0
+}
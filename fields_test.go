@@ -0,0 +1,156 @@
+package kway
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+type fieldsAddress struct {
+	City string
+	Zip  int
+}
+
+type FieldsMeta struct {
+	Source uint8
+}
+
+type fieldsRow struct {
+	*FieldsMeta
+	Name     string
+	Age      int32
+	Score    float64
+	Active   bool
+	Key      []byte
+	When     time.Time
+	Address  fieldsAddress
+	Previous *fieldsAddress
+	Tags     []string
+	hidden   int
+}
+
+func TestByFields(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	for _, tc := range []struct {
+		name   string
+		fields []string
+		a, b   fieldsRow
+		result int
+	}{
+		{"none", nil, fieldsRow{Name: "a"}, fieldsRow{Name: "b"}, 0},
+		{"string", []string{"Name"}, fieldsRow{Name: "a"}, fieldsRow{Name: "b"}, -1},
+		{"asc", []string{"+Name"}, fieldsRow{Name: "b"}, fieldsRow{Name: "a"}, 1},
+		{"desc", []string{"-Name"}, fieldsRow{Name: "a"}, fieldsRow{Name: "b"}, 1},
+		{"int", []string{"Age"}, fieldsRow{Age: -3}, fieldsRow{Age: 2}, -1},
+		{"float", []string{"Score"}, fieldsRow{Score: math.NaN()}, fieldsRow{Score: math.Inf(-1)}, -1},
+		{"bool", []string{"Active"}, fieldsRow{Active: true}, fieldsRow{}, 1},
+		{"bytes", []string{"Key"}, fieldsRow{Key: []byte("ab")}, fieldsRow{Key: []byte("b")}, -1},
+		{"method", []string{"When"}, fieldsRow{When: epoch.Add(time.Second)}, fieldsRow{When: epoch}, 1},
+		{"nested", []string{"Address.City"}, fieldsRow{Address: fieldsAddress{City: "x"}}, fieldsRow{Address: fieldsAddress{City: "y"}}, -1},
+		{"pointer", []string{"Previous.Zip"}, fieldsRow{Previous: &fieldsAddress{Zip: 2}}, fieldsRow{Previous: &fieldsAddress{Zip: 1}}, 1},
+		{"nil pointer", []string{"Previous.Zip"}, fieldsRow{}, fieldsRow{Previous: &fieldsAddress{}}, -1},
+		{"nil pointer desc", []string{"-Previous.Zip"}, fieldsRow{}, fieldsRow{Previous: &fieldsAddress{}}, 1},
+		{"embedded", []string{"Source"}, fieldsRow{FieldsMeta: &FieldsMeta{Source: 2}}, fieldsRow{FieldsMeta: &FieldsMeta{Source: 3}}, -1},
+		{"nil embedded", []string{"Source"}, fieldsRow{FieldsMeta: &FieldsMeta{}}, fieldsRow{}, 1},
+		{"multiple", []string{"Name", "-Age"}, fieldsRow{Name: "a", Age: 1}, fieldsRow{Name: "a", Age: 2}, 1},
+		{"equal", []string{"Name", "Age"}, fieldsRow{Name: "a", Age: 1}, fieldsRow{Name: "a", Age: 1}, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := ByFields[fieldsRow](tc.fields...)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if result := c(tc.a, tc.b); result != tc.result {
+				t.Errorf("Expected %d, got %d", tc.result, result)
+			}
+			p, err := ByFields[*fieldsRow](tc.fields...)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if result := p(&tc.a, &tc.b); result != tc.result {
+				t.Errorf("Expected %d for pointers, got %d", tc.result, result)
+			}
+		})
+	}
+}
+
+func TestByFields_NilPointer(t *testing.T) {
+	c, err := ByFields[*fieldsRow]("Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := c(nil, &fieldsRow{}); result != -1 {
+		t.Errorf("Expected -1, got %d", result)
+	}
+	if result := c(nil, nil); result != 0 {
+		t.Errorf("Expected 0, got %d", result)
+	}
+}
+
+func TestByFields_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		field   string
+		message string
+	}{
+		{"Missing", "kway: invalid field: Missing of kway.fieldsRow: not found"},
+		{"hidden", "kway: invalid field: hidden of kway.fieldsRow: unexported"},
+		{"Tags", "kway: invalid field: Tags of kway.fieldsRow: type []string is not ordered"},
+		{"Name.Length", "kway: invalid field: Name.Length of kway.fieldsRow: not a struct field"},
+		{"Address.", "kway: invalid field: Address. of kway.fieldsRow: not found"},
+	} {
+		t.Run(tc.field, func(t *testing.T) {
+			_, err := ByFields[fieldsRow]("Name", tc.field)
+			if !errors.Is(err, ErrInvalidField) {
+				t.Fatalf("Expected ErrInvalidField, got %v", err)
+			}
+			if err.Error() != tc.message {
+				t.Errorf("Expected %q, got %q", tc.message, err.Error())
+			}
+		})
+	}
+	if _, err := ByFields[int]("Name"); !errors.Is(err, ErrInvalidField) {
+		t.Errorf("Expected ErrInvalidField, got %v", err)
+	}
+}
+
+func TestMergeByFields(t *testing.T) {
+	a := []fieldsRow{{Name: "a", Age: 3}, {Name: "a", Age: 1}, {Name: "c", Age: 1}}
+	b := []fieldsRow{{Name: "a", Age: 2}, {Name: "b", Age: 5}}
+	var actual []string
+	for v := range MergeByFields([]string{"Name", "-Age"}, slices.Values(a), slices.Values(b)) {
+		actual = append(actual, v.Name+string(rune('0'+v.Age)))
+	}
+	if expected := []string{"a3", "a2", "a1", "b5", "c1"}; !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, ErrInvalidField) {
+			t.Errorf("Expected ErrInvalidField, got %v", err)
+		}
+	}()
+	MergeByFields[fieldsRow]([]string{"Missing"})
+}
+
+func TestByFields_Cached(t *testing.T) {
+	if _, err := ByFields[fieldsRow]("Name", "Age"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fieldsCache.Load(fieldsCacheKey{reflect.TypeFor[fieldsRow](), "Name\x00Age"}); !ok {
+		t.Error("Expected the fields to be cached")
+	}
+}
+
+func BenchmarkByFields(b *testing.B) {
+	c, err := ByFields[fieldsRow]("Name", "-Age")
+	if err != nil {
+		b.Fatal(err)
+	}
+	x, y := fieldsRow{Name: "a", Age: 1}, fieldsRow{Name: "a", Age: 2}
+	for b.Loop() {
+		c(x, y)
+	}
+}
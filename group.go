@@ -0,0 +1,214 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Merge2Group performs a k-way merge of the provided sequences, each sorted
+// by key, per `cmp`, yielding each distinct key once, with the values of all
+// elements with that key, i.e. a streaming group-by, over sorted shards.
+//
+// As the merge is stable, each group's values are ordered by input sequence,
+// then by their order within it. The yielded key is that of the group's first
+// element. Each values slice is newly allocated, and may be retained.
+func Merge2Group[K any, V any](cmp func(a, b K) int, seqs ...iter.Seq2[K, V]) iter.Seq2[K, []V] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	merged := Merge2(func(a1 K, _ V, b1 K, _ V) int { return cmp(a1, b1) }, seqs...)
+	return func(yield func(K, []V) bool) {
+		var (
+			key    K
+			values []V
+		)
+		for k, v := range merged {
+			if values != nil && cmp(key, k) != 0 {
+				if !yield(key, values) {
+					return
+				}
+				values = nil
+			}
+			if values == nil {
+				key = k
+			}
+			values = append(values, v)
+		}
+		if values != nil {
+			yield(key, values)
+		}
+	}
+}
+
+// Merge2Dedup performs a k-way merge of the provided sequences, each sorted
+// by key, per `cmp`, yielding exactly one element per distinct key, chosen
+// according to `policy`, e.g. [KeepLast], to merge snapshot shards, in which
+// later sequences supersede earlier ones.
+//
+// For [RejectDuplicates], iteration panics with a [*DuplicateKeyError], at
+// the second occurrence of any key, including within a single sequence.
+// For [KeepFirst], the yielded key is the first of those that compare equal,
+// and for [KeepLast], the last.
+func Merge2Dedup[K any, V any](cmp func(a, b K) int, policy DuplicatePolicy, seqs ...iter.Seq2[K, V]) iter.Seq2[K, V] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	if policy < KeepFirst || policy > RejectDuplicates {
+		panic("kway: invalid duplicate policy")
+	}
+	if !anySeq(seqs) {
+		return emptySeq2[K, V]
+	}
+	m := NewMerger2(func(a1 K, _ V, b1 K, _ V) int { return cmp(a1, b1) })
+	return func(yield func(K, V) bool) {
+		var (
+			prev wrappedSeq2Value[K, V]
+			ok   bool
+		)
+		for v := range m.merge(seqs, false, panicError) {
+			if ok && cmp(prev.v1, v.v1) == 0 {
+				switch policy {
+				case KeepLast:
+					prev = *v
+				case RejectDuplicates:
+					panic(&DuplicateKeyError{Key: v.v1, First: prev.i, Second: v.i})
+				}
+				continue
+			}
+			// KeepLast must wait for the end of each run
+			if policy == KeepLast {
+				if ok && !yield(prev.v1, prev.v2) {
+					return
+				}
+			} else if !yield(v.v1, v.v2) {
+				return
+			}
+			prev, ok = *v, true
+		}
+		if ok && policy == KeepLast {
+			yield(prev.v1, prev.v2)
+		}
+	}
+}
+
+// MergeCoalesce performs a k-way merge of the provided sorted input
+// sequences, per [Merge], passing each run of elements that compare equal,
+// per `cmp`, to `coalesce`, and yielding the single element it returns in
+// their place, e.g. to merge replicated records field by field. A run of a
+// single element is also passed to `coalesce`.
+//
+// As the merge is stable, the items are ordered by input sequence, then by
+// their order within it. The items slice is reused, and must not be retained
+// by `coalesce`.
+func MergeCoalesce[T any](cmp func(a, b T) int, coalesce func(items []T) T, seqs ...iter.Seq[T]) iter.Seq[T] {
+	if coalesce == nil {
+		panic("kway: nil coalesce function")
+	}
+	merged := Merge(cmp, seqs...)
+	return func(yield func(T) bool) {
+		var items []T
+		for v := range merged {
+			if len(items) != 0 && cmp(items[0], v) != 0 {
+				c := coalesce(items)
+				clear(items)
+				items = items[:0]
+				if !yield(c) {
+					return
+				}
+			}
+			items = append(items, v)
+		}
+		if len(items) != 0 {
+			yield(coalesce(items))
+		}
+	}
+}
+
+// MergeLatest performs a k-way merge of the provided sorted input sequences,
+// per [Merge], yielding, for each run of elements that compare equal, per
+// `cmp`, the element with the greatest version, per `version`, regardless
+// of the order of the sequences, i.e. last-writer-wins reconciliation of
+// replicated records, by embedded version or timestamp.
+//
+// Elements with equal versions are resolved deterministically, in favor of
+// the later sequence, then the later element within it, per [KeepLast].
+// Keyed sequences may be merged via [PairsOf].
+func MergeLatest[T any, V cmp.Ordered](cmp func(a, b T) int, version func(v T) V, seqs ...iter.Seq[T]) iter.Seq[T] {
+	if version == nil {
+		panic("kway: nil version function")
+	}
+	merged := Merge(cmp, seqs...)
+	return func(yield func(T) bool) {
+		var (
+			latest T
+			ver    V
+			ok     bool
+		)
+		for v := range merged {
+			if ok && cmp(latest, v) == 0 {
+				// ties are resolved by stability, as later elements follow
+				if vv := version(v); vv >= ver {
+					latest, ver = v, vv
+				}
+				continue
+			}
+			if ok && !yield(latest) {
+				return
+			}
+			latest, ver, ok = v, version(v), true
+		}
+		if ok {
+			yield(latest)
+		}
+	}
+}
+
+// MergeUpsert performs a k-way merge of the provided sorted input sequences,
+// per [Merge], yielding, for each run of elements that compare equal, per
+// `cmp`, only the element from the highest-indexed sequence, i.e. the last,
+// per [KeepLast], and dropping the others. This is the semantics of applying
+// sorted delta files, oldest first, over a sorted base snapshot, e.g.
+// MergeUpsert(cmp, base, delta1, delta2). Equal elements within the winning
+// sequence resolve to the last of them.
+//
+// To select the newest element by an embedded version, instead, see
+// [MergeLatest], and for keyed sequences, see [Merge2Dedup].
+func MergeUpsert[T any](cmp func(a, b T) int, seqs ...iter.Seq[T]) iter.Seq[T] {
+	merged := Merge(cmp, seqs...)
+	return func(yield func(T) bool) {
+		var (
+			last T
+			ok   bool
+		)
+		for v := range merged {
+			// later sequences follow, as the merge is stable
+			if ok && cmp(last, v) != 0 && !yield(last) {
+				return
+			}
+			last, ok = v, true
+		}
+		if ok {
+			yield(last)
+		}
+	}
+}
+
+// MergeFirst performs a k-way merge of the provided sorted input sequences,
+// per [Merge], yielding only the first element of each run of elements that
+// compare equal, per `cmp`. As the merge is stable, this is the element from
+// the lowest-indexed sequence, per [KeepFirst], e.g. to merge sources in
+// order of precedence.
+//
+// Rather than filtering the merged output, the suppressed elements are
+// skipped as each source is advanced, such that they are compared only
+// against the element yielded, and never reorder the merge.
+func MergeFirst[T any](cmp func(a, b T) int, seqs ...iter.Seq[T]) iter.Seq[T] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	if !anySeq(seqs) {
+		return emptySeq[T]
+	}
+	return (&seqMerge[T]{cmp: cmp, seqs: slices.Clone(seqs)}).first
+}
@@ -0,0 +1,519 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMerge2Group(t *testing.T) {
+	type group struct {
+		key    string
+		values []int
+	}
+
+	tests := []struct {
+		name     string
+		keys     [][]string
+		values   [][]int
+		expected []group
+	}{
+		{
+			name:   "grouped across sources",
+			keys:   [][]string{{"a", "b", "b", "d"}, {"a", "c", "d"}},
+			values: [][]int{{1, 2, 3, 4}, {5, 6, 7}},
+			expected: []group{
+				{"a", []int{1, 5}},
+				{"b", []int{2, 3}},
+				{"c", []int{6}},
+				{"d", []int{4, 7}},
+			},
+		},
+		{
+			name:     "single",
+			keys:     [][]string{{"a"}},
+			values:   [][]int{{1}},
+			expected: []group{{"a", []int{1}}},
+		},
+		{
+			name:   "empty",
+			keys:   [][]string{{}, {}},
+			values: [][]int{{}, {}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seqs := make([]iter.Seq2[string, int], len(tt.keys))
+			for i := range tt.keys {
+				seqs[i] = sliceSeq2(tt.keys[i], tt.values[i])
+			}
+			var result []group
+			for k, v := range Merge2Group(strings.Compare, seqs...) {
+				result = append(result, group{k, v})
+			}
+			if !slices.EqualFunc(result, tt.expected, func(a, b group) bool {
+				return a.key == b.key && slices.Equal(a.values, b.values)
+			}) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMerge2Group_firstKey(t *testing.T) {
+	var keys []string
+	var values [][]int
+	for k, v := range Merge2Group(CompareASCIIFold,
+		sliceSeq2([]string{"A", "b"}, []int{1, 2}),
+		sliceSeq2([]string{"a", "B"}, []int{3, 4}),
+	) {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	if !slices.Equal(keys, []string{"A", "b"}) {
+		t.Errorf("Expected [A b], got %v", keys)
+	}
+	if len(values) != 2 || !slices.Equal(values[0], []int{1, 3}) || !slices.Equal(values[1], []int{2, 4}) {
+		t.Errorf("Expected [[1 3] [2 4]], got %v", values)
+	}
+}
+
+func TestMerge2Group_earlyExit(t *testing.T) {
+	var keys []string
+	for k := range Merge2Group(strings.Compare,
+		sliceSeq2([]string{"a", "b", "c"}, []int{1, 2, 3}),
+		sliceSeq2([]string{"a", "c"}, []int{4, 5}),
+	) {
+		keys = append(keys, k)
+		if k == "b" {
+			break
+		}
+	}
+	if !slices.Equal(keys, []string{"a", "b"}) {
+		t.Errorf("Expected [a b], got %v", keys)
+	}
+}
+
+func TestMerge2Group_nilCompare(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil comparison function")
+		}
+	}()
+	Merge2Group[string, int](nil)
+}
+
+func TestMerge2Dedup(t *testing.T) {
+	seqs := func() []iter.Seq2[string, int] {
+		return []iter.Seq2[string, int]{
+			sliceSeq2([]string{"a", "b", "d"}, []int{1, 2, 3}),
+			sliceSeq2([]string{"a", "c", "d"}, []int{4, 5, 6}),
+			sliceSeq2([]string{"d"}, []int{7}),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		policy   DuplicatePolicy
+		expected []Pair[string, int]
+	}{
+		{name: "keep first", policy: KeepFirst, expected: []Pair[string, int]{{"a", 1}, {"b", 2}, {"c", 5}, {"d", 3}}},
+		{name: "keep last", policy: KeepLast, expected: []Pair[string, int]{{"a", 4}, {"b", 2}, {"c", 5}, {"d", 7}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := collectSeq(PairsOf(Merge2Dedup(strings.Compare, tt.policy, seqs()...)))
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMerge2Dedup_withinSource(t *testing.T) {
+	seq := sliceSeq2([]string{"a", "a", "b"}, []int{1, 2, 3})
+	if result := collectSeq(PairsOf(Merge2Dedup(strings.Compare, KeepLast, seq))); !slices.Equal(result, []Pair[string, int]{{"a", 2}, {"b", 3}}) {
+		t.Errorf("Unexpected result: %v", result)
+	}
+	if result := collectSeq(PairsOf(Merge2Dedup(strings.Compare, KeepFirst, seq))); !slices.Equal(result, []Pair[string, int]{{"a", 1}, {"b", 3}}) {
+		t.Errorf("Unexpected result: %v", result)
+	}
+}
+
+func TestMerge2Dedup_rejectDuplicates(t *testing.T) {
+	var result []string
+	func() {
+		defer func() {
+			err, ok := recover().(*DuplicateKeyError)
+			if !ok || err.Key != "b" || err.First != 0 || err.Second != 1 {
+				t.Errorf("Expected duplicate key error, got %v", err)
+			}
+		}()
+		for k := range Merge2Dedup(strings.Compare, RejectDuplicates,
+			sliceSeq2([]string{"a", "b"}, []int{1, 2}),
+			sliceSeq2([]string{"b"}, []int{3}),
+		) {
+			result = append(result, k)
+		}
+	}()
+	if !slices.Equal(result, []string{"a", "b"}) {
+		t.Errorf("Expected [a b], got %v", result)
+	}
+
+	result = collectSeq(Keys(Merge2Dedup(strings.Compare, RejectDuplicates,
+		sliceSeq2([]string{"a"}, []int{1}),
+		sliceSeq2([]string{"b"}, []int{2}),
+	)))
+	if !slices.Equal(result, []string{"a", "b"}) {
+		t.Errorf("Expected [a b], got %v", result)
+	}
+}
+
+func TestMerge2Dedup_earlyExit(t *testing.T) {
+	for _, policy := range []DuplicatePolicy{KeepFirst, KeepLast} {
+		var keys []string
+		for k := range Merge2Dedup(strings.Compare, policy,
+			sliceSeq2([]string{"a", "b", "c"}, []int{1, 2, 3}),
+			sliceSeq2([]string{"a"}, []int{4}),
+		) {
+			keys = append(keys, k)
+			break
+		}
+		if !slices.Equal(keys, []string{"a"}) {
+			t.Errorf("Policy %d: expected [a], got %v", policy, keys)
+		}
+	}
+}
+
+func TestMerge2Dedup_panics(t *testing.T) {
+	for _, fn := range []func(){
+		func() { Merge2Dedup[string, int](nil, KeepFirst) },
+		func() { Merge2Dedup[string, int](strings.Compare, DuplicatePolicy(99)) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			fn()
+		}()
+	}
+	if result := collectSeq(Keys(Merge2Dedup[string, int](strings.Compare, KeepLast))); len(result) != 0 {
+		t.Errorf("Expected no elements, got %v", result)
+	}
+}
+
+func TestMergeCoalesce(t *testing.T) {
+	type record struct {
+		key   string
+		value int
+	}
+	cmpFunc := func(a, b record) int { return strings.Compare(a.key, b.key) }
+	sum := func(items []record) record {
+		r := record{key: items[0].key}
+		for _, v := range items {
+			r.value = r.value*10 + v.value
+		}
+		return r
+	}
+
+	tests := []struct {
+		name     string
+		input    [][]record
+		expected []record
+	}{
+		{
+			name:     "no sequences",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:     "no duplicates",
+			input:    [][]record{{{"a", 1}, {"c", 3}}, {{"b", 2}}},
+			expected: []record{{"a", 1}, {"b", 2}, {"c", 3}},
+		},
+		{
+			name:     "stable order",
+			input:    [][]record{{{"a", 1}, {"a", 2}, {"b", 3}}, {{"a", 4}, {"b", 5}}, {{"a", 6}}},
+			expected: []record{{"a", 1246}, {"b", 35}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seqs []iter.Seq[record]
+			for _, s := range tt.input {
+				seqs = append(seqs, slices.Values(s))
+			}
+			if result := collectSeq(MergeCoalesce(cmpFunc, sum, seqs...)); !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMergeCoalesce_earlyExit(t *testing.T) {
+	var calls int
+	coalesce := func(items []int) int {
+		calls++
+		return items[0]
+	}
+	seq := MergeCoalesce(cmp.Compare[int], coalesce, slices.Values([]int{1, 1, 2, 3}), slices.Values([]int{2, 3}))
+	for v := range seq {
+		if v != 1 {
+			t.Errorf("Expected 1, got %d", v)
+		}
+		break
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+}
+
+func TestMergeCoalesce_nilCoalesce(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "kway: nil coalesce function" {
+			t.Errorf("Expected panic, got %v", r)
+		}
+	}()
+	MergeCoalesce(cmp.Compare[int], nil)
+}
+
+func TestMergeLatest(t *testing.T) {
+	type record struct {
+		key     string
+		version int
+		source  int
+	}
+	cmpFunc := func(a, b record) int { return strings.Compare(a.key, b.key) }
+	version := func(r record) int { return r.version }
+
+	tests := []struct {
+		name     string
+		input    [][]record
+		expected []record
+	}{
+		{
+			name:     "no sequences",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:     "greatest version",
+			input:    [][]record{{{"a", 3, 0}, {"b", 1, 0}}, {{"a", 1, 1}, {"b", 2, 1}}, {{"a", 2, 2}, {"c", 1, 2}}},
+			expected: []record{{"a", 3, 0}, {"b", 2, 1}, {"c", 1, 2}},
+		},
+		{
+			name:     "ties favor later sequence",
+			input:    [][]record{{{"a", 1, 0}}, {{"a", 1, 1}}, {{"a", 0, 2}}},
+			expected: []record{{"a", 1, 1}},
+		},
+		{
+			name:     "within sequence",
+			input:    [][]record{{{"a", 2, 0}, {"a", 2, 1}, {"a", 1, 2}}},
+			expected: []record{{"a", 2, 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seqs []iter.Seq[record]
+			for _, s := range tt.input {
+				seqs = append(seqs, slices.Values(s))
+			}
+			if result := collectSeq(MergeLatest(cmpFunc, version, seqs...)); !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMergeLatest_earlyExit(t *testing.T) {
+	seq := MergeLatest(cmp.Compare[int], func(v int) int { return v }, slices.Values([]int{1, 2, 3}), slices.Values([]int{1, 3}))
+	var result []int
+	for v := range seq {
+		result = append(result, v)
+		if len(result) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(result, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", result)
+	}
+}
+
+func TestMergeLatest_nilVersion(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "kway: nil version function" {
+			t.Errorf("Expected panic, got %v", r)
+		}
+	}()
+	MergeLatest[int, int](cmp.Compare[int], nil)
+}
+
+func TestMergeUpsert(t *testing.T) {
+	type record struct {
+		key    string
+		source int
+	}
+	cmpFunc := func(a, b record) int { return strings.Compare(a.key, b.key) }
+
+	tests := []struct {
+		name     string
+		input    [][]record
+		expected []record
+	}{
+		{
+			name:     "no sequences",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:     "deltas over base",
+			input:    [][]record{{{"a", 0}, {"b", 0}, {"c", 0}, {"e", 0}}, {{"b", 1}, {"d", 1}}, {{"b", 2}, {"e", 2}}},
+			expected: []record{{"a", 0}, {"b", 2}, {"c", 0}, {"d", 1}, {"e", 2}},
+		},
+		{
+			name:     "empty base",
+			input:    [][]record{nil, {{"a", 1}}},
+			expected: []record{{"a", 1}},
+		},
+		{
+			name:     "within sequence",
+			input:    [][]record{{{"a", 0}}, {{"a", 1}, {"a", 2}}},
+			expected: []record{{"a", 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seqs []iter.Seq[record]
+			for _, s := range tt.input {
+				seqs = append(seqs, slices.Values(s))
+			}
+			if result := collectSeq(MergeUpsert(cmpFunc, seqs...)); !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMergeUpsert_earlyExit(t *testing.T) {
+	seq := MergeUpsert(cmp.Compare[int], slices.Values([]int{1, 2, 3}), slices.Values([]int{1, 3}))
+	var result []int
+	for v := range seq {
+		result = append(result, v)
+		if len(result) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(result, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", result)
+	}
+}
+
+func TestMergeFirst(t *testing.T) {
+	type record struct {
+		key    string
+		source int
+	}
+	cmpFunc := func(a, b record) int { return strings.Compare(a.key, b.key) }
+
+	tests := []struct {
+		name     string
+		input    [][]record
+		expected []record
+	}{
+		{
+			name:     "no sequences",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:     "first source wins",
+			input:    [][]record{{{"a", 0}, {"c", 0}}, {{"a", 1}, {"b", 1}, {"c", 1}}, {{"b", 2}, {"d", 2}}},
+			expected: []record{{"a", 0}, {"b", 1}, {"c", 0}, {"d", 2}},
+		},
+		{
+			name:     "within sequence",
+			input:    [][]record{{{"a", 0}, {"a", 1}, {"b", 2}}, {{"a", 3}, {"a", 4}}, nil},
+			expected: []record{{"a", 0}, {"b", 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seqs []iter.Seq[record]
+			for _, s := range tt.input {
+				seqs = append(seqs, slices.Values(s))
+			}
+			if result := collectSeq(MergeFirst(cmpFunc, seqs...)); !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMergeFirst_random(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 50 {
+		var seqs []iter.Seq[int]
+		for range r.IntN(6) {
+			values := make([]int, r.IntN(30))
+			for j := range values {
+				values[j] = r.IntN(20)
+			}
+			slices.Sort(values)
+			seqs = append(seqs, slices.Values(values))
+		}
+		expected := slices.Compact(collectSeq(Merge(cmp.Compare[int], seqs...)))
+		if result := collectSeq(MergeFirst(cmp.Compare[int], seqs...)); !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	}
+}
+
+func TestMergeFirst_comparisons(t *testing.T) {
+	// duplicates are compared only against the element yielded
+	var calls int
+	cmpFunc := func(a, b int) int {
+		calls++
+		return cmp.Compare(a, b)
+	}
+	result := collectSeq(MergeFirst(cmpFunc, slices.Values([]int{1, 1, 1, 1, 2}), slices.Values([]int{3})))
+	if !slices.Equal(result, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", result)
+	}
+	if calls > 10 {
+		t.Errorf("Expected at most 10 comparisons, got %d", calls)
+	}
+}
+
+func TestMergeFirst_copiesSequences(t *testing.T) {
+	seqs := []iter.Seq[int]{slices.Values([]int{1, 2}), slices.Values([]int{1, 3})}
+	seq := MergeFirst(cmp.Compare[int], seqs...)
+	seqs[0], seqs[1] = nil, nil
+	if result := collectSeq(seq); !slices.Equal(result, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", result)
+	}
+}
+
+func TestMergeFirst_earlyExit(t *testing.T) {
+	seq := MergeFirst(cmp.Compare[int], slices.Values([]int{1, 2, 3}), slices.Values([]int{1, 3}))
+	var result []int
+	for v := range seq {
+		result = append(result, v)
+		if len(result) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(result, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", result)
+	}
+}
@@ -3,12 +3,19 @@ package kway
 import (
 	"container/heap"
 	"iter"
+	"slices"
 )
 
 type mergeState[T interface{ index() int }] struct {
-	cmp   func(a, b T) int
-	seqs  []iter.Seq[T]
-	items []T
+	cmp      func(a, b T) int
+	seqs     []iter.Seq[T]
+	items    []T
+	strategy Strategy
+	// nodes and done are used by StrategyLoserTree
+	nodes []int
+	done  []bool
+	// moves counts the elements moved, if not nil, see [Stats.Moves]
+	moves *int64
 }
 
 func (x *mergeState[T]) Len() int { return len(x.items) }
@@ -23,6 +30,9 @@ func (x *mergeState[T]) Less(i, j int) bool {
 
 func (x *mergeState[T]) Swap(i, j int) {
 	x.items[i], x.items[j] = x.items[j], x.items[i]
+	if x.moves != nil && i != j {
+		*x.moves++
+	}
 }
 
 func (x *mergeState[T]) Push(v any) {
@@ -39,15 +49,36 @@ func (x *mergeState[T]) Pop() (item any) {
 }
 
 func (x *mergeState[T]) all(yield func(T) bool) {
-	x.items = make([]T, 0, len(x.seqs))
 	pulls := make([]func() (T, bool), len(x.seqs))
 	for i, seq := range x.seqs {
 		if seq != nil {
 			next, stop := iter.Pull(seq)
 			defer stop()
+			pulls[i] = next
+		}
+	}
+	x.merge(pulls, yield)
+}
+
+// merge merges the sources represented by their next functions, which may be
+// nil, and are not called again once they report no more elements. The
+// capacity of the existing buffers of x is reused.
+func (x *mergeState[T]) merge(pulls []func() (T, bool), yield func(T) bool) {
+	switch x.strategy {
+	case StrategyLoserTree:
+		mergeLoserTree(x, pulls, yield)
+		return
+	case StrategyHeap4:
+		mergeDAryHeap(x, pulls, yield)
+		return
+	}
+	x.items = slices.Grow(x.items[:0], len(pulls))
+	for i, next := range pulls {
+		if next != nil {
 			if v, ok := next(); ok {
 				x.items = append(x.items, v)
-				pulls[i] = next
+			} else {
+				pulls[i] = nil
 			}
 		}
 	}
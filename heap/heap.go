@@ -0,0 +1,142 @@
+// Package heap implements a generic binary min-heap, ordered by a comparison
+// function, as an alternative to [container/heap] that requires no
+// interface implementation, or boxing of elements.
+package heap
+
+// Heap is a binary min-heap, backed by a slice, ordered by a comparison
+// function. The minimum element, per the comparison function, is at index 0.
+// The zero value is not usable, see [New].
+type Heap[T any] struct {
+	cmp func(a, b T) int
+	s   []T
+}
+
+// New returns a [Heap] ordered by `cmp`, which should behave like
+// [cmp.Compare], taking ownership of `s`, which is arranged into heap order
+// in O(n) time. The slice may be nil.
+func New[T any](cmp func(a, b T) int, s []T) *Heap[T] {
+	if cmp == nil {
+		panic("heap: nil comparison function")
+	}
+	h := &Heap[T]{cmp: cmp, s: s}
+	h.Init()
+	return h
+}
+
+// Len returns the number of elements.
+func (h *Heap[T]) Len() int { return len(h.s) }
+
+// Slice returns the underlying slice, in heap order. It must not be modified,
+// except via [Heap.Fix], while in use by the Heap.
+func (h *Heap[T]) Slice() []T { return h.s }
+
+// Init re-establishes the heap order, after the elements of [Heap.Slice]
+// have been modified, in O(n) time.
+func (h *Heap[T]) Init() {
+	n := len(h.s)
+	for i := n/2 - 1; i >= 0; i-- {
+		h.down(i, n)
+	}
+}
+
+// Push adds v to the heap, in O(log n) time.
+func (h *Heap[T]) Push(v T) {
+	h.s = append(h.s, v)
+	h.up(len(h.s) - 1)
+}
+
+// Pop removes and returns the minimum element, in O(log n) time. It panics
+// if the heap is empty.
+func (h *Heap[T]) Pop() T {
+	return h.Remove(0)
+}
+
+// Peek returns the minimum element, without removing it, and true, or false
+// if the heap is empty.
+func (h *Heap[T]) Peek() (v T, ok bool) {
+	if len(h.s) == 0 {
+		return v, false
+	}
+	return h.s[0], true
+}
+
+// Remove removes and returns the element at index i, of [Heap.Slice], in
+// O(log n) time. It panics if i is out of range.
+func (h *Heap[T]) Remove(i int) T {
+	if i < 0 || i >= len(h.s) {
+		panic("heap: index out of range")
+	}
+	n := len(h.s) - 1
+	if n != i {
+		h.swap(i, n)
+		if !h.down(i, n) {
+			h.up(i)
+		}
+	}
+	v := h.s[n]
+	h.s[n] = *new(T)
+	h.s = h.s[:n]
+	return v
+}
+
+// Fix re-establishes the heap order, after the element at index i, of
+// [Heap.Slice], has changed, in O(log n) time. It is cheaper than removing
+// and pushing the element.
+func (h *Heap[T]) Fix(i int) {
+	if i < 0 || i >= len(h.s) {
+		panic("heap: index out of range")
+	}
+	if !h.down(i, len(h.s)) {
+		h.up(i)
+	}
+}
+
+// Replace replaces the minimum element with v, returning the previous
+// minimum, in O(log n) time. It is equivalent to, but cheaper than, a Pop
+// followed by a Push. It panics if the heap is empty.
+func (h *Heap[T]) Replace(v T) T {
+	if len(h.s) == 0 {
+		panic("heap: empty heap")
+	}
+	old := h.s[0]
+	h.s[0] = v
+	h.down(0, len(h.s))
+	return old
+}
+
+func (h *Heap[T]) less(i, j int) bool { return h.cmp(h.s[i], h.s[j]) < 0 }
+
+func (h *Heap[T]) swap(i, j int) { h.s[i], h.s[j] = h.s[j], h.s[i] }
+
+func (h *Heap[T]) up(j int) {
+	for {
+		i := (j - 1) / 2 // parent
+		if i == j || !h.less(j, i) {
+			break
+		}
+		h.swap(i, j)
+		j = i
+	}
+}
+
+// down moves the element at i0 down, within the first n elements, reporting
+// whether it moved.
+func (h *Heap[T]) down(i0, n int) bool {
+	i := i0
+	for {
+		j1 := 2*i + 1
+		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+			break
+		}
+		j := j1 // left child
+		if j2 := j1 + 1; j2 < n && h.less(j2, j1) {
+			j = j2 // right child
+		}
+		if !h.less(j, i) {
+			break
+		}
+		h.swap(i, j)
+		i = j
+	}
+	return i > i0
+}
@@ -0,0 +1,151 @@
+package heap
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func drain[T any](h *Heap[T]) (result []T) {
+	for h.Len() != 0 {
+		result = append(result, h.Pop())
+	}
+	return result
+}
+
+func TestHeap(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		push  []int
+	}{
+		{name: "empty"},
+		{name: "init only", input: []int{5, 3, 8, 1, 9, 2}},
+		{name: "push only", push: []int{5, 3, 8, 1, 9, 2}},
+		{name: "mixed with duplicates", input: []int{4, 4, 1}, push: []int{1, 7, 0, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New(cmp.Compare[int], slices.Clone(tt.input))
+			for _, v := range tt.push {
+				h.Push(v)
+			}
+			expected := slices.Sorted(slices.Values(slices.Concat(tt.input, tt.push)))
+			if result := drain(h); !slices.Equal(result, expected) {
+				t.Errorf("Expected %v, got %v", expected, result)
+			}
+		})
+	}
+}
+
+func TestHeap_Random(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	h := New[int](cmp.Compare[int], nil)
+	var expected []int
+	for range 1000 {
+		switch r.IntN(4) {
+		case 0, 1:
+			v := r.IntN(100)
+			h.Push(v)
+			expected = append(expected, v)
+		case 2:
+			if h.Len() != 0 {
+				slices.Sort(expected)
+				if v := h.Pop(); v != expected[0] {
+					t.Fatalf("Expected %d, got %d", expected[0], v)
+				}
+				expected = expected[1:]
+			}
+		case 3:
+			if h.Len() != 0 {
+				i := r.IntN(h.Len())
+				v := h.Remove(i)
+				j := slices.Index(expected, v)
+				expected = slices.Delete(expected, j, j+1)
+			}
+		}
+	}
+	slices.Sort(expected)
+	if result := drain(h); !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestHeap_Peek(t *testing.T) {
+	h := New(cmp.Compare[int], []int{3, 1, 2})
+	if v, ok := h.Peek(); !ok || v != 1 {
+		t.Errorf("Expected (1, true), got (%d, %v)", v, ok)
+	}
+	if h.Len() != 3 {
+		t.Errorf("Expected Len() = 3, got %d", h.Len())
+	}
+	drain(h)
+	if _, ok := h.Peek(); ok {
+		t.Error("Expected false for empty heap")
+	}
+}
+
+func TestHeap_Fix(t *testing.T) {
+	h := New(cmp.Compare[int], []int{1, 5, 3, 7, 9})
+	s := h.Slice()
+	i := slices.Index(s, 9)
+	s[i] = 0
+	h.Fix(i)
+	s[0] = 10 // replaces the 0, now the minimum
+	h.Fix(0)
+	if result := drain(h); !slices.Equal(result, []int{1, 3, 5, 7, 10}) {
+		t.Errorf("Expected [1 3 5 7 10], got %v", result)
+	}
+}
+
+func TestHeap_Init(t *testing.T) {
+	h := New(cmp.Compare[int], []int{1, 2, 3})
+	s := h.Slice()
+	s[0], s[2] = 9, 0
+	h.Init()
+	if result := drain(h); !slices.Equal(result, []int{0, 2, 9}) {
+		t.Errorf("Expected [0 2 9], got %v", result)
+	}
+}
+
+func TestHeap_Replace(t *testing.T) {
+	h := New(cmp.Compare[int], []int{2, 4, 6})
+	if v := h.Replace(5); v != 2 {
+		t.Errorf("Expected 2, got %d", v)
+	}
+	if result := drain(h); !slices.Equal(result, []int{4, 5, 6}) {
+		t.Errorf("Expected [4 5 6], got %v", result)
+	}
+}
+
+func TestHeap_Panics(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"nil comparison function": func() { New[int](nil, nil) },
+		"pop empty":               func() { New[int](cmp.Compare[int], nil).Pop() },
+		"replace empty":           func() { New[int](cmp.Compare[int], nil).Replace(1) },
+		"fix out of range":        func() { New(cmp.Compare[int], []int{1}).Fix(1) },
+		"remove out of range":     func() { New(cmp.Compare[int], []int{1}).Remove(-1) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
+
+func BenchmarkHeap(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 2))
+	h := New(cmp.Compare[int], make([]int, 0, 1024))
+	for range 1024 {
+		h.Push(r.Int())
+	}
+	for b.Loop() {
+		h.Replace(r.Int())
+	}
+}
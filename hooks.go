@@ -0,0 +1,46 @@
+package kway
+
+// SourceHooks are callbacks for the lifecycle of each source of a merge, see
+// [WithSourceHooks]. Each is optional, and is called with the index of the
+// source, from the goroutine iterating the merged sequence.
+type SourceHooks struct {
+	// OnOpen is called once the source is opened, before its first element
+	// is pulled.
+	OnOpen func(source int)
+	// OnExhausted is called once the source ends, i.e. has no more elements.
+	// It is not called for sources that remain when the merge stops.
+	OnExhausted func(source int)
+	// OnStop is called once the merge is finished with the source, after it
+	// has been stopped, whether it was exhausted or not, including if
+	// iteration stops early, or panics.
+	OnStop func(source int)
+}
+
+// WithSourceHooks configures callbacks for the lifecycle of each source of
+// the Merger's merges, e.g. to log or time each source, or to release the
+// resources backing it, such as file handles, as soon as the merge is
+// finished with it. Sources are opened at the start of each iteration of the
+// merged sequence, and stopped once it ends, in the reverse order.
+func WithSourceHooks(hooks SourceHooks) Option {
+	return func(o *options) {
+		o.hooks = hooks
+	}
+}
+
+// empty returns whether no hooks are configured.
+func (x SourceHooks) empty() bool {
+	return x.OnOpen == nil && x.OnExhausted == nil && x.OnStop == nil
+}
+
+// exhaustedNext wraps next, calling onExhausted once it first ends.
+func exhaustedNext[E any](onExhausted func(source int), source int, next func() (E, bool)) func() (E, bool) {
+	var done bool
+	return func() (E, bool) {
+		v, ok := next()
+		if !ok && !done {
+			done = true
+			onExhausted(source)
+		}
+		return v, ok
+	}
+}
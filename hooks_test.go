@@ -0,0 +1,97 @@
+package kway
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+)
+
+// recordHooks returns hooks recording each event, as "op source".
+func recordHooks(events *[]string) SourceHooks {
+	record := func(op string) func(int) {
+		return func(source int) { *events = append(*events, fmt.Sprintf("%s %d", op, source)) }
+	}
+	return SourceHooks{OnOpen: record("open"), OnExhausted: record("exhausted"), OnStop: record("stop")}
+}
+
+func TestWithSourceHooks(t *testing.T) {
+	var events []string
+	stopped := make([]bool, 3)
+	seq := func(i int, values ...int) iter.Seq[int] {
+		return func(yield func(int) bool) {
+			defer func() { stopped[i] = true }()
+			for _, v := range values {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+	hooks := recordHooks(&events)
+	onStop := hooks.OnStop
+	hooks.OnStop = func(source int) {
+		if !stopped[source] {
+			t.Errorf("Expected source %d to be stopped before OnStop", source)
+		}
+		onStop(source)
+	}
+	m := NewMerger(cmp.Compare[int], WithSourceHooks(hooks))
+	if actual, expected := collectSeq(m.Merge(seq(0, 1, 4), nil, seq(2, 2, 3))), []int{1, 2, 3, 4}; !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+	if expected := []string{"open 0", "open 2", "exhausted 2", "exhausted 0", "stop 2", "stop 0"}; !slices.Equal(events, expected) {
+		t.Errorf("Expected %v, got %v", expected, events)
+	}
+}
+
+func TestWithSourceHooks_EarlyTermination(t *testing.T) {
+	var events []string
+	m := NewMerger(cmp.Compare[int], WithSourceHooks(recordHooks(&events)))
+	for v := range m.Merge(slices.Values([]int{1}), slices.Values([]int{2, 3})) {
+		if v == 2 {
+			break
+		}
+	}
+	if expected := []string{"open 0", "open 1", "exhausted 0", "stop 1", "stop 0"}; !slices.Equal(events, expected) {
+		t.Errorf("Expected %v, got %v", expected, events)
+	}
+}
+
+func TestWithSourceHooks_Panic(t *testing.T) {
+	var events []string
+	m := NewMerger(cmp.Compare[int], WithVerifySorted(), WithSourceHooks(SourceHooks{OnStop: recordHooks(&events).OnStop}))
+	func() {
+		defer func() {
+			if _, ok := recover().(*OrderError); !ok {
+				t.Error("Expected an order error")
+			}
+		}()
+		for range m.Merge(slices.Values([]int{2, 1}), slices.Values([]int{3})) {
+		}
+	}()
+	if expected := []string{"stop 1", "stop 0"}; !slices.Equal(events, expected) {
+		t.Errorf("Expected %v, got %v", expected, events)
+	}
+}
+
+func TestWithSourceHooks_Merger2(t *testing.T) {
+	var events []string
+	m := NewMerger2(func(a1, a2, b1, b2 int) int { return cmp.Compare(a2, b2) }, WithSourceHooks(recordHooks(&events)))
+	for range m.Merge(slices.All([]int{1, 2}), slices.All([]int{3})) {
+	}
+	if expected := []string{"open 0", "open 1", "exhausted 0", "exhausted 1", "stop 1", "stop 0"}; !slices.Equal(events, expected) {
+		t.Errorf("Expected %v, got %v", expected, events)
+	}
+}
+
+func TestExhaustedNext(t *testing.T) {
+	var calls []int
+	next := exhaustedNext(func(source int) { calls = append(calls, source) }, 7, func() (int, bool) { return 0, false })
+	next()
+	next()
+	if !slices.Equal(calls, []int{7}) {
+		t.Errorf("Expected [7], got %v", calls)
+	}
+}
@@ -0,0 +1,137 @@
+package kway
+
+import (
+	"iter"
+	"sort"
+)
+
+// Indexed is a collection accessed by index, such as a sorted container
+// implementing [sort.Interface], with an accessor, allowing it to be merged
+// without first being copied into a slice. See [NewSortedIndex] and
+// [IndexFunc].
+type Indexed[T any] interface {
+	// Len returns the number of elements.
+	Len() int
+	// At returns the element at index i, in [0, Len()).
+	At(i int) T
+}
+
+// IndexFunc returns an [Indexed] of `n` elements, accessed by `at`, e.g. for
+// a container with differently named methods.
+func IndexFunc[T any](n int, at func(i int) T) Indexed[T] {
+	if n < 0 {
+		panic("kway: negative length")
+	}
+	if at == nil {
+		panic("kway: nil accessor")
+	}
+	return indexFunc[T]{n, at}
+}
+
+type indexFunc[T any] struct {
+	n  int
+	at func(i int) T
+}
+
+func (x indexFunc[T]) Len() int { return x.n }
+
+func (x indexFunc[T]) At(i int) T { return x.at(i) }
+
+// SortedIndex is a [Seekable] backed by a sorted [Indexed] collection,
+// equivalent to a [SortedSlice]. The collection must not be modified while
+// in use.
+type SortedIndex[T any] struct {
+	cmp func(a, b T) int
+	x   Indexed[T]
+}
+
+var _ Seekable[any] = (*SortedIndex[any])(nil)
+
+// NewSortedIndex returns a [SortedIndex] for `x`, which must be sorted
+// according to `cmp`.
+func NewSortedIndex[T any](cmp func(a, b T) int, x Indexed[T]) *SortedIndex[T] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	if x == nil {
+		panic("kway: nil collection")
+	}
+	return &SortedIndex[T]{cmp: cmp, x: x}
+}
+
+// Len returns the number of elements.
+func (x *SortedIndex[T]) Len() int { return x.x.Len() }
+
+// All returns a sequence of all elements.
+func (x *SortedIndex[T]) All() iter.Seq[T] { return x.from(0) }
+
+// Seek returns a sequence of the elements greater than or equal to key,
+// located by binary search.
+func (x *SortedIndex[T]) Seek(key T) iter.Seq[T] {
+	return x.from(sort.Search(x.x.Len(), func(i int) bool { return x.cmp(x.x.At(i), key) >= 0 }))
+}
+
+func (x *SortedIndex[T]) from(i int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := x.x.Len(); i < n; i++ {
+			if !yield(x.x.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Cursor returns an [IndexCursor] over all elements, which may be merged
+// without creating coroutines, see [MergeCursors].
+func (x *SortedIndex[T]) Cursor() *IndexCursor[T] {
+	return &IndexCursor[T]{cmp: x.cmp, x: x.x, n: x.x.Len()}
+}
+
+// IndexCursor is a [SeekCursor] over a sorted [Indexed] collection, see
+// [SortedIndex.Cursor], equivalent to a [SliceCursor].
+type IndexCursor[T any] struct {
+	cmp func(a, b T) int
+	x   Indexed[T]
+	n   int
+	i   int
+}
+
+var (
+	_ SeekCursor[any]  = (*IndexCursor[any])(nil)
+	_ CloneCursor[any] = (*IndexCursor[any])(nil)
+)
+
+// Next returns the next element of the collection.
+func (x *IndexCursor[T]) Next() (v T, ok bool) {
+	if x.i == x.n {
+		return v, false
+	}
+	v = x.x.At(x.i)
+	x.i++
+	return v, true
+}
+
+// Prev returns the previous element of the collection.
+func (x *IndexCursor[T]) Prev() (v T, ok bool) {
+	if x.i == 0 {
+		return v, false
+	}
+	x.i--
+	return x.x.At(x.i), true
+}
+
+// Seek positions the cursor before the first element greater than or equal
+// to key, per [SliceCursor.Seek].
+func (x *IndexCursor[T]) Seek(key T) {
+	x.i = gallopIndex(x.cmp, x.n, x.x.At, x.i, key)
+}
+
+// Len returns the number of remaining elements, after the position.
+func (x *IndexCursor[T]) Len() int { return x.n - x.i }
+
+// Clone returns an *IndexCursor over the same collection, at the same
+// position.
+func (x *IndexCursor[T]) Clone() BidiCursor[T] {
+	c := *x
+	return &c
+}
@@ -0,0 +1,124 @@
+package kway
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// legacyList is a sorted container implementing sort.Interface, with an
+// accessor.
+type legacyList []int
+
+func (x legacyList) Len() int           { return len(x) }
+func (x legacyList) Less(i, j int) bool { return x[i] < x[j] }
+func (x legacyList) Swap(i, j int)      { x[i], x[j] = x[j], x[i] }
+func (x legacyList) At(i int) int       { return x[i] }
+
+var _ sort.Interface = legacyList(nil)
+
+func TestSortedIndex(t *testing.T) {
+	a := legacyList{5, 1, 3}
+	sort.Sort(a)
+	b := []int{2, 4, 6}
+	x := NewSortedIndex(cmp.Compare[int], a)
+	y := NewSortedIndex(cmp.Compare[int], IndexFunc(len(b), func(i int) int { return b[i] }))
+
+	if x.Len() != 3 {
+		t.Errorf("Expected 3, got %d", x.Len())
+	}
+	expected := []int{1, 2, 3, 4, 5, 6}
+	if result := collectSeq(Merge(cmp.Compare[int], x.All(), y.All())); !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+	if result := collectSeq(MergeCursors(cmp.Compare[int], x.Cursor(), y.Cursor())); !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+	if result := collectSeq(Merge(cmp.Compare[int], x.Seek(4), y.Seek(4))); !slices.Equal(result, []int{4, 5, 6}) {
+		t.Errorf("Expected [4 5 6], got %v", result)
+	}
+	for v := range x.Seek(2) {
+		if v != 3 {
+			t.Errorf("Expected 3, got %d", v)
+		}
+		break
+	}
+}
+
+func TestIndexCursor(t *testing.T) {
+	s := []int{1, 3, 3, 5, 7, 9, 11}
+	for from := 0; from <= len(s); from++ {
+		for key := 0; key <= 12; key++ {
+			c := NewSortedIndex(cmp.Compare[int], legacyList(s)).Cursor()
+			c.i = from
+			c.Seek(key)
+			expected, _ := slices.BinarySearch(s, key)
+			if c.i != expected {
+				t.Errorf("Seek(%d) from %d: Expected %d, got %d", key, from, expected, c.i)
+			}
+			if c.Len() != len(s)-expected {
+				t.Errorf("Expected length %d, got %d", len(s)-expected, c.Len())
+			}
+		}
+	}
+
+	c := NewSortedIndex(cmp.Compare[int], legacyList{1, 2}).Cursor()
+	if _, ok := c.Prev(); ok {
+		t.Error("Expected no previous element")
+	}
+	var result []int
+	for v, ok := c.Next(); ok; v, ok = c.Next() {
+		result = append(result, v)
+	}
+	if v, ok := c.Prev(); !ok || v != 2 {
+		t.Errorf("Expected 2, got %v", v)
+	}
+	if !slices.Equal(result, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", result)
+	}
+
+	large := make(legacyList, 1<<16)
+	for i := range large {
+		large[i] = i
+	}
+	var cursors []Cursor[int]
+	cursors = append(cursors, NewSortedIndex(cmp.Compare[int], large).Cursor(), NewSortedSlice(cmp.Compare[int], []int{7, 70000}).Cursor())
+	if result := collectSeq(IntersectCursors(cmp.Compare[int], cursors...)); !slices.Equal(result, []int{7}) {
+		t.Errorf("Expected [7], got %v", result)
+	}
+}
+
+func TestIndexCursor_Clone(t *testing.T) {
+	c := NewSortedIndex(cmp.Compare[int], legacyList{1, 2, 3}).Cursor()
+	c.Next()
+	clone := c.Clone()
+	if v, ok := clone.Next(); !ok || v != 2 {
+		t.Errorf("Expected 2, got %v", v)
+	}
+	if v, ok := c.Prev(); !ok || v != 1 {
+		t.Errorf("Expected the original to be unaffected, got %v", v)
+	}
+}
+
+func TestIndexFunc_panics(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		f     func()
+		panic string
+	}{
+		{"negative length", func() { IndexFunc(-1, func(int) int { return 0 }) }, "kway: negative length"},
+		{"nil accessor", func() { IndexFunc[int](0, nil) }, "kway: nil accessor"},
+		{"nil cmp", func() { NewSortedIndex[int](nil, legacyList{}) }, "kway: nil comparison function"},
+		{"nil collection", func() { NewSortedIndex[int](cmp.Compare[int], nil) }, "kway: nil collection"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tt.panic {
+					t.Errorf("Expected %q, got %v", tt.panic, r)
+				}
+			}()
+			tt.f()
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package kway
+
+import (
+	"iter"
+)
+
+// intersectSource is an input of an intersection.
+type intersectSource[T any] interface {
+	// next returns the next element
+	next() (T, bool)
+	// seek returns the next element greater than or equal to key,
+	// skipping any lesser elements
+	seek(key T) (T, bool)
+	// remaining returns the number of remaining elements, or -1 if unknown
+	remaining() int
+}
+
+// pullIntersectSource is an intersectSource that advances element-at-a-time.
+type pullIntersectSource[T any] struct {
+	cmp  func(a, b T) int
+	pull func() (T, bool)
+}
+
+func (x *pullIntersectSource[T]) next() (T, bool) { return x.pull() }
+
+func (x *pullIntersectSource[T]) seek(key T) (T, bool) {
+	for {
+		v, ok := x.pull()
+		if !ok || x.cmp(v, key) >= 0 {
+			return v, ok
+		}
+	}
+}
+
+func (x *pullIntersectSource[T]) remaining() int { return -1 }
+
+// cursorIntersectSource is an intersectSource that advances using a seek
+// method, such as [SeekCursor.Seek].
+type cursorIntersectSource[T any] struct {
+	c      Cursor[T]
+	seekTo func(key T)
+}
+
+func (x cursorIntersectSource[T]) next() (T, bool) { return x.c.Next() }
+
+func (x cursorIntersectSource[T]) seek(key T) (T, bool) {
+	x.seekTo(key)
+	return x.c.Next()
+}
+
+func (x cursorIntersectSource[T]) remaining() int {
+	if c, ok := x.c.(interface{ Len() int }); ok {
+		return c.Len()
+	}
+	return -1
+}
+
+// cursorIntersect returns the intersectSource for c, advancing using its
+// Seek or SeekGE method, if any.
+func cursorIntersect[T any](cmp func(a, b T) int, c Cursor[T]) intersectSource[T] {
+	switch s := c.(type) {
+	case interface{ Seek(key T) }:
+		return cursorIntersectSource[T]{c: c, seekTo: s.Seek}
+	case interface{ SeekGE(key T) }:
+		return cursorIntersectSource[T]{c: c, seekTo: s.SeekGE}
+	}
+	return &pullIntersectSource[T]{cmp: cmp, pull: c.Next}
+}
+
+// Intersect yields the elements present in every one of the provided sorted
+// input sequences, per `cmp`, advancing through each sequence
+// element-at-a-time. Each distinct element is yielded once, as it occurs
+// first in the first sequence. A nil sequence is empty, so the intersection
+// is empty.
+//
+// See [IntersectCursors] for an algorithm which skips elements, orders of
+// magnitude faster when the sizes of the inputs are skewed.
+func Intersect[T any](cmp func(a, b T) int, seqs ...iter.Seq[T]) iter.Seq[T] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	if len(seqs) == 0 {
+		return emptySeq[T]
+	}
+	for _, seq := range seqs {
+		if seq == nil {
+			return emptySeq[T]
+		}
+	}
+	return func(yield func(T) bool) {
+		srcs := make([]intersectSource[T], len(seqs))
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			srcs[i] = &pullIntersectSource[T]{cmp: cmp, pull: next}
+		}
+		intersect(cmp, srcs, yield)
+	}
+}
+
+// IntersectCursors yields the elements present in every one of the provided
+// sorted cursors, per [Intersect], galloping: each candidate is taken from
+// the source with the fewest remaining elements, if known, then the other
+// sources are advanced to it, using Seek, which, for a [SliceCursor], is an
+// exponential search. The cost is therefore driven by the smallest source,
+// rather than the sum of their sizes. Cursors implementing a Len method,
+// returning the number of remaining elements, like [SliceCursor], are
+// preferred as the driving source, accordingly.
+//
+// Cursors lacking a Seek method, per [SeekCursor], are advanced using a
+// SeekGE method, per [OrderedCursor], if any, or else element-at-a-time.
+//
+// The cursors are consumed by iteration, so the returned sequence is
+// intended to be iterated once.
+func IntersectCursors[T any](cmp func(a, b T) int, cursors ...Cursor[T]) iter.Seq[T] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	if len(cursors) == 0 {
+		return emptySeq[T]
+	}
+	srcs := make([]intersectSource[T], len(cursors))
+	for i, c := range cursors {
+		if c == nil {
+			return emptySeq[T]
+		}
+		srcs[i] = cursorIntersect(cmp, c)
+	}
+	return func(yield func(T) bool) {
+		intersect(cmp, srcs, yield)
+	}
+}
+
+// IntersectSlices yields the elements present in every one of the provided
+// sorted slices, per [IntersectCursors]. Unlike the cursors, the slices may
+// be intersected any number of times.
+func IntersectSlices[T any](cmp func(a, b T) int, slices ...[]T) iter.Seq[T] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	return func(yield func(T) bool) {
+		cursors := make([]Cursor[T], len(slices))
+		for i, s := range slices {
+			cursors[i] = &SliceCursor[T]{cmp: cmp, s: s}
+		}
+		IntersectCursors(cmp, cursors...)(yield)
+	}
+}
+
+// intersect implements the intersection of srcs, which must not be empty,
+// by repeatedly advancing each source to the current candidate, until all
+// match, in round-robin order, starting after the source of the candidate.
+func intersect[T any](cmp func(a, b T) int, srcs []intersectSource[T], yield func(T) bool) {
+	vals := make([]T, len(srcs))
+	driver := smallestSource(srcs)
+	candidate, ok := srcs[driver].next()
+	for ok {
+		vals[driver] = candidate
+		matched := 1
+		for i := (driver + 1) % len(srcs); matched < len(srcs); i = (i + 1) % len(srcs) {
+			var v T
+			if v, ok = srcs[i].seek(candidate); !ok {
+				return
+			}
+			vals[i] = v
+			if cmp(v, candidate) == 0 {
+				matched++
+			} else {
+				candidate, matched = v, 1
+			}
+		}
+		if !yield(vals[0]) {
+			return
+		}
+
+		// the next candidate is the first greater element of the driver
+		driver = smallestSource(srcs)
+		for {
+			var v T
+			if v, ok = srcs[driver].next(); !ok || cmp(v, candidate) != 0 {
+				candidate = v
+				break
+			}
+		}
+	}
+}
+
+// smallestSource returns the index of the source with the fewest remaining
+// elements, preferring sources for which the number is known, then the
+// lowest index.
+func smallestSource[T any](srcs []intersectSource[T]) int {
+	best, n := 0, srcs[0].remaining()
+	for i := 1; i < len(srcs); i++ {
+		if v := srcs[i].remaining(); v >= 0 && (n < 0 || v < n) {
+			best, n = i, v
+		}
+	}
+	return best
+}
@@ -0,0 +1,229 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// naiveIntersect returns the distinct elements present in every input.
+func naiveIntersect(inputs [][]int) []int {
+	if len(inputs) == 0 {
+		return nil
+	}
+	var result []int
+	for _, v := range slices.Compact(slices.Clone(inputs[0])) {
+		found := true
+		for _, s := range inputs[1:] {
+			if _, ok := slices.BinarySearch(s, v); !ok {
+				found = false
+				break
+			}
+		}
+		if found {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    [][]int
+		expected []int
+	}{
+		{
+			name:     "no sequences",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:     "single sequence",
+			input:    [][]int{{1, 1, 2, 3}},
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:     "disjoint",
+			input:    [][]int{{1, 3, 5}, {2, 4, 6}},
+			expected: nil,
+		},
+		{
+			name:     "common elements",
+			input:    [][]int{{1, 2, 3, 5, 8}, {2, 3, 4, 8, 9}, {0, 2, 8}},
+			expected: []int{2, 8},
+		},
+		{
+			name:     "duplicates",
+			input:    [][]int{{1, 1, 2, 2, 2, 4}, {1, 2, 2, 4, 4}, {1, 1, 1, 4}},
+			expected: []int{1, 4},
+		},
+		{
+			name:     "empty sequence",
+			input:    [][]int{{1, 2}, {}, {1, 2}},
+			expected: nil,
+		},
+		{
+			name:     "skewed",
+			input:    [][]int{{5, 500}, {0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 100, 499, 500, 501}},
+			expected: []int{5, 500},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seqs []iter.Seq[int]
+			for _, s := range tt.input {
+				seqs = append(seqs, slices.Values(s))
+			}
+			if result := collectSeq(Intersect(cmp.Compare[int], seqs...)); !slices.Equal(result, tt.expected) {
+				t.Errorf("Intersect: Expected %v, got %v", tt.expected, result)
+			}
+			if result := collectSeq(IntersectSlices(cmp.Compare[int], tt.input...)); !slices.Equal(result, tt.expected) {
+				t.Errorf("IntersectSlices: Expected %v, got %v", tt.expected, result)
+			}
+			var cursors []Cursor[int]
+			for _, s := range tt.input {
+				cursors = append(cursors, NewSortedSlice(cmp.Compare[int], s).Cursor())
+			}
+			if result := collectSeq(IntersectCursors(cmp.Compare[int], cursors...)); !slices.Equal(result, tt.expected) {
+				t.Errorf("IntersectCursors: Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestIntersect_Random(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 500 {
+		inputs := make([][]int, 1+rng.IntN(4))
+		for i := range inputs {
+			inputs[i] = make([]int, rng.IntN(40))
+			for j := range inputs[i] {
+				inputs[i][j] = rng.IntN(30)
+			}
+			slices.Sort(inputs[i])
+		}
+		expected := naiveIntersect(inputs)
+		var seqs []iter.Seq[int]
+		for _, s := range inputs {
+			seqs = append(seqs, slices.Values(s))
+		}
+		if result := collectSeq(Intersect(cmp.Compare[int], seqs...)); !slices.Equal(result, expected) {
+			t.Fatalf("Intersect %v: Expected %v, got %v", inputs, expected, result)
+		}
+		if result := collectSeq(IntersectSlices(cmp.Compare[int], inputs...)); !slices.Equal(result, expected) {
+			t.Fatalf("IntersectSlices %v: Expected %v, got %v", inputs, expected, result)
+		}
+	}
+}
+
+func TestIntersect_FirstSequence(t *testing.T) {
+	type value struct {
+		key, seqID int
+	}
+	cmpFunc := func(a, b value) int { return cmp.Compare(a.key, b.key) }
+	a := []value{{1, 0}, {2, 0}, {2, 1}}
+	b := []value{{2, 2}, {2, 3}}
+	expected := []value{{2, 0}}
+	if result := collectSeq(Intersect(cmpFunc, slices.Values(a), slices.Values(b))); !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+	if result := collectSeq(IntersectSlices(cmpFunc, b, a)); !slices.Equal(result, []value{{2, 2}}) {
+		t.Errorf("Expected [{2 2}], got %v", result)
+	}
+}
+
+func TestIntersect_Nil(t *testing.T) {
+	if result := collectSeq(Intersect(cmp.Compare[int], slices.Values([]int{1}), nil)); result != nil {
+		t.Errorf("Expected nil, got %v", result)
+	}
+	c := NewSortedSlice(cmp.Compare[int], []int{1}).Cursor()
+	if result := collectSeq(IntersectCursors(cmp.Compare[int], c, nil)); result != nil {
+		t.Errorf("Expected nil, got %v", result)
+	}
+	for _, f := range []func(){
+		func() { Intersect[int](nil) },
+		func() { IntersectCursors[int](nil) },
+		func() { IntersectSlices[int](nil) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != "kway: nil comparison function" {
+					t.Errorf("Expected panic, got %v", r)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestIntersect_EarlyExit(t *testing.T) {
+	var stopped bool
+	seq := func(yield func(int) bool) {
+		defer func() { stopped = true }()
+		for _, v := range []int{1, 2, 3} {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+	for v := range Intersect(cmp.Compare[int], seq, slices.Values([]int{1, 2, 3})) {
+		if v != 1 {
+			t.Errorf("Expected 1, got %v", v)
+		}
+		break
+	}
+	if !stopped {
+		t.Error("Expected sequence to be stopped")
+	}
+	var result []int
+	for v := range IntersectSlices(cmp.Compare[int], []int{1, 2, 3}, []int{2, 3}) {
+		result = append(result, v)
+		break
+	}
+	if !slices.Equal(result, []int{2}) {
+		t.Errorf("Expected [2], got %v", result)
+	}
+}
+
+func TestIntersectSlices_Gallops(t *testing.T) {
+	large := make([]int, 1<<16)
+	for i := range large {
+		large[i] = i
+	}
+	var n int
+	cmpFunc := func(a, b int) int {
+		n++
+		return cmp.Compare(a, b)
+	}
+	result := collectSeq(IntersectSlices(cmpFunc, large, []int{100, 30000, 60000}))
+	if !slices.Equal(result, []int{100, 30000, 60000}) {
+		t.Errorf("Expected [100 30000 60000], got %v", result)
+	}
+	if n > 200 {
+		t.Errorf("Expected at most 200 comparisons, got %d", n)
+	}
+}
+
+func BenchmarkIntersect_Skewed(b *testing.B) {
+	large := make([]int, 1_000_000)
+	for i := range large {
+		large[i] = i * 2
+	}
+	small := []int{10, 1000, 100_000, 500_000, 999_998, 1_500_000}
+	b.Run("pull", func(b *testing.B) {
+		for b.Loop() {
+			for range Intersect(cmp.Compare[int], slices.Values(small), slices.Values(large)) {
+			}
+		}
+	})
+	b.Run("galloping", func(b *testing.B) {
+		for b.Loop() {
+			for range IntersectSlices(cmp.Compare[int], small, large) {
+			}
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Interval is a half-open interval, [Start, End), containing the values
+// greater than or equal to Start, and less than End. An interval with an End
+// less than or equal to its Start is empty.
+type Interval[T any] struct {
+	Start T
+	End   T
+}
+
+// MergeIntervals performs a k-way merge of the provided input sequences of
+// intervals, each sorted by Start, coalescing intervals that overlap or are
+// adjacent, i.e. where one ends at the Start of the next. The output is the
+// minimal sorted sequence of disjoint, non-adjacent intervals covering the
+// same values as the input. Empty intervals are ignored.
+func MergeIntervals[T cmp.Ordered](seqs ...iter.Seq[Interval[T]]) iter.Seq[Interval[T]] {
+	return MergeIntervalsFunc(cmp.Compare[T], seqs...)
+}
+
+// MergeIntervalsFunc is [MergeIntervals], for interval bounds ordered by
+// `cmp`, e.g. [netip.Addr.Compare], for IP ranges. See [Merge] for details
+// on the comparison function.
+func MergeIntervalsFunc[T any](cmp func(a, b T) int, seqs ...iter.Seq[Interval[T]]) iter.Seq[Interval[T]] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	merged := Merge(func(a, b Interval[T]) int { return cmp(a.Start, b.Start) }, seqs...)
+	return func(yield func(Interval[T]) bool) {
+		var (
+			cur  Interval[T]
+			have bool
+		)
+		for v := range merged {
+			switch {
+			case cmp(v.End, v.Start) <= 0:
+				// empty
+			case !have:
+				cur, have = v, true
+			case cmp(v.Start, cur.End) <= 0:
+				if cmp(v.End, cur.End) > 0 {
+					cur.End = v.End
+				}
+			default:
+				if !yield(cur) {
+					return
+				}
+				cur = v
+			}
+		}
+		if have {
+			yield(cur)
+		}
+	}
+}
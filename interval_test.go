@@ -0,0 +1,98 @@
+package kway
+
+import (
+	"iter"
+	"net/netip"
+	"slices"
+	"testing"
+)
+
+func TestMergeIntervals(t *testing.T) {
+	type iv = Interval[int]
+
+	tests := []struct {
+		name     string
+		input    [][]iv
+		expected []iv
+	}{
+		{
+			name:     "no sequences",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:     "disjoint",
+			input:    [][]iv{{{1, 2}, {5, 6}}, {{3, 4}}},
+			expected: []iv{{1, 2}, {3, 4}, {5, 6}},
+		},
+		{
+			name:     "overlapping",
+			input:    [][]iv{{{1, 5}, {10, 12}}, {{3, 7}, {11, 20}}},
+			expected: []iv{{1, 7}, {10, 20}},
+		},
+		{
+			name:     "adjacent",
+			input:    [][]iv{{{1, 3}}, {{3, 5}}, {{5, 6}}},
+			expected: []iv{{1, 6}},
+		},
+		{
+			name:     "contained",
+			input:    [][]iv{{{1, 10}}, {{2, 3}, {4, 5}}},
+			expected: []iv{{1, 10}},
+		},
+		{
+			name:     "within a sequence",
+			input:    [][]iv{{{1, 4}, {2, 6}, {8, 9}}},
+			expected: []iv{{1, 6}, {8, 9}},
+		},
+		{
+			name:     "empty intervals",
+			input:    [][]iv{{{1, 1}, {3, 2}, {4, 5}}, {{5, 5}}},
+			expected: []iv{{4, 5}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seqs []iter.Seq[iv]
+			for _, s := range tt.input {
+				seqs = append(seqs, sliceSeq(s))
+			}
+			if result := collectSeq(MergeIntervals(seqs...)); !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMergeIntervals_EarlyTermination(t *testing.T) {
+	for v := range MergeIntervals(sliceSeq([]Interval[int]{{1, 2}, {3, 4}})) {
+		if v != (Interval[int]{1, 2}) {
+			t.Errorf("Expected {1 2}, got %v", v)
+		}
+		break
+	}
+}
+
+func TestMergeIntervalsFunc(t *testing.T) {
+	r := func(start, end string) Interval[netip.Addr] {
+		return Interval[netip.Addr]{netip.MustParseAddr(start), netip.MustParseAddr(end)}
+	}
+	result := collectSeq(MergeIntervalsFunc(netip.Addr.Compare,
+		sliceSeq([]Interval[netip.Addr]{r("10.0.0.0", "10.0.1.0"), r("192.168.0.0", "192.168.0.8")}),
+		sliceSeq([]Interval[netip.Addr]{r("10.0.1.0", "10.0.2.0")}),
+	))
+	expected := []Interval[netip.Addr]{r("10.0.0.0", "10.0.2.0"), r("192.168.0.0", "192.168.0.8")}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestMergeIntervalsFunc_NilCompareFunction(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil comparison function")
+		}
+	}()
+	MergeIntervalsFunc[int](nil)
+}
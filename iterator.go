@@ -0,0 +1,82 @@
+package kway
+
+import (
+	"iter"
+	"sync"
+)
+
+// Iterator is a pull-based handle to a sequence, such as the output of a
+// merge, which is safe for concurrent use. Calls to [Iterator.Next] are
+// serialized, and each returns a distinct element, allowing a single merged
+// sequence to be consumed by multiple goroutines, e.g. as a shared work queue.
+//
+// An Iterator is also a [Cursor], and may be merged as such.
+type Iterator[T any] struct {
+	mu   sync.Mutex
+	next func() (T, bool)
+	stop func()
+	done bool
+	// peeked is set if v is the next element, per Peek
+	peeked bool
+	v      T
+}
+
+var _ Cursor[any] = (*Iterator[any])(nil)
+
+// NewIterator returns an [Iterator] over `seq`, which is pulled via
+// [iter.Pull]. [Iterator.Stop] must be called, unless the sequence is known
+// to be exhausted, to release the resources held by `seq`.
+func NewIterator[T any](seq iter.Seq[T]) *Iterator[T] {
+	if seq == nil {
+		panic("kway: nil sequence")
+	}
+	next, stop := iter.Pull(seq)
+	return &Iterator[T]{next: next, stop: stop}
+}
+
+// Next returns the next element and true, or false if the sequence is
+// exhausted, or the Iterator has been stopped. Panics from the sequence are
+// propagated to the caller, after which the Iterator is stopped.
+func (x *Iterator[T]) Next() (v T, ok bool) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	v, ok = x.peek()
+	x.v, x.peeked = *new(T), false
+	return v, ok
+}
+
+// Peek returns the next element and true, without consuming it, such that it
+// is returned by the next call to [Iterator.Next], or false per
+// [Iterator.Next].
+func (x *Iterator[T]) Peek() (T, bool) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.peek()
+}
+
+func (x *Iterator[T]) peek() (v T, ok bool) {
+	if x.peeked {
+		return x.v, true
+	}
+	if x.done {
+		return v, false
+	}
+	defer func() {
+		if !ok {
+			x.done = true
+		}
+	}()
+	v, ok = x.next()
+	x.v, x.peeked = v, ok
+	return v, ok
+}
+
+// Stop stops the Iterator, releasing the underlying sequence. It waits for
+// any concurrent call to [Iterator.Next], and may be called multiple times.
+func (x *Iterator[T]) Stop() {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.done = true
+	x.v, x.peeked = *new(T), false
+	x.stop()
+}
@@ -0,0 +1,159 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestIterator(t *testing.T) {
+	it := NewIterator(Merge(cmp.Compare[int], sliceSeq([]int{1, 3}), sliceSeq([]int{2})))
+	defer it.Stop()
+
+	var result []int
+	for {
+		v, ok := it.Next()
+		if !ok {
+			break
+		}
+		result = append(result, v)
+	}
+	if !slices.Equal(result, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", result)
+	}
+	if _, ok := it.Next(); ok {
+		t.Error("Expected exhausted iterator")
+	}
+}
+
+func TestIterator_Concurrent(t *testing.T) {
+	var seqs []iter.Seq[int]
+	for i := range 4 {
+		var s []int
+		for j := range 1000 {
+			s = append(s, j*4+i)
+		}
+		seqs = append(seqs, sliceSeq(s))
+	}
+	var expected []int
+	for i := range 4000 {
+		expected = append(expected, i)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var result []int
+	it := NewIterator(Merge(cmp.Compare[int], seqs...))
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var local []int
+			for {
+				v, ok := it.Next()
+				if !ok {
+					break
+				}
+				if len(local) != 0 && v <= local[len(local)-1] {
+					t.Errorf("Expected increasing elements, got %d after %d", v, local[len(local)-1])
+				}
+				local = append(local, v)
+			}
+			mu.Lock()
+			result = append(result, local...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	slices.Sort(result)
+	if !slices.Equal(result, expected) {
+		t.Errorf("Expected each element exactly once, got %d elements", len(result))
+	}
+}
+
+func TestIterator_Stop(t *testing.T) {
+	var stopped bool
+	it := NewIterator(func(yield func(int) bool) {
+		defer func() { stopped = true }()
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	})
+	if v, ok := it.Next(); !ok || v != 0 {
+		t.Errorf("Expected (0, true), got (%d, %v)", v, ok)
+	}
+	it.Stop()
+	it.Stop()
+	if !stopped {
+		t.Error("Expected sequence to be stopped")
+	}
+	if _, ok := it.Next(); ok {
+		t.Error("Expected stopped iterator")
+	}
+}
+
+func TestIterator_Peek(t *testing.T) {
+	it := NewIterator(sliceSeq([]int{1, 2}))
+	defer it.Stop()
+	for _, expected := range []int{1, 2} {
+		for range 2 {
+			if v, ok := it.Peek(); !ok || v != expected {
+				t.Errorf("Peek: expected (%d, true), got (%d, %v)", expected, v, ok)
+			}
+		}
+		if v, ok := it.Next(); !ok || v != expected {
+			t.Errorf("Next: expected (%d, true), got (%d, %v)", expected, v, ok)
+		}
+	}
+	if _, ok := it.Peek(); ok {
+		t.Error("Expected exhausted iterator")
+	}
+
+	it = NewIterator(sliceSeq([]int{1, 2}))
+	it.Peek()
+	it.Stop()
+	if _, ok := it.Next(); ok {
+		t.Error("Expected stopped iterator")
+	}
+}
+
+func TestIterator_Panic(t *testing.T) {
+	it := NewIterator(func(yield func(int) bool) {
+		yield(1)
+		panic("boom")
+	})
+	it.Next()
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("Expected panic %q, got %v", "boom", r)
+			}
+		}()
+		it.Next()
+	}()
+	if _, ok := it.Next(); ok {
+		t.Error("Expected stopped iterator")
+	}
+}
+
+func TestIterator_Cursor(t *testing.T) {
+	a := NewIterator(sliceSeq([]int{1, 4}))
+	b := NewIterator(sliceSeq([]int{2, 3}))
+	if result := collectSeq(MergeCursors[int](cmp.Compare[int], a, b)); !slices.Equal(result, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected [1 2 3 4], got %v", result)
+	}
+}
+
+func TestNewIterator_NilSequence(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil sequence")
+		}
+	}()
+	NewIterator[int](nil)
+}
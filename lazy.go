@@ -0,0 +1,156 @@
+package kway
+
+import (
+	"iter"
+
+	"github.com/joeycumines/go-kway/heap"
+)
+
+// LazySource is a source of a lazy merge, see [MergeLazy], which is opened
+// only once the merge requires its first element, e.g. to avoid opening many
+// files or connections up front.
+type LazySource[T any] struct {
+	// Open opens the source, returning its elements, which must be sorted.
+	// A nil sequence is treated as empty.
+	Open func() (iter.Seq[T], error)
+	// Min and Max are the least and greatest elements of the source,
+	// inclusive, if Bounded, e.g. per the metadata of a file. A bounded source
+	// is not opened until the merge reaches Min, and is never opened if its
+	// bounds lie outside the range of [MergeLazyRange].
+	Min, Max T
+	Bounded  bool
+}
+
+// MergeLazy performs a k-way merge of the provided sources, per [Merge],
+// opening each only once the merge requires its first element. Sources
+// without bounds are opened at the start of each iteration, while bounded
+// sources are opened once each element less than their Min has been yielded.
+// Each opened source is stopped once iteration ends. Sources with a nil Open
+// function are ignored.
+//
+// Elements are yielded with a nil error. If a source fails to open, the zero
+// value is yielded with the error, and iteration stops.
+func MergeLazy[T any](cmp func(a, b T) int, srcs ...LazySource[T]) iter.Seq2[T, error] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	return (&lazyMerge[T]{cmp: cmp, srcs: srcs}).merge
+}
+
+// MergeLazyRange performs a k-way merge of the elements of the provided
+// sources greater than or equal to `lo`, and less than `hi`, per
+// [MergeLazy]. Bounded sources that contain no elements within the range are
+// never opened, and bounded sources with a Min less than `lo` are opened once
+// the merge reaches `lo`. Elements less than `lo` are skipped, and each source
+// is read only until its first element greater than or equal to `hi`.
+func MergeLazyRange[T any](cmp func(a, b T) int, lo, hi T, srcs ...LazySource[T]) iter.Seq2[T, error] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	return (&lazyMerge[T]{cmp: cmp, srcs: srcs, lo: lo, hi: hi, ranged: true}).merge
+}
+
+// lazyMerge is the merge of [MergeLazy] or [MergeLazyRange].
+type lazyMerge[T any] struct {
+	cmp    func(a, b T) int
+	srcs   []LazySource[T]
+	lo, hi T
+	ranged bool
+}
+
+// lazyValue is the current element of the source with index i, or, if the
+// source is not yet open, the element at which it must be opened.
+type lazyValue[T any] struct {
+	i    int
+	v    T
+	open bool
+}
+
+// compare orders the sources, falling back to comparison by index
+// (documented behavior). A source that is not yet open orders no later than
+// its first element, which is at least v.
+func (x *lazyMerge[T]) compare(a, b lazyValue[T]) int {
+	if v := x.cmp(a.v, b.v); v != 0 {
+		return v
+	}
+	return a.i - b.i
+}
+
+func (x *lazyMerge[T]) merge(yield func(T, error) bool) {
+	pulls := make([]func() (T, bool), len(x.srcs))
+	var stops []func()
+	defer func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}()
+	// open opens the source with index i, returning its first element
+	open := func(i int) (T, bool, error) {
+		seq, err := x.srcs[i].Open()
+		if err != nil || seq == nil {
+			return *new(T), false, err
+		}
+		next, stop := iter.Pull(seq)
+		stops = append(stops, stop)
+		pulls[i] = next
+		v, ok := next()
+		for x.ranged && ok && x.cmp(v, x.lo) < 0 {
+			v, ok = next()
+		}
+		return v, x.within(v, ok), nil
+	}
+
+	items := make([]lazyValue[T], 0, len(x.srcs))
+	for i, src := range x.srcs {
+		switch {
+		case src.Open == nil:
+		case !src.Bounded:
+			v, ok, err := open(i)
+			if err != nil {
+				yield(*new(T), err)
+				return
+			}
+			if ok {
+				items = append(items, lazyValue[T]{i, v, true})
+			}
+		case !x.ranged:
+			items = append(items, lazyValue[T]{i: i, v: src.Min})
+		case x.cmp(src.Max, x.lo) >= 0 && x.cmp(src.Min, x.hi) < 0:
+			v := src.Min
+			if x.cmp(v, x.lo) < 0 {
+				v = x.lo
+			}
+			items = append(items, lazyValue[T]{i: i, v: v})
+		}
+	}
+
+	h := heap.New(x.compare, items)
+	for h.Len() != 0 {
+		top := &h.Slice()[0]
+		var ok bool
+		if top.open {
+			if !yield(top.v, nil) {
+				return
+			}
+			top.v, ok = pulls[top.i]()
+			ok = x.within(top.v, ok)
+		} else {
+			var err error
+			if top.v, ok, err = open(top.i); err != nil {
+				yield(*new(T), err)
+				return
+			}
+			top.open = true
+		}
+		if ok {
+			h.Fix(0)
+		} else {
+			h.Pop()
+		}
+	}
+}
+
+// within returns whether v, if ok, is less than the upper bound, if ranged.
+func (x *lazyMerge[T]) within(v T, ok bool) bool {
+	return ok && (!x.ranged || x.cmp(v, x.hi) < 0)
+}
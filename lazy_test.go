@@ -0,0 +1,206 @@
+package kway
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// lazySources returns lazy sources for the slices, optionally bounded,
+// recording the indexes of those opened, and stopped, in order.
+func lazySources(s [][]int, bounded bool) (srcs []LazySource[int], opened, stopped *[]int) {
+	opened, stopped = new([]int), new([]int)
+	for i, values := range s {
+		src := LazySource[int]{Open: func() (iter.Seq[int], error) {
+			*opened = append(*opened, i)
+			return func(yield func(int) bool) {
+				defer func() { *stopped = append(*stopped, i) }()
+				for _, v := range values {
+					if !yield(v) {
+						return
+					}
+				}
+			}, nil
+		}}
+		if bounded && len(values) != 0 {
+			src.Min, src.Max, src.Bounded = values[0], values[len(values)-1], true
+		}
+		srcs = append(srcs, src)
+	}
+	return srcs, opened, stopped
+}
+
+func collectLazy(t *testing.T, seq iter.Seq2[int, error]) []int {
+	t.Helper()
+	var result []int
+	for v, err := range seq {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+func TestMergeLazy(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 50 {
+		var (
+			s    [][]int
+			seqs []iter.Seq[int]
+		)
+		for range r.IntN(8) {
+			values := make([]int, r.IntN(20))
+			offset := r.IntN(100)
+			for j := range values {
+				values[j] = offset + r.IntN(50)
+			}
+			slices.Sort(values)
+			s = append(s, values)
+			seqs = append(seqs, slices.Values(values))
+		}
+		expected := collectSeq(Merge(cmp.Compare[int], seqs...))
+		for _, bounded := range []bool{false, true} {
+			srcs, opened, stopped := lazySources(s, bounded)
+			if actual := collectLazy(t, MergeLazy(cmp.Compare[int], srcs...)); !slices.Equal(actual, expected) {
+				t.Errorf("Expected %v, got %v", expected, actual)
+			}
+			if len(*stopped) != len(*opened) {
+				t.Errorf("Expected all %d opened sources to be stopped, got %d", len(*opened), len(*stopped))
+			}
+		}
+	}
+}
+
+func TestMergeLazy_Stability(t *testing.T) {
+	type value struct{ key, src int }
+	cmpFunc := func(a, b value) int { return cmp.Compare(a.key, b.key) }
+	src := func(i int, keys ...int) LazySource[value] {
+		var values []value
+		for _, k := range keys {
+			values = append(values, value{k, i})
+		}
+		return LazySource[value]{
+			Open:    func() (iter.Seq[value], error) { return slices.Values(values), nil },
+			Min:     values[0],
+			Max:     values[len(values)-1],
+			Bounded: true,
+		}
+	}
+	var actual []value
+	for v, err := range MergeLazy(cmpFunc, src(0, 1, 2, 2), src(1, 2, 3), src(2, 1, 2)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual = append(actual, v)
+	}
+	expected := []value{{1, 0}, {1, 2}, {2, 0}, {2, 0}, {2, 1}, {2, 2}, {3, 1}}
+	if !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+}
+
+func TestMergeLazy_OpenOnDemand(t *testing.T) {
+	srcs, opened, stopped := lazySources([][]int{{1, 2, 3}, {10, 11}, {20, 21}, {4, 5}}, true)
+	srcs = append(srcs, LazySource[int]{})
+	var actual []int
+	for v, err := range MergeLazy(cmp.Compare[int], srcs...) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual = append(actual, v)
+		if v == 10 {
+			break
+		}
+	}
+	if expected := []int{1, 2, 3, 4, 5, 10}; !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+	if expected := []int{0, 3, 1}; !slices.Equal(*opened, expected) {
+		t.Errorf("Expected opened %v, got %v", expected, *opened)
+	}
+	if expected := []int{0, 3, 1}; !slices.Equal(*stopped, expected) {
+		t.Errorf("Expected stopped %v, got %v", expected, *stopped)
+	}
+}
+
+func TestMergeLazyRange(t *testing.T) {
+	s := [][]int{{1, 2, 3}, {2, 6, 12}, {10, 11}, {20, 21}, {4, 5, 8, 9}}
+	srcs, opened, _ := lazySources(s, true)
+	unbounded, _, _ := lazySources([][]int{{0, 5, 7, 15}}, false)
+	srcs = append(srcs, unbounded...)
+	if actual, expected := collectLazy(t, MergeLazyRange(cmp.Compare[int], 5, 11, srcs...)), []int{5, 5, 6, 7, 8, 9, 10}; !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+	if expected := []int{1, 4, 2}; !slices.Equal(*opened, expected) {
+		t.Errorf("Expected opened %v, got %v", expected, *opened)
+	}
+
+	srcs, opened, _ = lazySources(s, true)
+	if actual := collectLazy(t, MergeLazyRange(cmp.Compare[int], 13, 20, srcs...)); len(actual) != 0 {
+		t.Errorf("Expected no elements, got %v", actual)
+	}
+	if len(*opened) != 0 {
+		t.Errorf("Expected no sources to be opened, got %v", *opened)
+	}
+}
+
+func TestMergeLazy_OpenError(t *testing.T) {
+	expected := errors.New("open failed")
+	srcs, _, stopped := lazySources([][]int{{1, 2}, {3}}, true)
+	srcs = append(srcs, LazySource[int]{
+		Open:    func() (iter.Seq[int], error) { return nil, expected },
+		Min:     2,
+		Max:     2,
+		Bounded: true,
+	}, LazySource[int]{
+		Open: func() (iter.Seq[int], error) { return nil, nil },
+	})
+	var (
+		values []int
+		errs   []error
+	)
+	for v, err := range MergeLazy(cmp.Compare[int], srcs...) {
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			values = append(values, v)
+		}
+	}
+	// the failing source orders after the equal element of the first
+	if !slices.Equal(values, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", values)
+	}
+	if len(errs) != 1 || errs[0] != expected {
+		t.Errorf("Expected [%v], got %v", expected, errs)
+	}
+	if !slices.Equal(*stopped, []int{0}) {
+		t.Errorf("Expected the opened source to be stopped, got %v", *stopped)
+	}
+
+	srcs = []LazySource[int]{{Open: func() (iter.Seq[int], error) { return nil, expected }}}
+	for v, err := range MergeLazy(cmp.Compare[int], srcs...) {
+		if v != 0 || err != expected {
+			t.Errorf("Expected (0, %v), got (%v, %v)", expected, v, err)
+		}
+	}
+}
+
+func TestMergeLazy_NilComparison(t *testing.T) {
+	for _, f := range []func(){
+		func() { MergeLazy[int](nil) },
+		func() { MergeLazyRange(nil, 0, 1, LazySource[int]{}) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != "kway: nil comparison function" {
+					t.Errorf("Expected panic, got %v", r)
+				}
+			}()
+			f()
+		}()
+	}
+}
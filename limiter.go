@@ -0,0 +1,134 @@
+package kway
+
+import (
+	"errors"
+	"sync"
+)
+
+// Limiter is a concurrency budget, which may be shared by any number of
+// mergers, to cap the total number of goroutines started by their concurrent
+// features, such as [Merger.FanIn] and [Merger.MergePartitioned]. Each slot
+// of the budget corresponds to one goroutine, and the bounded buffer it
+// fills, so the budget also caps the number of in-flight buffers.
+//
+// Slots are granted in the order they are requested. A merge that is unable
+// to acquire its slots waits, before starting the affected goroutines.
+type Limiter struct {
+	mu      sync.Mutex
+	size    int
+	used    int
+	waiters []*limiterWaiter
+}
+
+type limiterWaiter struct {
+	n     int
+	ready chan struct{}
+}
+
+// NewLimiter returns a [Limiter] with a budget of `size` slots.
+func NewLimiter(size int) *Limiter {
+	if size <= 0 {
+		panic("kway: limiter size must be positive")
+	}
+	return &Limiter{size: size}
+}
+
+// ErrLimiterExceeded is reported by a merge that requires more slots, at
+// once, than the size of its [Limiter], e.g. by [Merger.FanIn], with more
+// producers.
+var ErrLimiterExceeded = errors.New("kway: limiter size exceeded")
+
+// WithLimiter configures the [Limiter] used to bound the concurrency of the
+// Merger's concurrent features. By default, concurrency is bounded only by
+// the configuration of each merge, e.g. [WithParallelism].
+//
+// A merge that requires more slots, at once, than the size of the limiter
+// is not started: [Merger.FanIn] reports [ErrLimiterExceeded]. Prefetching,
+// per [WithPrefetch], of more sequences than the size of the limiter instead
+// acquires every slot, running without any other use of the limiter.
+func WithLimiter(limiter *Limiter) Option {
+	if limiter == nil {
+		panic("kway: nil limiter")
+	}
+	return func(o *options) {
+		o.limiter = limiter
+	}
+}
+
+// Size returns the total number of slots.
+func (l *Limiter) Size() int { return l.size }
+
+// InUse returns the number of slots currently acquired.
+func (l *Limiter) InUse() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.used
+}
+
+// acquire waits for n slots, returning false if done is closed first. The
+// caller must ensure n does not exceed the size, see [ErrLimiterExceeded].
+func (l *Limiter) acquire(done <-chan struct{}, n int) bool {
+	if n > l.size {
+		panic("kway: limiter size exceeded")
+	}
+	l.mu.Lock()
+	if len(l.waiters) == 0 && l.used+n <= l.size {
+		l.used += n
+		l.mu.Unlock()
+		return true
+	}
+	w := &limiterWaiter{n: n, ready: make(chan struct{})}
+	l.waiters = append(l.waiters, w)
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return true
+	case <-done:
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	select {
+	case <-w.ready:
+		// granted concurrently, give them back
+		l.used -= n
+	default:
+		for i, v := range l.waiters {
+			if v == w {
+				l.waiters = append(l.waiters[:i], l.waiters[i+1:]...)
+				break
+			}
+		}
+	}
+	l.grant()
+	return false
+}
+
+// release returns n slots, previously acquired.
+func (l *Limiter) release(n int) {
+	if n == 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.used -= n
+	if l.used < 0 {
+		panic("kway: limiter released more than acquired")
+	}
+	l.grant()
+}
+
+// grant wakes waiters, in order, while their requests fit.
+func (l *Limiter) grant() {
+	for len(l.waiters) != 0 {
+		w := l.waiters[0]
+		if l.used+w.n > l.size {
+			return
+		}
+		l.used += w.n
+		l.waiters[0] = nil
+		l.waiters = l.waiters[1:]
+		close(w.ready)
+	}
+}
@@ -0,0 +1,212 @@
+package kway
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	l := NewLimiter(3)
+	never := make(chan struct{})
+
+	if !l.acquire(never, 2) {
+		t.Fatal("Expected acquire to succeed")
+	}
+	if l.InUse() != 2 {
+		t.Errorf("Expected InUse() = 2, got %d", l.InUse())
+	}
+
+	waitFor := func(n int) {
+		for {
+			l.mu.Lock()
+			waiting := len(l.waiters)
+			l.mu.Unlock()
+			if waiting == n {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// a later request that would fit waits behind an earlier one
+	var wg sync.WaitGroup
+	for i, n := range []int{2, 1} {
+		waitFor(i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !l.acquire(never, n) {
+				t.Error("Expected acquire to succeed")
+			}
+		}()
+	}
+	waitFor(2)
+	if l.InUse() != 2 {
+		t.Errorf("Expected InUse() = 2, got %d", l.InUse())
+	}
+
+	l.release(2)
+	wg.Wait()
+	if l.InUse() != 3 {
+		t.Errorf("Expected InUse() = 3, got %d", l.InUse())
+	}
+	l.release(3)
+	if l.InUse() != 0 {
+		t.Errorf("Expected InUse() = 0, got %d", l.InUse())
+	}
+}
+
+func TestLimiter_Done(t *testing.T) {
+	l := NewLimiter(1)
+	never := make(chan struct{})
+	l.acquire(never, 1)
+
+	done := make(chan struct{})
+	close(done)
+	if l.acquire(done, 1) {
+		t.Error("Expected acquire to fail")
+	}
+	l.mu.Lock()
+	waiting := len(l.waiters)
+	l.mu.Unlock()
+	if waiting != 0 {
+		t.Errorf("Expected no waiters, got %d", waiting)
+	}
+
+	l.release(1)
+	if !l.acquire(never, 1) {
+		t.Error("Expected acquire to succeed")
+	}
+}
+
+func TestLimiter_Panics(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"zero size":   func() { NewLimiter(0) },
+		"nil limiter": func() { WithLimiter(nil) },
+		"exceeded":    func() { NewLimiter(1).acquire(nil, 2) },
+		"over release": func() {
+			NewLimiter(1).release(1)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
+
+func TestLimiter_FanIn(t *testing.T) {
+	l := NewLimiter(3)
+	m := NewMerger(cmp.Compare[int], WithLimiter(l))
+
+	var running, peak atomic.Int64
+	producer := func(ctx context.Context, emit func(v int) error) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		for i := range 100 {
+			if err := emit(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			seq, wait := m.FanIn(context.Background(), producer, producer)
+			if n := len(collectSeq(seq)); n != 200 {
+				t.Errorf("Expected 200 elements, got %d", n)
+			}
+			if err := wait(); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if p := peak.Load(); p > 2 {
+		t.Errorf("Expected at most 2 concurrent producers, got %d", p)
+	}
+	if l.InUse() != 0 {
+		t.Errorf("Expected InUse() = 0, got %d", l.InUse())
+	}
+}
+
+func TestLimiter_FanInCanceled(t *testing.T) {
+	l := NewLimiter(1)
+	l.acquire(nil, 1)
+	defer l.release(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	seq, wait := NewMerger(cmp.Compare[int], WithLimiter(l)).FanIn(ctx, sliceProducer([]int{1}))
+	if result := collectSeq(seq); result != nil {
+		t.Errorf("Expected nil, got %v", result)
+	}
+	if err := wait(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestLimiter_FanInExceeded(t *testing.T) {
+	l := NewLimiter(2)
+	p := sliceProducer([]int{1})
+	seq, wait := NewMerger(cmp.Compare[int], WithLimiter(l)).FanIn(context.Background(), p, nil, p, p)
+	if result := collectSeq(seq); result != nil {
+		t.Errorf("Expected nil, got %v", result)
+	}
+	if err := wait(); !errors.Is(err, ErrLimiterExceeded) {
+		t.Errorf("Expected %v, got %v", ErrLimiterExceeded, err)
+	}
+	if l.InUse() != 0 {
+		t.Errorf("Expected InUse() = 0, got %d", l.InUse())
+	}
+	seq, wait = NewMerger(cmp.Compare[int], WithLimiter(l)).FanIn(context.Background(), p, nil, p)
+	if result := collectSeq(seq); !slices.Equal(result, []int{1, 1}) {
+		t.Errorf("Expected [1 1], got %v", result)
+	}
+	if err := wait(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestLimiter_MergePartitioned(t *testing.T) {
+	l := NewLimiter(1)
+	src := NewSortedSlice(cmp.Compare[int], []int{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	m := NewMerger(cmp.Compare[int], WithLimiter(l), WithParallelism(4))
+
+	if result := collectSeq(m.MergePartitioned([]int{3, 6}, src, src)); len(result) != 18 || !slices.IsSorted(result) {
+		t.Errorf("Unexpected result: %v", result)
+	}
+
+	for v := range m.MergePartitioned([]int{3, 6}, src, src) {
+		if l.InUse() != 1 {
+			t.Errorf("Expected InUse() = 1, got %d", l.InUse())
+		}
+		if v == 4 {
+			break
+		}
+	}
+	if l.InUse() != 0 {
+		t.Errorf("Expected InUse() = 0, got %d", l.InUse())
+	}
+}
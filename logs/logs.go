@@ -0,0 +1,189 @@
+// Package logs merges log files, or streams, from any number of sources,
+// e.g. hosts, by the timestamp of each line, using
+// [github.com/joeycumines/go-kway]. Timestamps are parsed by a [Parser],
+// either built-in, e.g. [RFC3339], or custom, with lines lacking a timestamp
+// handled per a [Policy].
+package logs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/joeycumines/go-kway"
+)
+
+// maxLineSize is the maximum length of a line read by [Merge].
+const maxLineSize = 1 << 20
+
+// Policy determines how [Merge] handles lines without a timestamp, i.e.
+// those the [Parser] cannot parse.
+type Policy int
+
+const (
+	// Attach appends each such line to the preceding line of its source, as
+	// for multi-line messages, such as stack traces. Lines preceding the
+	// first timestamp of a source are attached to each other, with the zero
+	// time.
+	Attach Policy = iota
+	// Skip discards each such line.
+	Skip
+	// Fail stops reading the source at the first such line, reporting a
+	// [*ParseError].
+	Fail
+)
+
+// Line is an entry of a merged log.
+type Line struct {
+	// Time is the timestamp of the line.
+	Time time.Time
+	// Text is the line, without its line ending. Lines attached, per
+	// [Attach], follow it, each preceded by a newline.
+	Text string
+	// Source is the index of the source of the line.
+	Source int
+	// Number is the one-based line number of the line, within its source.
+	Number int
+}
+
+// ParseError indicates a line without a timestamp, per the [Fail] policy.
+type ParseError struct {
+	// Source is the index of the source of the line.
+	Source int
+	// Number is the one-based line number of the line, within its source.
+	Number int
+	// Text is the line.
+	Text string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("logs: source %d line %d: no timestamp: %q", e.Source, e.Number, e.Text)
+}
+
+// Merge merges the lines of the provided readers, each sorted by timestamp,
+// per `parse`, with lines without a timestamp per `policy`. Lines with
+// equal timestamps are ordered by source, then by their order within it.
+//
+// Errors, whether reading a source, or per [Fail], are yielded, with the
+// zero value, per [kway.MergeValuesErr], and stop the affected source only.
+// To stop the merge at the first error, stop iterating the returned
+// sequence, on receiving it.
+func Merge(parse Parser, policy Policy, readers ...io.Reader) iter.Seq2[Line, error] {
+	seqs := make([]iter.Seq2[string, error], len(readers))
+	for i, r := range readers {
+		if r != nil {
+			seqs[i] = scan(r)
+		}
+	}
+	return merge(parse, policy, seqs)
+}
+
+// MergeLines merges the provided sequences of lines, without line endings,
+// per [Merge].
+func MergeLines(parse Parser, policy Policy, seqs ...iter.Seq[string]) iter.Seq2[Line, error] {
+	lines := make([]iter.Seq2[string, error], len(seqs))
+	for i, seq := range seqs {
+		if seq != nil {
+			lines[i] = func(yield func(string, error) bool) {
+				for v := range seq {
+					if !yield(v, nil) {
+						return
+					}
+				}
+			}
+		}
+	}
+	return merge(parse, policy, lines)
+}
+
+func merge(parse Parser, policy Policy, seqs []iter.Seq2[string, error]) iter.Seq2[Line, error] {
+	if parse == nil {
+		panic("logs: nil parser")
+	}
+	if policy < Attach || policy > Fail {
+		panic("logs: invalid policy")
+	}
+	sources := make([]iter.Seq2[Line, error], len(seqs))
+	for i, seq := range seqs {
+		if seq != nil {
+			sources[i] = entries(parse, policy, i, seq)
+		}
+	}
+	return kway.MergeValuesErr(compareLines, kway.StopSource, sources...)
+}
+
+func compareLines(a, b Line) int { return a.Time.Compare(b.Time) }
+
+// scan returns the lines of r, stopping at the first error.
+func scan(r io.Reader) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		s := bufio.NewScanner(r)
+		s.Buffer(nil, maxLineSize)
+		for s.Scan() {
+			if !yield(strings.TrimSuffix(s.Text(), "\r"), nil) {
+				return
+			}
+		}
+		if err := s.Err(); err != nil {
+			yield("", err)
+		}
+	}
+}
+
+// entries returns the lines of source i, parsed per parse and policy.
+func entries(parse Parser, policy Policy, i int, lines iter.Seq2[string, error]) iter.Seq2[Line, error] {
+	return func(yield func(Line, error) bool) {
+		var (
+			pending  Line
+			attached []string
+			ok       bool
+			number   int
+		)
+		flush := func() bool {
+			if !ok {
+				return true
+			}
+			if attached != nil {
+				pending.Text = strings.Join(slices.Insert(attached, 0, pending.Text), "\n")
+				attached = nil
+			}
+			ok = false
+			return yield(pending, nil)
+		}
+		for text, err := range lines {
+			if err != nil {
+				if flush() {
+					yield(Line{}, err)
+				}
+				return
+			}
+			number++
+			t, parsed := parse(text)
+			if !parsed {
+				switch policy {
+				case Attach:
+					if ok {
+						attached = append(attached, text)
+						continue
+					}
+				case Skip:
+					continue
+				case Fail:
+					if flush() {
+						yield(Line{}, &ParseError{Source: i, Number: number, Text: text})
+					}
+					return
+				}
+			}
+			if !flush() {
+				return
+			}
+			pending, ok = Line{Time: t, Text: text, Source: i, Number: number}, true
+		}
+		flush()
+	}
+}
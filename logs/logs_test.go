@@ -0,0 +1,157 @@
+package logs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// format formats the lines and errors yielded by seq.
+func format(seq iter.Seq2[Line, error]) []string {
+	var result []string
+	for line, err := range seq {
+		if err != nil {
+			result = append(result, "error: "+err.Error())
+			continue
+		}
+		result = append(result, fmt.Sprintf("%d:%d %s", line.Source, line.Number, line.Text))
+	}
+	return result
+}
+
+func TestMerge(t *testing.T) {
+	host1 := "2024-01-01T00:00:01Z a1\n" +
+		"2024-01-01T00:00:03Z a3\n" +
+		"\tat main.go:12\n" +
+		"2024-01-01T00:00:05Z a5\n"
+	host2 := "header\n" +
+		"2024-01-01T00:00:02Z b2\r\n" +
+		"2024-01-01T00:00:03Z b3\n" +
+		"2024-01-01T00:00:04Z b4"
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		expected []string
+	}{
+		{
+			name:   "attach",
+			policy: Attach,
+			expected: []string{
+				"1:1 header",
+				"0:1 2024-01-01T00:00:01Z a1",
+				"1:2 2024-01-01T00:00:02Z b2",
+				"0:2 2024-01-01T00:00:03Z a3\n\tat main.go:12",
+				"1:3 2024-01-01T00:00:03Z b3",
+				"1:4 2024-01-01T00:00:04Z b4",
+				"0:4 2024-01-01T00:00:05Z a5",
+			},
+		},
+		{
+			name:   "skip",
+			policy: Skip,
+			expected: []string{
+				"0:1 2024-01-01T00:00:01Z a1",
+				"1:2 2024-01-01T00:00:02Z b2",
+				"0:2 2024-01-01T00:00:03Z a3",
+				"1:3 2024-01-01T00:00:03Z b3",
+				"1:4 2024-01-01T00:00:04Z b4",
+				"0:4 2024-01-01T00:00:05Z a5",
+			},
+		},
+		{
+			name:   "fail",
+			policy: Fail,
+			expected: []string{
+				`error: logs: source 1 line 1: no timestamp: "header"`,
+				"0:1 2024-01-01T00:00:01Z a1",
+				"0:2 2024-01-01T00:00:03Z a3",
+				`error: logs: source 0 line 3: no timestamp: "\tat main.go:12"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := format(Merge(RFC3339, tt.policy, strings.NewReader(host1), strings.NewReader(host2)))
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMerge_readError(t *testing.T) {
+	errRead := errors.New("read failed")
+	r := io.MultiReader(strings.NewReader("2024-01-01T00:00:01Z a\n2024-01-01T00:00:04Z b\n"), iotest.ErrReader(errRead))
+	var (
+		lines []string
+		err   error
+	)
+	for line, e := range Merge(RFC3339, Attach, r, strings.NewReader("2024-01-01T00:00:02Z c\n")) {
+		if e != nil {
+			err = e
+			continue
+		}
+		lines = append(lines, line.Text)
+	}
+	if !errors.Is(err, errRead) {
+		t.Errorf("Expected %v, got %v", errRead, err)
+	}
+	expected := []string{"2024-01-01T00:00:01Z a", "2024-01-01T00:00:02Z c", "2024-01-01T00:00:04Z b"}
+	if !slices.Equal(lines, expected) {
+		t.Errorf("Expected %q, got %q", expected, lines)
+	}
+}
+
+func TestMergeLines(t *testing.T) {
+	a := slices.Values([]string{`{"time":2,"msg":"a"}`, `{"time":4,"msg":"b"}`})
+	b := slices.Values([]string{`{"time":1,"msg":"c"}`, `{"time":3,"msg":"d"}`})
+	var result []string
+	for line, err := range MergeLines(JSONTime, Fail, a, b) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		result = append(result, fmt.Sprintf("%d %d", line.Time.Unix(), line.Source))
+	}
+	expected := []string{"1 1", "2 0", "3 1", "4 0"}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
+
+func TestMerge_earlyExit(t *testing.T) {
+	var n int
+	for range MergeLines(RFC3339, Attach, slices.Values([]string{"2024-01-01T00:00:01Z a", "x", "2024-01-01T00:00:02Z b"})) {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("Expected 1 line, got %d", n)
+	}
+}
+
+func TestMerge_panics(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		f     func()
+		panic string
+	}{
+		{"nil parser", func() { Merge(nil, Attach) }, "logs: nil parser"},
+		{"invalid policy", func() { MergeLines(RFC3339, -1) }, "logs: invalid policy"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tt.panic {
+					t.Errorf("Expected %q, got %v", tt.panic, r)
+				}
+			}()
+			tt.f()
+		})
+	}
+}
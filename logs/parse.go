@@ -0,0 +1,122 @@
+package logs
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parser returns the timestamp of a line of a log, or false if it has none,
+// or it cannot be parsed. Custom parsers may be used with [Merge], along
+// with the built-in parsers, e.g. [RFC3339].
+type Parser func(line string) (time.Time, bool)
+
+// RFC3339 parses the timestamp at the start of each line, in RFC 3339
+// format, with optional fractional seconds, as the first space-delimited
+// field, optionally enclosed in square brackets, e.g.
+// "2024-01-02T15:04:05.123Z INFO started", or "[2024-01-02T15:04:05Z] ...".
+func RFC3339(line string) (time.Time, bool) {
+	field, _, _ := strings.Cut(line, " ")
+	if len(field) >= 2 && field[0] == '[' && field[len(field)-1] == ']' {
+		field = field[1 : len(field)-1]
+	}
+	t, err := time.Parse(time.RFC3339Nano, field)
+	return t, err == nil
+}
+
+// Layout returns a [Parser] for timestamps at the start of each line, in the
+// format described by `layout`, per [time.ParseInLocation], occupying the
+// same number of bytes as the layout, e.g. "2006-01-02 15:04:05".
+func Layout(layout string, loc *time.Location) Parser {
+	if layout == "" {
+		panic("logs: empty layout")
+	}
+	if loc == nil {
+		panic("logs: nil location")
+	}
+	return func(line string) (time.Time, bool) {
+		if len(line) < len(layout) {
+			return time.Time{}, false
+		}
+		t, err := time.ParseInLocation(layout, line[:len(layout)], loc)
+		return t, err == nil
+	}
+}
+
+// syslogLayout is the timestamp format of RFC 3164.
+const syslogLayout = time.Stamp
+
+// Syslog returns a [Parser] for lines in either syslog format: RFC 5424,
+// e.g. "<34>1 2003-10-11T22:14:15.003Z host app ...", or RFC 3164, e.g.
+// "<34>Oct 11 22:14:15 host app: ...", with an optional priority. As RFC 3164
+// timestamps have neither a year, nor a time zone, they are parsed as being
+// in `year`, in `loc`.
+func Syslog(year int, loc *time.Location) Parser {
+	if loc == nil {
+		panic("logs: nil location")
+	}
+	return func(line string) (time.Time, bool) {
+		if strings.HasPrefix(line, "<") {
+			end := strings.IndexByte(line, '>')
+			if end < 2 || !isDigits(line[1:end]) {
+				return time.Time{}, false
+			}
+			line = line[end+1:]
+		}
+		if version, rest, ok := strings.Cut(line, " "); ok && version != "" && isDigits(version) {
+			field, _, _ := strings.Cut(rest, " ")
+			t, err := time.Parse(time.RFC3339Nano, field)
+			return t, err == nil
+		}
+		if len(line) < len(syslogLayout) {
+			return time.Time{}, false
+		}
+		t, err := time.ParseInLocation(syslogLayout, line[:len(syslogLayout)], loc)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc), true
+	}
+}
+
+// JSON returns a [Parser] for lines that are JSON objects, with the
+// timestamp in the top-level `field`, either as a string, in RFC 3339
+// format, or as a number, of seconds since the Unix epoch, e.g.
+// `{"time":"2024-01-02T15:04:05Z","msg":"started"}`.
+func JSON(field string) Parser {
+	return func(line string) (time.Time, bool) {
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(line), &obj); err != nil {
+			return time.Time{}, false
+		}
+		raw, ok := obj[field]
+		if !ok {
+			return time.Time{}, false
+		}
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			t, err := time.Parse(time.RFC3339Nano, s)
+			return t, err == nil
+		}
+		f, err := strconv.ParseFloat(string(raw), 64)
+		if err != nil || math.IsInf(f, 0) {
+			return time.Time{}, false
+		}
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(frac*1e9)).UTC(), true
+	}
+}
+
+// JSONTime is a [JSON] parser, for the "time" field.
+var JSONTime = JSON("time")
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
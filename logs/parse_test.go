@@ -0,0 +1,68 @@
+package logs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsers(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	tests := []struct {
+		name     string
+		parse    Parser
+		line     string
+		expected time.Time
+		ok       bool
+	}{
+		{"rfc3339", RFC3339, "2024-01-02T15:04:05Z INFO started", time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), true},
+		{"rfc3339 fraction", RFC3339, "2024-01-02T15:04:05.25+01:00 x", time.Date(2024, 1, 2, 14, 4, 5, 250000000, time.UTC), true},
+		{"rfc3339 brackets", RFC3339, "[2024-01-02T15:04:05Z] x", time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), true},
+		{"rfc3339 only", RFC3339, "2024-01-02T15:04:05Z", time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), true},
+		{"rfc3339 invalid", RFC3339, "\tat main.go:12", time.Time{}, false},
+		{"layout", Layout("2006-01-02 15:04:05", est), "2024-01-02 15:04:05 x", time.Date(2024, 1, 2, 20, 4, 5, 0, time.UTC), true},
+		{"layout short", Layout("2006-01-02 15:04:05", est), "2024-01-02", time.Time{}, false},
+		{"syslog rfc3164", Syslog(2023, est), "<34>Oct 11 22:14:15 host su: failed", time.Date(2023, 10, 12, 3, 14, 15, 0, time.UTC), true},
+		{"syslog rfc3164 no priority", Syslog(2023, time.UTC), "Oct  1 02:14:15 host su: failed", time.Date(2023, 10, 1, 2, 14, 15, 0, time.UTC), true},
+		{"syslog rfc3164 leap day", Syslog(2024, time.UTC), "Feb 29 00:00:00 host x", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), true},
+		{"syslog rfc5424", Syslog(2023, est), "<165>1 2003-10-11T22:14:15.003Z host app - - msg", time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC), true},
+		{"syslog rfc5424 nil timestamp", Syslog(2023, est), "<165>1 - host app - - msg", time.Time{}, false},
+		{"syslog invalid priority", Syslog(2023, est), "<x>Oct 11 22:14:15 host", time.Time{}, false},
+		{"syslog invalid", Syslog(2023, est), "continued", time.Time{}, false},
+		{"json string", JSONTime, `{"time":"2024-01-02T15:04:05Z","msg":"x"}`, time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), true},
+		{"json number", JSONTime, `{"time":1700000000.5}`, time.Unix(1700000000, 500000000), true},
+		{"json field", JSON("ts"), `{"ts":"2024-01-02T15:04:05Z"}`, time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), true},
+		{"json missing", JSONTime, `{"ts":"2024-01-02T15:04:05Z"}`, time.Time{}, false},
+		{"json invalid", JSONTime, `not json`, time.Time{}, false},
+		{"json wrong type", JSONTime, `{"time":true}`, time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := tt.parse(tt.line)
+			if ok != tt.ok || !result.Equal(tt.expected) {
+				t.Errorf("Expected %v, %v, got %v, %v", tt.expected, tt.ok, result, ok)
+			}
+		})
+	}
+}
+
+func TestParsers_panics(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		f     func()
+		panic string
+	}{
+		{"empty layout", func() { Layout("", time.UTC) }, "logs: empty layout"},
+		{"nil layout location", func() { Layout(time.DateTime, nil) }, "logs: nil location"},
+		{"nil syslog location", func() { Syslog(2024, nil) }, "logs: nil location"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tt.panic {
+					t.Errorf("Expected %q, got %v", tt.panic, r)
+				}
+			}()
+			tt.f()
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package kway
+
+// loserTree is a tournament tree, over the current elements of k sources,
+// each a leaf. The tree is implicit, like a heap: the leaf of source i is
+// node k+i, and node n, for 0 < n < k, is the parent of nodes 2n and 2n+1,
+// and stores the source that lost the match between the winners of its
+// children. Node 0 stores the overall winner.
+type loserTree[T interface{ index() int }] struct {
+	cmp   func(a, b T) int
+	items []T
+	// done marks exhausted sources, which lose every match
+	done  []bool
+	tree  []int
+	moves *int64
+}
+
+// beats returns true if the element of source i precedes that of source j.
+func (x *loserTree[T]) beats(i, j int) bool {
+	if x.done[i] || x.done[j] {
+		return !x.done[i]
+	}
+	if v := x.cmp(x.items[i], x.items[j]); v != 0 {
+		return v < 0
+	}
+	// fall back to comparison by index (documented behavior)
+	return x.items[i].index() < x.items[j].index()
+}
+
+// init plays every match, bottom up, using winners, of length 2k, as
+// scratch.
+func (x *loserTree[T]) init(winners []int) {
+	k := len(x.items)
+	for i := range k {
+		winners[k+i] = i
+	}
+	for n := k - 1; n > 0; n-- {
+		a, b := winners[2*n], winners[2*n+1]
+		if x.beats(b, a) {
+			a, b = b, a
+		}
+		winners[n], x.tree[n] = a, b
+	}
+	if k > 1 {
+		x.tree[0] = winners[1]
+	}
+}
+
+// replay replays the matches on the path from the leaf of source i, which
+// must be the previous winner, to the root, against the cached losers.
+func (x *loserTree[T]) replay(i int) {
+	winner := i
+	for n := (len(x.items) + i) / 2; n > 0; n /= 2 {
+		if x.beats(x.tree[n], winner) {
+			x.tree[n], winner = winner, x.tree[n]
+			if x.moves != nil {
+				*x.moves++
+			}
+		}
+	}
+	x.tree[0] = winner
+}
+
+// mergeLoserTree implements [mergeState.merge], using a [loserTree], with
+// the buffers of s.
+func mergeLoserTree[T interface{ index() int }](s *mergeState[T], pulls []func() (T, bool), yield func(T) bool) {
+	k := len(pulls)
+	if k == 0 {
+		return
+	}
+	s.items, s.done, s.nodes = reuse(s.items, k), reuse(s.done, k), reuse(s.nodes, 3*k)
+	x := loserTree[T]{
+		cmp:   s.cmp,
+		items: s.items,
+		done:  s.done,
+		tree:  s.nodes[:k],
+		moves: s.moves,
+	}
+	for i, next := range pulls {
+		var ok bool
+		if next != nil {
+			x.items[i], ok = next()
+		}
+		if !ok {
+			pulls[i] = nil
+			x.done[i] = true
+		}
+	}
+	x.init(s.nodes[k:])
+	for {
+		i := x.tree[0]
+		if x.done[i] {
+			return
+		}
+		v := x.items[i]
+		if !yield(v) {
+			return
+		}
+		var ok bool
+		x.items[i], ok = pulls[i]()
+		if !ok {
+			pulls[i] = nil
+			x.done[i] = true
+		}
+		x.replay(i)
+	}
+}
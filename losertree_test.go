@@ -0,0 +1,76 @@
+package kway
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+// mockPulls returns pull functions for the inputs, a nil input having a nil
+// pull function.
+func mockPulls(inputs ...[]int) []func() (*mockIndexValue, bool) {
+	pulls := make([]func() (*mockIndexValue, bool), len(inputs))
+	for i, s := range inputs {
+		if s == nil {
+			continue
+		}
+		pulls[i] = func() (*mockIndexValue, bool) {
+			if len(s) == 0 {
+				return nil, false
+			}
+			v := &mockIndexValue{value: s[0], idx: i}
+			s = s[1:]
+			return v, true
+		}
+	}
+	return pulls
+}
+
+func TestMergeLoserTree(t *testing.T) {
+	compare := func(a, b *mockIndexValue) int { return cmp.Compare(a.value, b.value) }
+	for _, tc := range []struct {
+		name     string
+		inputs   [][]int
+		expected []int
+	}{
+		{"none", nil, nil},
+		{"nil", [][]int{nil, nil}, nil},
+		{"one", [][]int{{1, 2, 3}}, []int{1, 2, 3}},
+		{"empty", [][]int{{}, {1}, {}}, []int{1}},
+		{"odd", [][]int{{1, 4, 7}, {2, 5, 8}, {3, 6, 9}}, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{"uneven", [][]int{{5}, nil, {1, 2, 3, 4, 6, 7}, {0, 8}, {}}, []int{0, 1, 2, 3, 4, 5, 6, 7, 8}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual []int
+			mergeLoserTree(&mergeState[*mockIndexValue]{cmp: compare}, mockPulls(tc.inputs...), func(v *mockIndexValue) bool {
+				actual = append(actual, v.value)
+				return true
+			})
+			if !slices.Equal(actual, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+
+	t.Run("stable", func(t *testing.T) {
+		var sources []int
+		mergeLoserTree(&mergeState[*mockIndexValue]{cmp: compare}, mockPulls([]int{1, 2}, []int{1, 1}, []int{0, 1}), func(v *mockIndexValue) bool {
+			sources = append(sources, v.idx)
+			return true
+		})
+		if expected := []int{2, 0, 1, 1, 2, 0}; !slices.Equal(sources, expected) {
+			t.Errorf("Expected %v, got %v", expected, sources)
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		var actual []int
+		mergeLoserTree(&mergeState[*mockIndexValue]{cmp: compare}, mockPulls([]int{1, 3}, []int{2, 4}), func(v *mockIndexValue) bool {
+			actual = append(actual, v.value)
+			return len(actual) < 2
+		})
+		if expected := []int{1, 2}; !slices.Equal(actual, expected) {
+			t.Errorf("Expected %v, got %v", expected, actual)
+		}
+	})
+}
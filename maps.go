@@ -0,0 +1,54 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// MergeMaps yields the union of the entries of the provided maps, in key
+// order, with keys present in multiple maps handled according to `policy`,
+// e.g. [KeepLast], for later maps to supersede earlier ones, as for
+// [Merge2Dedup]. The keys of each map are sorted, at the start of each
+// iteration, which, for n keys, is O(n log n).
+func MergeMaps[K cmp.Ordered, V any](policy DuplicatePolicy, maps ...map[K]V) iter.Seq2[K, V] {
+	return MergeMapsFunc(cmp.Compare[K], policy, maps...)
+}
+
+// MergeMapsFunc is the equivalent of [MergeMaps], ordering keys per `cmp`,
+// which must be consistent with the == operator, i.e. return 0 only for
+// equal keys.
+func MergeMapsFunc[K comparable, V any](cmp func(a, b K) int, policy DuplicatePolicy, maps ...map[K]V) iter.Seq2[K, V] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	seqs := make([]iter.Seq2[K, V], len(maps))
+	for i, m := range maps {
+		if m == nil {
+			continue
+		}
+		seqs[i] = func(yield func(K, V) bool) {
+			keys := make([]K, 0, len(m))
+			for k := range m {
+				keys = append(keys, k)
+			}
+			slices.SortFunc(keys, cmp)
+			MapEntries(m, keys)(yield)
+		}
+	}
+	return Merge2Dedup(cmp, policy, seqs...)
+}
+
+// MapEntries returns a sequence of the entries of `m`, in the order of
+// `keys`, e.g. to merge maps with pre-sorted keys, using [Merge2Dedup],
+// without sorting them for each iteration. Keys missing from the map are
+// skipped.
+func MapEntries[K comparable, V any](m map[K]V, keys []K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, k := range keys {
+			if v, ok := m[k]; ok && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package kway
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMergeMaps(t *testing.T) {
+	a := map[string]int{"c": 1, "a": 2, "e": 3}
+	b := map[string]int{"b": 4, "c": 5}
+	c := map[string]int{"c": 6, "f": 7}
+
+	tests := []struct {
+		name     string
+		policy   DuplicatePolicy
+		expected []Pair[string, int]
+	}{
+		{name: "keep first", policy: KeepFirst, expected: []Pair[string, int]{{"a", 2}, {"b", 4}, {"c", 1}, {"e", 3}, {"f", 7}}},
+		{name: "keep last", policy: KeepLast, expected: []Pair[string, int]{{"a", 2}, {"b", 4}, {"c", 6}, {"e", 3}, {"f", 7}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := collectSeq(PairsOf(MergeMaps(tt.policy, a, nil, b, map[string]int{}, c)))
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMergeMaps_rejectDuplicates(t *testing.T) {
+	defer func() {
+		err, ok := recover().(*DuplicateKeyError)
+		if !ok || err.Key != 2 || err.First != 0 || err.Second != 1 {
+			t.Errorf("Expected duplicate key error, got %v", err)
+		}
+	}()
+	for range MergeMaps(RejectDuplicates, map[int]bool{1: true, 2: true}, map[int]bool{2: false}) {
+	}
+}
+
+func TestMergeMaps_reiterate(t *testing.T) {
+	m := map[int]string{2: "b"}
+	seq := MergeMaps(KeepFirst, m, map[int]string{1: "a"})
+	if keys := collectSeq(Keys(seq)); !slices.Equal(keys, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", keys)
+	}
+	m[0] = "z"
+	if keys := collectSeq(Keys(seq)); !slices.Equal(keys, []int{0, 1, 2}) {
+		t.Errorf("Expected [0 1 2], got %v", keys)
+	}
+}
+
+func TestMergeMapsFunc(t *testing.T) {
+	descending := func(a, b int) int { return cmp.Compare(b, a) }
+	result := collectSeq(Keys(MergeMapsFunc(descending, KeepFirst, map[int]bool{1: true, 3: true}, map[int]bool{2: true})))
+	if !slices.Equal(result, []int{3, 2, 1}) {
+		t.Errorf("Expected [3 2 1], got %v", result)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil comparison function")
+		}
+	}()
+	MergeMapsFunc[int, bool](nil, KeepFirst)
+}
+
+func TestMapEntries(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	result := collectSeq(PairsOf(MapEntries(m, []string{"a", "x", "c"})))
+	if expected := []Pair[string, int]{{"a", 1}, {"c", 3}}; !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	merged := collectSeq(PairsOf(Merge2Dedup(strings.Compare, KeepLast,
+		MapEntries(m, []string{"a", "b", "c"}),
+		MapEntries(map[string]int{"b": 20}, []string{"b"}),
+	)))
+	if expected := []Pair[string, int]{{"a", 1}, {"b", 20}, {"c", 3}}; !slices.Equal(merged, expected) {
+		t.Errorf("Expected %v, got %v", expected, merged)
+	}
+
+	var n int
+	for range MapEntries(m, []string{"a", "b"}) {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("Expected 1 element, got %d", n)
+	}
+}
@@ -2,6 +2,9 @@ package kway
 
 import (
 	"iter"
+	"slices"
+
+	"github.com/joeycumines/go-kway/heap"
 )
 
 // Merge performs a k-way merge of the provided sorted input sequences. It
@@ -20,35 +23,33 @@ func Merge[T any](cmp func(a, b T) int, seqs ...iter.Seq[T]) iter.Seq[T] {
 	if cmp == nil {
 		panic("kway: nil comparison function")
 	}
-	wrappedSeqs := make([]iter.Seq[*wrappedSeqValue[T]], len(seqs))
-	{
-		var ok bool
-		for i, seq := range seqs {
-			if seq != nil {
-				wrappedSeqs[i] = wrapSeq(i, seq)
-				ok = true
-			}
-		}
-		if !ok {
-			return emptySeq[T]
-		}
+	if !anySeq(seqs) {
+		return emptySeq[T]
+	}
+	if debugCheckComparator > 0 {
+		cmp = checkCompare(cmp, debugCheckComparator)
 	}
-	return mergeSeq(wrapCompare(cmp), wrappedSeqs)
+	return (&seqMerge[T]{cmp: cmp, seqs: slices.Clone(seqs)}).all
 }
 
 func emptySeq[T any](yield func(T) bool) {}
 
-func mergeSeq[T any](cmp func(a, b *wrappedSeqValue[T]) int, seqs []iter.Seq[*wrappedSeqValue[T]]) iter.Seq[T] {
-	return func(yield func(T) bool) {
-		for v := range (&mergeState[*wrappedSeqValue[T]]{
-			cmp:  cmp,
-			seqs: seqs,
-		}).all {
-			if !yield(v.v) {
-				return
-			}
-		}
+// MergeIndexed performs a k-way merge of the provided sorted input
+// sequences, like [Merge], but additionally yields the index of the input
+// sequence each element came from, as the first value of each pair.
+//
+// See [Merge] for details on the comparison function and stability.
+func MergeIndexed[T any](cmp func(a, b T) int, seqs ...iter.Seq[T]) iter.Seq2[int, T] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
 	}
+	if !anySeq(seqs) {
+		return emptySeq2[int, T]
+	}
+	if debugCheckComparator > 0 {
+		cmp = checkCompare(cmp, debugCheckComparator)
+	}
+	return (&seqMerge[T]{cmp: cmp, seqs: slices.Clone(seqs)}).merge
 }
 
 // Merge2 performs a k-way merge of the provided sorted input sequences. It
@@ -60,33 +61,166 @@ func Merge2[T1 any, T2 any](cmp func(a1 T1, a2 T2, b1 T1, b2 T2) int, seqs ...it
 	if cmp == nil {
 		panic("kway: nil comparison function")
 	}
-	wrappedSeqs := make([]iter.Seq[*wrappedSeq2Value[T1, T2]], len(seqs))
-	{
+	if !anySeq(seqs) {
+		return emptySeq2[T1, T2]
+	}
+	if debugCheckComparator > 0 {
+		cmp = checkCompare2(cmp, debugCheckComparator)
+	}
+	return (&seq2Merge[T1, T2]{cmp: cmp, seqs: slices.Clone(seqs)}).merge
+}
+
+func emptySeq2[T1 any, T2 any](yield func(T1, T2) bool) {}
+
+// seqMerge is a merge of the sequences of [Merge] or [MergeIndexed], which
+// implements the merged sequence as a method, rather than a closure, such
+// that the sources, and their current elements, are held by value, without
+// any allocation per source, or per element.
+type seqMerge[T any] struct {
+	cmp  func(a, b T) int
+	seqs []iter.Seq[T]
+}
+
+// indexedValue is the current element of the source with index i.
+type indexedValue[T any] struct {
+	i int
+	v T
+}
+
+// compare orders the current elements of the sources, falling back to
+// comparison by index (documented behavior).
+func (x *seqMerge[T]) compare(a, b indexedValue[T]) int {
+	if v := x.cmp(a.v, b.v); v != 0 {
+		return v
+	}
+	return a.i - b.i
+}
+
+// all yields the merged elements.
+func (x *seqMerge[T]) all(yield func(T) bool) {
+	x.merge(func(_ int, v T) bool { return yield(v) })
+}
+
+// merge yields the merged elements, with the index of their source.
+func (x *seqMerge[T]) merge(yield func(int, T) bool) {
+	items := make([]indexedValue[T], 0, len(x.seqs))
+	pulls := make([]func() (T, bool), len(x.seqs))
+	for i, seq := range x.seqs {
+		if seq == nil {
+			continue
+		}
+		next, stop := iter.Pull(seq)
+		defer stop()
+		if v, ok := next(); ok {
+			items = append(items, indexedValue[T]{i, v})
+			pulls[i] = next
+		}
+	}
+	h := heap.New(x.compare, items)
+	for h.Len() != 0 {
+		top := &h.Slice()[0]
+		if !yield(top.i, top.v) {
+			return
+		}
 		var ok bool
-		for i, seq := range seqs {
-			if seq != nil {
-				wrappedSeqs[i] = wrapSeq2(i, seq)
-				ok = true
-			}
+		if top.v, ok = pulls[top.i](); ok {
+			h.Fix(0)
+		} else {
+			h.Pop()
 		}
-		if !ok {
-			return emptySeq2[T1, T2]
+	}
+}
+
+// first yields the first of each run of merged elements that compare equal,
+// for [MergeFirst].
+func (x *seqMerge[T]) first(yield func(T) bool) {
+	items := make([]indexedValue[T], 0, len(x.seqs))
+	pulls := make([]func() (T, bool), len(x.seqs))
+	for i, seq := range x.seqs {
+		if seq == nil {
+			continue
+		}
+		next, stop := iter.Pull(seq)
+		defer stop()
+		if v, ok := next(); ok {
+			items = append(items, indexedValue[T]{i, v})
+			pulls[i] = next
+		}
+	}
+	h := heap.New(x.compare, items)
+	for h.Len() != 0 {
+		top := &h.Slice()[0]
+		v := top.v
+		if !yield(v) {
+			return
+		}
+		// advance each source at v past its duplicates, before fixing the heap
+		for {
+			var ok bool
+			top.v, ok = pulls[top.i]()
+			for ok && x.cmp(top.v, v) == 0 {
+				top.v, ok = pulls[top.i]()
+			}
+			if ok {
+				h.Fix(0)
+			} else {
+				h.Pop()
+			}
+			if h.Len() == 0 {
+				break
+			}
+			if top = &h.Slice()[0]; x.cmp(top.v, v) != 0 {
+				break
+			}
 		}
 	}
-	return mergeSeq2(wrapCompare2(cmp), wrappedSeqs)
 }
 
-func emptySeq2[T1 any, T2 any](yield func(T1, T2) bool) {}
+// seq2Merge is the [iter.Seq2] equivalent of seqMerge, for [Merge2].
+type seq2Merge[T1 any, T2 any] struct {
+	cmp  func(a1 T1, a2 T2, b1 T1, b2 T2) int
+	seqs []iter.Seq2[T1, T2]
+}
 
-func mergeSeq2[T1 any, T2 any](cmp func(a, b *wrappedSeq2Value[T1, T2]) int, seqs []iter.Seq[*wrappedSeq2Value[T1, T2]]) iter.Seq2[T1, T2] {
-	return func(yield func(T1, T2) bool) {
-		for v := range (&mergeState[*wrappedSeq2Value[T1, T2]]{
-			cmp:  cmp,
-			seqs: seqs,
-		}).all {
-			if !yield(v.v1, v.v2) {
-				return
-			}
+// indexedValue2 is the current pair of the source with index i.
+type indexedValue2[T1 any, T2 any] struct {
+	i  int
+	v1 T1
+	v2 T2
+}
+
+func (x *seq2Merge[T1, T2]) compare(a, b indexedValue2[T1, T2]) int {
+	if v := x.cmp(a.v1, a.v2, b.v1, b.v2); v != 0 {
+		return v
+	}
+	return a.i - b.i
+}
+
+func (x *seq2Merge[T1, T2]) merge(yield func(T1, T2) bool) {
+	items := make([]indexedValue2[T1, T2], 0, len(x.seqs))
+	pulls := make([]func() (T1, T2, bool), len(x.seqs))
+	for i, seq := range x.seqs {
+		if seq == nil {
+			continue
+		}
+		next, stop := iter.Pull2(seq)
+		defer stop()
+		if v1, v2, ok := next(); ok {
+			items = append(items, indexedValue2[T1, T2]{i, v1, v2})
+			pulls[i] = next
+		}
+	}
+	h := heap.New(x.compare, items)
+	for h.Len() != 0 {
+		top := &h.Slice()[0]
+		if !yield(top.v1, top.v2) {
+			return
+		}
+		var ok bool
+		if top.v1, top.v2, ok = pulls[top.i](); ok {
+			h.Fix(0)
+		} else {
+			h.Pop()
 		}
 	}
 }
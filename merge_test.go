@@ -310,6 +310,64 @@ func TestMerge_LargeSequences(t *testing.T) {
 	}
 }
 
+// Test MergeIndexed function
+
+func TestMergeIndexed_NilCompareFunction(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil comparison function")
+		} else if !strings.Contains(r.(string), "nil comparison function") {
+			t.Errorf("Expected panic message about nil comparison function, got: %v", r)
+		}
+	}()
+
+	seq := sliceSeq([]int{1, 2, 3})
+	_ = MergeIndexed[int](nil, seq)
+}
+
+func TestMergeIndexed_EmptyInput(t *testing.T) {
+	indexes, values := collectSeq2(MergeIndexed(cmp.Compare[int]))
+	if len(indexes) != 0 || len(values) != 0 {
+		t.Errorf("Expected empty result for no sequences, got %v, %v", indexes, values)
+	}
+
+	indexes, values = collectSeq2(MergeIndexed(cmp.Compare[int], nil, nil))
+	if len(indexes) != 0 || len(values) != 0 {
+		t.Errorf("Expected empty result for all nil sequences, got %v, %v", indexes, values)
+	}
+}
+
+func TestMergeIndexed_SourceIndexes(t *testing.T) {
+	indexes, values := collectSeq2(MergeIndexed(cmp.Compare[int],
+		sliceSeq([]int{1, 4, 4}),
+		nil,
+		sliceSeq([]int{2, 4}),
+		sliceSeq([]int{0, 5}),
+	))
+
+	expectedIndexes := []int{3, 0, 2, 0, 0, 2, 3}
+	expectedValues := []int{0, 1, 2, 4, 4, 4, 5}
+
+	if !slices.Equal(indexes, expectedIndexes) || !slices.Equal(values, expectedValues) {
+		t.Errorf("Expected %v, %v; got %v, %v", expectedIndexes, expectedValues, indexes, values)
+	}
+}
+
+func TestMergeIndexed_EarlyTermination(t *testing.T) {
+	var indexes, values []int
+	for i, v := range MergeIndexed(cmp.Compare[int], sliceSeq([]int{1, 3, 5}), sliceSeq([]int{2, 4, 6})) {
+		indexes = append(indexes, i)
+		values = append(values, v)
+		if len(values) == 3 {
+			break
+		}
+	}
+
+	if !slices.Equal(indexes, []int{0, 1, 0}) || !slices.Equal(values, []int{1, 2, 3}) {
+		t.Errorf("Early termination test failed. Got %v, %v", indexes, values)
+	}
+}
+
 // Test Merge2 function
 
 func TestMerge2_NilCompareFunction(t *testing.T) {
@@ -539,6 +597,24 @@ func BenchmarkMerge_MultipleSequences(b *testing.B) {
 	}
 }
 
+func TestMerge_CopiesSequences(t *testing.T) {
+	seqs := []iter.Seq[int]{sliceSeq([]int{1, 3}), sliceSeq([]int{2})}
+	merged := Merge(cmp.Compare[int], seqs...)
+	indexed := MergeIndexed(cmp.Compare[int], seqs...)
+	seqs2 := []iter.Seq2[int, string]{sliceSeq2([]int{1}, []string{"a"})}
+	merged2 := Merge2(func(a1 int, _ string, b1 int, _ string) int { return cmp.Compare(a1, b1) }, seqs2...)
+	seqs[0], seqs[1], seqs2[0] = nil, nil, nil
+	if result := collectSeq(merged); !slices.Equal(result, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", result)
+	}
+	if indexes, result := collectSeq2(indexed); !slices.Equal(indexes, []int{0, 1, 0}) || !slices.Equal(result, []int{1, 2, 3}) {
+		t.Errorf("Expected [0 1 0] [1 2 3], got %v %v", indexes, result)
+	}
+	if keys, values := collectSeq2(merged2); !slices.Equal(keys, []int{1}) || !slices.Equal(values, []string{"a"}) {
+		t.Errorf("Expected [1] [a], got %v %v", keys, values)
+	}
+}
+
 func Test_emptySeq(t *testing.T) {
 	emptySeq[int](func(v int) bool {
 		t.Fatal("unexpected call to emptySeq")
@@ -552,3 +628,92 @@ func Test_emptySeq2(t *testing.T) {
 		return true
 	})
 }
+
+func TestSeqMerge_compare(t *testing.T) {
+	x := &seqMerge[int]{cmp: cmp.Compare[int]}
+	for _, tc := range []struct {
+		a, b     indexedValue[int]
+		expected int
+	}{
+		{indexedValue[int]{0, 1}, indexedValue[int]{1, 2}, -1},
+		{indexedValue[int]{1, 2}, indexedValue[int]{0, 1}, 1},
+		{indexedValue[int]{0, 1}, indexedValue[int]{1, 1}, -1},
+		{indexedValue[int]{2, 1}, indexedValue[int]{1, 1}, 1},
+		{indexedValue[int]{1, 1}, indexedValue[int]{1, 1}, 0},
+	} {
+		if actual := x.compare(tc.a, tc.b); cmp.Compare(actual, 0) != tc.expected {
+			t.Errorf("compare(%v, %v): Expected sign %d, got %d", tc.a, tc.b, tc.expected, actual)
+		}
+	}
+
+	x2 := &seq2Merge[int, string]{cmp: func(a1 int, a2 string, b1 int, b2 string) int { return cmp.Compare(a1, b1) }}
+	if actual := x2.compare(indexedValue2[int, string]{1, 1, "a"}, indexedValue2[int, string]{0, 1, "b"}); actual <= 0 {
+		t.Errorf("Expected ties ordered by index, got %d", actual)
+	}
+}
+
+// TestMerge_allocations checks that the allocations of a merge do not depend
+// on the number of elements.
+func TestMerge_allocations(t *testing.T) {
+	if debugCheckComparator > 0 {
+		t.Skip("comparator checks allocate")
+	}
+	allocs := func(n int) (merge, indexed, merge2 float64) {
+		a, b := make([]int, n), make([]int, n)
+		for i := range n {
+			a[i], b[i] = i*2, i*2+1
+		}
+		merge = testing.AllocsPerRun(10, func() {
+			for range Merge(cmp.Compare[int], slices.Values(a), slices.Values(b)) {
+			}
+		})
+		indexed = testing.AllocsPerRun(10, func() {
+			for range MergeIndexed(cmp.Compare[int], slices.Values(a), slices.Values(b)) {
+			}
+		})
+		merge2 = testing.AllocsPerRun(10, func() {
+			for range Merge2(func(a1, a2, b1, b2 int) int { return cmp.Compare(a2, b2) }, slices.All(a), slices.All(b)) {
+			}
+		})
+		return
+	}
+	m1, i1, p1 := allocs(10)
+	m2, i2, p2 := allocs(1000)
+	if m1 != m2 || i1 != i2 || p1 != p2 {
+		t.Errorf("Expected allocations independent of element count, got %v, %v, %v vs %v, %v, %v", m1, i1, p1, m2, i2, p2)
+	}
+}
+
+func BenchmarkMerge_allocations(b *testing.B) {
+	const k, n = 8, 1000
+	seqs := make([]iter.Seq[int], k)
+	seqs2 := make([]iter.Seq2[int, int], k)
+	for i := range seqs {
+		s := make([]int, n)
+		for j := range s {
+			s[j] = i + j*k
+		}
+		seqs[i], seqs2[i] = slices.Values(s), slices.All(s)
+	}
+	b.Run("Merge", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			for range Merge(cmp.Compare[int], seqs...) {
+			}
+		}
+	})
+	b.Run("MergeIndexed", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			for range MergeIndexed(cmp.Compare[int], seqs...) {
+			}
+		}
+	})
+	b.Run("Merge2", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			for range Merge2(func(a1, a2, b1, b2 int) int { return cmp.Compare(a2, b2) }, seqs2...) {
+			}
+		}
+	})
+}
@@ -0,0 +1,233 @@
+package kway
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/joeycumines/go-kway/heap"
+)
+
+// MergedCursor is a stateful, bidirectional k-way merge of [BidiCursor]
+// sources, e.g. to page through a sorted view over multiple sources, in
+// either direction, without restarting the merge. The merged order is that
+// of [Merge]: elements comparing equal are ordered by source index, in both
+// directions.
+//
+// A MergedCursor is itself a [SeekCursor], positioned between elements of the
+// merged order. It is not safe for concurrent use, and assumes exclusive use
+// of its sources.
+type MergedCursor[T any] struct {
+	cmp  func(a, b T) int
+	srcs []mergedCursorSource[T]
+	// dir is the direction the heap is ordered for: 1 (Next), -1 (Prev), or
+	// 0 (neither, initially)
+	dir int
+	h   *heap.Heap[int]
+}
+
+// mergedCursorSource tracks the offset of a source's physical position from
+// its position in the merge, stepping ahead by one element, in the current
+// direction, to compare against the other sources.
+type mergedCursorSource[T any] struct {
+	c BidiCursor[T]
+	// v is the element between the logical and physical positions, if off
+	// is non-zero
+	v T
+	// off is the physical position, relative to the logical position: 1, if
+	// ahead (v is the next element), -1, if behind (v is the previous
+	// element), or 0
+	off int
+}
+
+var (
+	_ SeekCursor[any]  = (*MergedCursor[any])(nil)
+	_ CloneCursor[any] = (*MergedCursor[any])(nil)
+)
+
+// NewMergedCursor returns a [MergedCursor], merging the provided cursors,
+// which must each be sorted according to `cmp`, and positioned at their
+// start. Nil cursors are ignored. See [Merge] for details on the comparison
+// function.
+func NewMergedCursor[T any](cmp func(a, b T) int, cursors ...BidiCursor[T]) *MergedCursor[T] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	x := &MergedCursor[T]{cmp: cmp, srcs: make([]mergedCursorSource[T], len(cursors))}
+	for i, c := range cursors {
+		x.srcs[i].c = c
+	}
+	return x
+}
+
+// Next returns the next element in the merged order, and true, or false if
+// all sources are exhausted.
+func (x *MergedCursor[T]) Next() (T, bool) { return x.step(1) }
+
+// Prev returns the previous element in the merged order, and true, or false
+// if positioned at the start of all sources.
+func (x *MergedCursor[T]) Prev() (T, bool) { return x.step(-1) }
+
+// Peek returns the next element in the merged order, and true, without
+// consuming it, or false if all sources are exhausted.
+func (x *MergedCursor[T]) Peek() (v T, ok bool) {
+	x.orient(1)
+	if x.h.Len() == 0 {
+		return v, false
+	}
+	return x.srcs[x.h.Slice()[0]].v, true
+}
+
+// Seek positions the MergedCursor before the first element greater than or
+// equal to key, in the merged order, such that Next returns it, and merging
+// continues from there. Sources implementing [SeekCursor] are repositioned
+// using [SeekCursor.Seek], and others by stepping, discarding elements, in
+// whichever direction is required.
+func (x *MergedCursor[T]) Seek(key T) {
+	for i := range x.srcs {
+		src := &x.srcs[i]
+		if src.c == nil {
+			continue
+		}
+		if c, ok := src.c.(interface{ Seek(key T) }); ok {
+			c.Seek(key)
+		} else {
+			if src.off != 0 {
+				// return to the logical position
+				src.move(-src.off)
+			}
+			for {
+				v, ok := src.c.Prev()
+				if !ok {
+					break
+				}
+				if x.cmp(v, key) < 0 {
+					src.c.Next()
+					break
+				}
+			}
+			for {
+				v, ok := src.c.Next()
+				if !ok {
+					break
+				}
+				if x.cmp(v, key) >= 0 {
+					src.c.Prev()
+					break
+				}
+			}
+		}
+		src.v, src.off = *new(T), 0
+	}
+	x.dir = 0
+}
+
+// Fork returns an independent MergedCursor, at the same position in the
+// merged order, e.g. to look ahead speculatively, or to branch consumers,
+// without affecting x. The sources are cloned, as of their current positions,
+// including any elements buffered by x, so each must implement
+// [CloneCursor], such as [SliceCursor], [IndexCursor], or a MergedCursor of
+// such sources. Fork panics if a source does not.
+func (x *MergedCursor[T]) Fork() *MergedCursor[T] {
+	f := &MergedCursor[T]{cmp: x.cmp, srcs: slices.Clone(x.srcs)}
+	for i := range f.srcs {
+		src := &f.srcs[i]
+		if src.c == nil {
+			continue
+		}
+		c, ok := src.c.(CloneCursor[T])
+		if !ok {
+			panic("kway: fork of a source that is not a CloneCursor")
+		}
+		src.c = c.Clone()
+	}
+	// the heap is rebuilt on demand, from the buffered elements
+	return f
+}
+
+// Clone returns [MergedCursor.Fork], as a [BidiCursor].
+func (x *MergedCursor[T]) Clone() BidiCursor[T] { return x.Fork() }
+
+func (x *MergedCursor[T]) step(dir int) (v T, ok bool) {
+	x.orient(dir)
+	if x.h.Len() == 0 {
+		return v, false
+	}
+	i := x.h.Slice()[0]
+	src := &x.srcs[i]
+	v = src.v
+	src.v, src.off = *new(T), 0
+	if src.fetch(dir) {
+		x.h.Fix(0)
+	} else {
+		x.h.Pop()
+	}
+	return v, true
+}
+
+// orient prepares the heap for stepping in dir, rebuilding it, if the
+// direction changed.
+func (x *MergedCursor[T]) orient(dir int) {
+	if x.dir == dir {
+		return
+	}
+	var indexes []int
+	if x.h != nil {
+		indexes = x.h.Slice()[:0]
+	}
+	for i := range x.srcs {
+		if x.srcs[i].align(dir) {
+			indexes = append(indexes, i)
+		}
+	}
+	var order func(a, b int) int
+	if dir > 0 {
+		order = func(a, b int) int {
+			if v := x.cmp(x.srcs[a].v, x.srcs[b].v); v != 0 {
+				return v
+			}
+			return cmp.Compare(a, b)
+		}
+	} else {
+		order = func(a, b int) int {
+			if v := x.cmp(x.srcs[b].v, x.srcs[a].v); v != 0 {
+				return v
+			}
+			return cmp.Compare(b, a)
+		}
+	}
+	x.h = heap.New(order, indexes)
+	x.dir = dir
+}
+
+// align steps the source, such that it is ahead by one element, in dir,
+// reporting false if there is no such element.
+func (x *mergedCursorSource[T]) align(dir int) bool {
+	if x.c == nil {
+		return false
+	}
+	switch x.off {
+	case dir:
+		return true
+	case -dir:
+		// return to the logical position
+		x.move(dir)
+		x.v, x.off = *new(T), 0
+	}
+	return x.fetch(dir)
+}
+
+// fetch steps a source at its logical position ahead by one element, in dir.
+func (x *mergedCursorSource[T]) fetch(dir int) bool {
+	v, ok := x.move(dir)
+	if ok {
+		x.v, x.off = v, dir
+	}
+	return ok
+}
+
+func (x *mergedCursorSource[T]) move(dir int) (T, bool) {
+	if dir > 0 {
+		return x.c.Next()
+	}
+	return x.c.Prev()
+}
@@ -0,0 +1,263 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestMergedCursor(t *testing.T) {
+	type stableValue struct {
+		value int
+		seqID int
+	}
+	cmpFunc := func(a, b stableValue) int { return cmp.Compare(a.value, b.value) }
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for round := range 50 {
+		var (
+			cursors []BidiCursor[stableValue]
+			seqs    []iter.Seq[stableValue]
+		)
+		for i := range r.IntN(5) + 1 {
+			var s []stableValue
+			for range r.IntN(8) {
+				s = append(s, stableValue{r.IntN(10), i})
+			}
+			slices.SortFunc(s, cmpFunc)
+			if r.IntN(5) == 0 {
+				cursors = append(cursors, nil)
+				seqs = append(seqs, nil)
+				continue
+			}
+			var c BidiCursor[stableValue] = NewSortedSlice(cmpFunc, s).Cursor()
+			if r.IntN(2) == 0 {
+				// hide Seek, to exercise discard-scanning
+				c = struct{ BidiCursor[stableValue] }{c}
+			}
+			cursors = append(cursors, c)
+			seqs = append(seqs, sliceSeq(s))
+		}
+		expected := collectSeq(Merge(cmpFunc, seqs...))
+
+		x := NewMergedCursor(cmpFunc, cursors...)
+		var pos int
+		for step := range 100 {
+			switch r.IntN(5) {
+			case 0:
+				key := stableValue{r.IntN(12) - 1, 0}
+				x.Seek(key)
+				pos, _ = slices.BinarySearchFunc(expected, key, cmpFunc)
+			case 1, 2:
+				v, ok := x.Next()
+				if pos == len(expected) {
+					if ok {
+						t.Fatalf("Round %d, step %d: Next: expected end, got %v", round, step, v)
+					}
+					continue
+				}
+				if !ok || v != expected[pos] {
+					t.Fatalf("Round %d, step %d: Next: expected %v, got %v (%v)", round, step, expected[pos], v, ok)
+				}
+				pos++
+			default:
+				v, ok := x.Prev()
+				if pos == 0 {
+					if ok {
+						t.Fatalf("Round %d, step %d: Prev: expected start, got %v", round, step, v)
+					}
+					continue
+				}
+				pos--
+				if !ok || v != expected[pos] {
+					t.Fatalf("Round %d, step %d: Prev: expected %v, got %v (%v)", round, step, expected[pos], v, ok)
+				}
+			}
+		}
+	}
+}
+
+func TestMergedCursor_Paging(t *testing.T) {
+	a := NewSortedSlice(cmp.Compare[int], []int{1, 4, 7}).Cursor()
+	b := NewSortedSlice(cmp.Compare[int], []int{2, 5, 8}).Cursor()
+	c := NewSortedSlice(cmp.Compare[int], []int{3, 6, 9}).Cursor()
+	x := NewMergedCursor[int](cmp.Compare[int], a, b, c)
+
+	page := func(n int, step func() (int, bool)) (result []int) {
+		for range n {
+			v, ok := step()
+			if !ok {
+				break
+			}
+			result = append(result, v)
+		}
+		return result
+	}
+
+	if result := page(4, x.Next); !slices.Equal(result, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected [1 2 3 4], got %v", result)
+	}
+	if result := page(4, x.Next); !slices.Equal(result, []int{5, 6, 7, 8}) {
+		t.Errorf("Expected [5 6 7 8], got %v", result)
+	}
+	if result := page(6, x.Prev); !slices.Equal(result, []int{8, 7, 6, 5, 4, 3}) {
+		t.Errorf("Expected [8 7 6 5 4 3], got %v", result)
+	}
+	if result := page(10, x.Next); !slices.Equal(result, []int{3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("Expected [3 ... 9], got %v", result)
+	}
+	if result := page(10, x.Prev); len(result) != 9 || result[0] != 9 || result[8] != 1 {
+		t.Errorf("Expected [9 ... 1], got %v", result)
+	}
+}
+
+func TestMergedCursor_Seek(t *testing.T) {
+	a := NewSortedSlice(cmp.Compare[int], []int{1, 3, 5, 7}).Cursor()
+	b := struct{ BidiCursor[int] }{NewSortedSlice(cmp.Compare[int], []int{2, 3, 6}).Cursor()}
+	x := NewMergedCursor[int](cmp.Compare[int], a, b)
+
+	x.Seek(3)
+	if result := collectSeq(MergeCursors[int](cmp.Compare[int], x)); !slices.Equal(result, []int{3, 3, 5, 6, 7}) {
+		t.Errorf("Expected [3 3 5 6 7], got %v", result)
+	}
+	x.Seek(4)
+	if v, ok := x.Prev(); !ok || v != 3 {
+		t.Errorf("Expected (3, true), got (%d, %v)", v, ok)
+	}
+	x.Seek(100)
+	if _, ok := x.Next(); ok {
+		t.Error("Expected no elements after seeking past the end")
+	}
+	x.Seek(0)
+	if v, ok := x.Next(); !ok || v != 1 {
+		t.Errorf("Expected (1, true), got (%d, %v)", v, ok)
+	}
+}
+
+func TestMergedCursor_Peek(t *testing.T) {
+	x := NewMergedCursor[int](cmp.Compare[int],
+		NewSortedSlice(cmp.Compare[int], []int{1, 3}).Cursor(),
+		NewSortedSlice(cmp.Compare[int], []int{2}).Cursor(),
+	)
+	for _, expected := range []int{1, 2, 3} {
+		if v, ok := x.Peek(); !ok || v != expected {
+			t.Errorf("Peek: expected (%d, true), got (%d, %v)", expected, v, ok)
+		}
+		if v, ok := x.Next(); !ok || v != expected {
+			t.Errorf("Next: expected (%d, true), got (%d, %v)", expected, v, ok)
+		}
+	}
+	if _, ok := x.Peek(); ok {
+		t.Error("Expected false at the end")
+	}
+	if v, ok := x.Prev(); !ok || v != 3 {
+		t.Errorf("Expected (3, true), got (%d, %v)", v, ok)
+	}
+	if v, ok := x.Peek(); !ok || v != 3 {
+		t.Errorf("Expected (3, true), got (%d, %v)", v, ok)
+	}
+}
+
+func TestMergedCursor_Nested(t *testing.T) {
+	inner := NewMergedCursor[int](cmp.Compare[int],
+		NewSortedSlice(cmp.Compare[int], []int{1, 5}).Cursor(),
+		NewSortedSlice(cmp.Compare[int], []int{3}).Cursor(),
+	)
+	x := NewMergedCursor[int](cmp.Compare[int], inner, NewSortedSlice(cmp.Compare[int], []int{2, 4}).Cursor())
+	if result := collectSeq(MergeCursors[int](cmp.Compare[int], x)); !slices.Equal(result, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Expected [1 2 3 4 5], got %v", result)
+	}
+	if v, ok := x.Prev(); !ok || v != 5 {
+		t.Errorf("Expected (5, true), got (%d, %v)", v, ok)
+	}
+}
+
+func TestMergedCursor_Fork(t *testing.T) {
+	newCursor := func() *MergedCursor[int] {
+		inner := NewMergedCursor[int](cmp.Compare[int],
+			NewSortedSlice(cmp.Compare[int], []int{1, 5, 9}).Cursor(),
+			NewSortedSlice(cmp.Compare[int], []int{3, 7}).Cursor(),
+		)
+		return NewMergedCursor[int](cmp.Compare[int], inner, nil, NewSortedSlice(cmp.Compare[int], []int{2, 4, 6, 8}).Cursor())
+	}
+	drain := func(c Cursor[int]) (result []int) {
+		for v, ok := c.Next(); ok; v, ok = c.Next() {
+			result = append(result, v)
+		}
+		return result
+	}
+	for _, tc := range []struct {
+		name string
+		// move positions the cursor, before forking
+		move     func(x *MergedCursor[int])
+		expected []int
+	}{
+		{"start", func(x *MergedCursor[int]) {}, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{"next", func(x *MergedCursor[int]) { x.Next(); x.Next(); x.Next() }, []int{4, 5, 6, 7, 8, 9}},
+		{"peek", func(x *MergedCursor[int]) { x.Next(); x.Peek() }, []int{2, 3, 4, 5, 6, 7, 8, 9}},
+		{"prev", func(x *MergedCursor[int]) { drain(x); x.Prev(); x.Prev() }, []int{8, 9}},
+		{"seek", func(x *MergedCursor[int]) { x.Seek(6) }, []int{6, 7, 8, 9}},
+		{"end", func(x *MergedCursor[int]) { drain(x) }, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			x := newCursor()
+			tc.move(x)
+			f := x.Fork()
+			if actual := drain(f); !slices.Equal(actual, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, actual)
+			}
+			if actual := drain(x); !slices.Equal(actual, tc.expected) {
+				t.Errorf("Expected the original to be unaffected, %v, got %v", tc.expected, actual)
+			}
+			// the fork may step backward, over all elements
+			var reversed []int
+			for v, ok := f.Prev(); ok; v, ok = f.Prev() {
+				reversed = append(reversed, v)
+			}
+			if expected := []int{9, 8, 7, 6, 5, 4, 3, 2, 1}; !slices.Equal(reversed, expected) {
+				t.Errorf("Expected %v, got %v", expected, reversed)
+			}
+		})
+	}
+}
+
+func TestMergedCursor_Fork_NotCloneable(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "kway: fork of a source that is not a CloneCursor" {
+			t.Errorf("Expected panic, got %v", r)
+		}
+	}()
+	c := NewSortedSlice(cmp.Compare[int], []int{1}).Cursor()
+	NewMergedCursor[int](cmp.Compare[int], struct{ BidiCursor[int] }{c}).Fork()
+}
+
+func TestSliceCursor(t *testing.T) {
+	c := NewSortedSlice(cmp.Compare[int], []int{1, 2}).Cursor()
+	if _, ok := c.Prev(); ok {
+		t.Error("Expected false at the start")
+	}
+	c.Next()
+	c.Next()
+	if v, ok := c.Prev(); !ok || v != 2 || c.Len() != 1 {
+		t.Errorf("Expected (2, true) with 1 remaining, got (%d, %v) with %d", v, ok, c.Len())
+	}
+	c.Seek(0)
+	if c.Len() != 2 {
+		t.Errorf("Expected 2 remaining, got %d", c.Len())
+	}
+	c.Seek(2)
+	if v, ok := c.Next(); !ok || v != 2 {
+		t.Errorf("Expected (2, true), got (%d, %v)", v, ok)
+	}
+}
+
+func TestNewMergedCursor_NilCompareFunction(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil comparison function")
+		}
+	}()
+	NewMergedCursor[int](nil)
+}
@@ -0,0 +1,79 @@
+package kway
+
+import (
+	"iter"
+)
+
+// ErrorPolicy determines how [MergeValuesErr] handles errors yielded by its
+// input sequences.
+type ErrorPolicy int
+
+const (
+	// StopSource stops iteration of each input sequence at its first error.
+	// The merge continues with the remaining sequences.
+	StopSource ErrorPolicy = iota
+	// SkipError continues iteration of each input sequence past its errors,
+	// skipping only the element yielded with each error.
+	SkipError
+)
+
+// MergeValuesErr performs a k-way merge of the provided sorted input
+// sequences, each yielding elements with a nil error, or a non-nil error, as
+// is common for sequences backed by I/O. Unlike [Merge2], which would
+// compare the errors, elements with a nil error are merged by value, per
+// [Merge], and each non-nil error is yielded, with the zero value, after the
+// element preceding it in its sequence, and before any subsequent element of
+// the merge. Errors are handled per `policy`, e.g. [StopSource].
+//
+// To stop the merge at the first error, stop iterating the returned
+// sequence, on receiving it.
+func MergeValuesErr[T any](cmp func(a, b T) int, policy ErrorPolicy, seqs ...iter.Seq2[T, error]) iter.Seq2[T, error] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	if policy < StopSource || policy > SkipError {
+		panic("kway: invalid error policy")
+	}
+	if !anySeq(seqs) {
+		return emptySeq2[T, error]
+	}
+	return func(yield func(T, error) bool) {
+		// errors are pulled (via iter.Pull) synchronously with the merge
+		var pending []error
+		values := make([]iter.Seq[T], len(seqs))
+		for i, seq := range seqs {
+			if seq == nil {
+				continue
+			}
+			values[i] = func(yield func(T) bool) {
+				for v, err := range seq {
+					if err != nil {
+						pending = append(pending, err)
+						if policy == StopSource {
+							return
+						}
+					} else if !yield(v) {
+						return
+					}
+				}
+			}
+		}
+		flush := func() bool {
+			for len(pending) != 0 {
+				err := pending[0]
+				pending[0] = nil
+				pending = pending[1:]
+				if !yield(*new(T), err) {
+					return false
+				}
+			}
+			return true
+		}
+		for v := range Merge(cmp, values...) {
+			if !flush() || !yield(v, nil) {
+				return
+			}
+		}
+		flush()
+	}
+}
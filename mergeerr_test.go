@@ -0,0 +1,144 @@
+package kway
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+)
+
+// errSeq returns a sequence yielding the values, with an error in place of
+// each negative value.
+func errSeq(values ...int) iter.Seq2[int, error] {
+	return func(yield func(int, error) bool) {
+		for _, v := range values {
+			var err error
+			if v < 0 {
+				v, err = 0, fmt.Errorf("error %d", -v)
+			}
+			if !yield(v, err) {
+				return
+			}
+		}
+	}
+}
+
+func collectValuesErr(seq iter.Seq2[int, error]) []string {
+	var result []string
+	for v, err := range seq {
+		if err != nil {
+			result = append(result, err.Error())
+		} else {
+			result = append(result, fmt.Sprint(v))
+		}
+	}
+	return result
+}
+
+func TestMergeValuesErr(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   ErrorPolicy
+		seqs     []iter.Seq2[int, error]
+		expected []string
+	}{
+		{
+			name:     "no errors",
+			seqs:     []iter.Seq2[int, error]{errSeq(1, 3), errSeq(2)},
+			expected: []string{"1", "2", "3"},
+		},
+		{
+			name:     "stop source",
+			policy:   StopSource,
+			seqs:     []iter.Seq2[int, error]{errSeq(1, 4, -1, 5), errSeq(2, 3, 6)},
+			expected: []string{"1", "2", "3", "4", "error 1", "6"},
+		},
+		{
+			name:     "skip error",
+			policy:   SkipError,
+			seqs:     []iter.Seq2[int, error]{errSeq(1, 4, -1, 5), errSeq(2, 3, 6)},
+			expected: []string{"1", "2", "3", "4", "error 1", "5", "6"},
+		},
+		{
+			name:     "initial errors",
+			policy:   SkipError,
+			seqs:     []iter.Seq2[int, error]{errSeq(-1, 2), nil, errSeq(-2, -3, 1)},
+			expected: []string{"error 1", "error 2", "error 3", "1", "2"},
+		},
+		{
+			name:     "trailing error",
+			seqs:     []iter.Seq2[int, error]{errSeq(1, -1), errSeq(2)},
+			expected: []string{"1", "error 1", "2"},
+		},
+		{
+			name:     "only errors",
+			seqs:     []iter.Seq2[int, error]{errSeq(-1, 1), errSeq(-2)},
+			expected: []string{"error 1", "error 2"},
+		},
+		{
+			name: "empty",
+			seqs: []iter.Seq2[int, error]{nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := collectValuesErr(MergeValuesErr(cmp.Compare[int], tt.policy, tt.seqs...))
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMergeValuesErr_earlyExit(t *testing.T) {
+	var result []error
+	for _, err := range MergeValuesErr(cmp.Compare[int], SkipError, errSeq(-1, -2, 1), errSeq(2)) {
+		result = append(result, err)
+		if err != nil {
+			break
+		}
+	}
+	if len(result) != 1 || result[0].Error() != "error 1" {
+		t.Errorf("Expected [error 1], got %v", result)
+	}
+
+	var values []int
+	for v := range MergeValuesErr(cmp.Compare[int], SkipError, errSeq(1, 3), errSeq(2)) {
+		values = append(values, v)
+		if v == 2 {
+			break
+		}
+	}
+	if !slices.Equal(values, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", values)
+	}
+}
+
+func TestMergeValuesErr_errorIdentity(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	seq := func(yield func(int, error) bool) { yield(0, sentinel) }
+	for _, err := range MergeValuesErr(cmp.Compare[int], StopSource, seq) {
+		if err != sentinel {
+			t.Errorf("Expected sentinel error, got %v", err)
+		}
+	}
+}
+
+func TestMergeValuesErr_panics(t *testing.T) {
+	for _, fn := range []func(){
+		func() { MergeValuesErr[int](nil, StopSource) },
+		func() { MergeValuesErr(cmp.Compare[int], ErrorPolicy(99)) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			fn()
+		}()
+	}
+}
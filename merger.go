@@ -0,0 +1,297 @@
+package kway
+
+import (
+	"iter"
+)
+
+// Merger performs k-way merges of sorted sequences, like [Merge], with
+// behavior configured by [Option] values. A Merger may be reused, including
+// concurrently.
+type Merger[T any] struct {
+	// cmp is the primary comparison function
+	cmp func(a, b T) int
+	// order is the effective comparison function, incorporating tie breaks
+	order func(a, b T) int
+	opts  options
+	// buffers are retained from the last completed merge
+	buffers *bufferCache[*wrappedSeqValue[T]]
+	// summary is the typed summary of the options, if any
+	summary *Summary[T]
+}
+
+// NewMerger returns a new [Merger] using the provided comparison function and
+// options. See [Merge] for details on the comparison function.
+func NewMerger[T any](cmp func(a, b T) int, opts ...Option) *Merger[T] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	m := &Merger[T]{cmp: cmp, opts: newOptions(opts), buffers: new(bufferCache[*wrappedSeqValue[T]])}
+	m.order = cmp
+	if m.opts.tieBreak != nil {
+		tieBreak, ok := m.opts.tieBreak.(func(a, b T) int)
+		if !ok {
+			panic("kway: tie-break comparison function type mismatch")
+		}
+		m.order = func(a, b T) int {
+			if v := cmp(a, b); v != 0 {
+				return v
+			}
+			return tieBreak(a, b)
+		}
+	}
+	if m.opts.summary != nil {
+		var ok bool
+		if m.summary, ok = m.opts.summary.(*Summary[T]); !ok {
+			panic("kway: summary element type mismatch")
+		}
+	}
+	return m
+}
+
+// Merge performs a k-way merge of the provided sorted input sequences, per
+// [Merge], applying the options of the Merger.
+//
+// Violations detected by options such as [WithVerifySorted] cause a panic,
+// from the iteration of the returned sequence, with the error as its value.
+// See also [Merger.MergeChecked].
+func (m *Merger[T]) Merge(seqs ...iter.Seq[T]) iter.Seq[T] {
+	if !anySeq(seqs) {
+		return emptySeq[T]
+	}
+	return func(yield func(T) bool) {
+		m, _, err := m.bounded(countSeqs(seqs), m.opts.verifySorted, 0)
+		if err != nil {
+			panic(err)
+		}
+		srcs, release := prefetchSources(&m.opts, seqs)
+		defer release()
+		for v := range m.merge(srcs, m.opts.verifySorted, panicError) {
+			if !yield(v.v) {
+				return
+			}
+		}
+	}
+}
+
+// MergeChecked performs a k-way merge of the provided sorted input sequences,
+// per [Merger.Merge], but reports violations as errors, rather than panicking.
+// Each input sequence is verified to be sorted, as per [WithVerifySorted].
+//
+// Elements are yielded with a nil error, until a violation is detected, at
+// which point the zero value is yielded with the error (e.g. an
+// [*OrderError]), and iteration stops. The element that caused the violation
+// is not yielded.
+func (m *Merger[T]) MergeChecked(seqs ...iter.Seq[T]) iter.Seq2[T, error] {
+	if !anySeq(seqs) {
+		return emptySeq2[T, error]
+	}
+	return func(yield func(T, error) bool) {
+		var err error
+		fail := func(e error) {
+			if err == nil {
+				err = e
+			}
+		}
+		m, _, err := m.bounded(countSeqs(seqs), true, 0)
+		if err != nil {
+			yield(*new(T), err)
+			return
+		}
+		srcs, release := prefetchSources(&m.opts, seqs)
+		defer release()
+		for v := range m.merge(srcs, true, fail) {
+			if !yield(v.v, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(*new(T), err)
+		}
+	}
+}
+
+// Reset releases the references to elements and sources held by the buffers
+// the Merger retains from its last completed merge, retaining the buffers
+// themselves, such that subsequent merges of up to the same number of
+// sources do not reallocate them. Buffers are retained, and cleared,
+// automatically, as each merge completes, with Reset intended for long-lived
+// Mergers, between bursts of merges, e.g. per request, in a server. Merges
+// in progress are unaffected, and concurrent merges each use their own
+// buffers.
+func (m *Merger[T]) Reset() {
+	m.buffers.reset()
+}
+
+// MergeChecked performs a k-way merge of the provided sorted input sequences,
+// verifying each is sorted, and reporting violations as errors, rather than
+// panicking. It is equivalent to [Merger.MergeChecked], using a [Merger]
+// without options.
+func MergeChecked[T any](cmp func(a, b T) int, seqs ...iter.Seq[T]) iter.Seq2[T, error] {
+	return NewMerger(cmp).MergeChecked(seqs...)
+}
+
+// merge returns the underlying merged sequence, see mergePipeline. Sources
+// may be nil.
+func (m *Merger[T]) merge(srcs []pullSource[T], verify bool, fail func(err error)) iter.Seq[*wrappedSeqValue[T]] {
+	f := &failure{fail: fail}
+	buf := m.buffers.get()
+	wrapped := reuse(buf.srcs, len(srcs))
+	buf.srcs = wrapped
+	for i, src := range srcs {
+		if src != nil {
+			wrapped[i] = func() (func() (*wrappedSeqValue[T], bool), func()) {
+				next, stop := src()
+				if verify {
+					next = verifyNext(m.order, i, next, func(err *OrderError) { f.report(err) })
+				}
+				return wrapNext(i, next), stop
+			}
+		}
+	}
+	cmp := m.order
+	if m.opts.checkComparator > 0 {
+		cmp = checkCompare(cmp, m.opts.checkComparator)
+	}
+	var summary summarizer[*wrappedSeqValue[T]]
+	if m.summary != nil {
+		summary = wrappedSummary[*wrappedSeqValue[T], T]{m.summary, func(v *wrappedSeqValue[T]) T { return v.v }}
+	}
+	return mergePipeline(&m.opts, wrapCompare(cmp), func(a, b *wrappedSeqValue[T]) bool {
+		return m.cmp(a.v, b.v) == 0
+	}, wrapped, f, buf, summary)
+}
+
+// seqSources returns the pull sources for seqs, which may be nil.
+func seqSources[T any](seqs []iter.Seq[T]) []pullSource[T] {
+	srcs := make([]pullSource[T], len(seqs))
+	for i, seq := range seqs {
+		if seq != nil {
+			srcs[i] = seqSource(seq)
+		}
+	}
+	return srcs
+}
+
+func anySeq[S ~func(Y), Y any](seqs []S) bool {
+	for _, seq := range seqs {
+		if seq != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func panicError(err error) { panic(err) }
+
+// Merger2 is the [iter.Seq2] equivalent of [Merger], performing k-way merges
+// like [Merge2], with behavior configured by [Option] values. A Merger2 may be
+// reused, including concurrently.
+//
+// Options accepting comparison functions must use the [iter.Seq2] form, e.g.
+// [WithTieBreak2].
+type Merger2[T1 any, T2 any] struct {
+	cmp     func(a1 T1, a2 T2, b1 T1, b2 T2) int
+	order   func(a1 T1, a2 T2, b1 T1, b2 T2) int
+	opts    options
+	buffers *bufferCache[*wrappedSeq2Value[T1, T2]]
+	summary *Summary[Pair[T1, T2]]
+}
+
+// NewMerger2 returns a new [Merger2] using the provided comparison function
+// and options. See [Merge] for details on the comparison function.
+func NewMerger2[T1 any, T2 any](cmp func(a1 T1, a2 T2, b1 T1, b2 T2) int, opts ...Option) *Merger2[T1, T2] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	m := &Merger2[T1, T2]{cmp: cmp, opts: newOptions(opts), buffers: new(bufferCache[*wrappedSeq2Value[T1, T2]])}
+	m.order = cmp
+	if m.opts.tieBreak != nil {
+		tieBreak, ok := m.opts.tieBreak.(func(a1 T1, a2 T2, b1 T1, b2 T2) int)
+		if !ok {
+			panic("kway: tie-break comparison function type mismatch")
+		}
+		m.order = func(a1 T1, a2 T2, b1 T1, b2 T2) int {
+			if v := cmp(a1, a2, b1, b2); v != 0 {
+				return v
+			}
+			return tieBreak(a1, a2, b1, b2)
+		}
+	}
+	if m.opts.summary != nil {
+		var ok bool
+		if m.summary, ok = m.opts.summary.(*Summary[Pair[T1, T2]]); !ok {
+			panic("kway: summary element type mismatch")
+		}
+	}
+	return m
+}
+
+// Merge performs a k-way merge of the provided sorted input sequences, per
+// [Merge2], applying the options of the Merger2.
+//
+// Violations detected by options such as [WithVerifySorted] cause a panic,
+// from the iteration of the returned sequence, with the error as its value.
+// For a [*DuplicateKeyError], the key is the first value of the pair.
+func (m *Merger2[T1, T2]) Merge(seqs ...iter.Seq2[T1, T2]) iter.Seq2[T1, T2] {
+	if !anySeq(seqs) {
+		return emptySeq2[T1, T2]
+	}
+	return func(yield func(T1, T2) bool) {
+		n := countSeqs(seqs)
+		m, err := m.bounded(n)
+		if err != nil {
+			panic(err)
+		}
+		defer m.opts.acquirePrefetch(n)()
+		for v := range m.merge(seqs, m.opts.verifySorted, panicError) {
+			if !yield(v.v1, v.v2) {
+				return
+			}
+		}
+	}
+}
+
+// Reset releases the references held by the buffers the Merger2 retains
+// from its last completed merge, retaining the buffers, see [Merger.Reset].
+func (m *Merger2[T1, T2]) Reset() {
+	m.buffers.reset()
+}
+
+func (m *Merger2[T1, T2]) merge(seqs []iter.Seq2[T1, T2], verify bool, fail func(err error)) iter.Seq[*wrappedSeq2Value[T1, T2]] {
+	f := &failure{fail: fail}
+	buf := m.buffers.get()
+	wrapped := reuse(buf.srcs, len(seqs))
+	buf.srcs = wrapped
+	for i, seq := range seqs {
+		if seq != nil {
+			wrapped[i] = func() (func() (*wrappedSeq2Value[T1, T2], bool), func()) {
+				var (
+					next func() (T1, T2, bool)
+					stop func()
+				)
+				if m.opts.prefetch > 0 {
+					next, stop = prefetchPull2(seq, m.opts.prefetch)
+				} else {
+					next, stop = iter.Pull2(seq)
+				}
+				if verify {
+					next = verifyNext2(m.order, i, next, func(err *OrderError) { f.report(err) })
+				}
+				return wrapNext2(i, next), stop
+			}
+		}
+	}
+	cmp := m.order
+	if m.opts.checkComparator > 0 {
+		cmp = checkCompare2(cmp, m.opts.checkComparator)
+	}
+	var summary summarizer[*wrappedSeq2Value[T1, T2]]
+	if m.summary != nil {
+		summary = wrappedSummary[*wrappedSeq2Value[T1, T2], Pair[T1, T2]]{m.summary, func(v *wrappedSeq2Value[T1, T2]) Pair[T1, T2] {
+			return Pair[T1, T2]{v.v1, v.v2}
+		}}
+	}
+	return mergePipeline(&m.opts, wrapCompare2(cmp), func(a, b *wrappedSeq2Value[T1, T2]) bool {
+		return m.cmp(a.v1, a.v2, b.v1, b.v2) == 0
+	}, wrapped, f, buf, summary)
+}
@@ -0,0 +1,481 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNewMerger_NilCompareFunction(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil comparison function")
+		} else if !strings.Contains(r.(string), "nil comparison function") {
+			t.Errorf("Expected panic message about nil comparison function, got: %v", r)
+		}
+	}()
+
+	_ = NewMerger[int](nil)
+}
+
+func TestMerger_Merge(t *testing.T) {
+	m := NewMerger(cmp.Compare[int])
+
+	tests := []struct {
+		name     string
+		seqs     []iter.Seq[int]
+		expected []int
+	}{
+		{name: "no sequences"},
+		{name: "nil sequences", seqs: []iter.Seq[int]{nil, nil}},
+		{
+			name:     "multiple sequences",
+			seqs:     []iter.Seq[int]{sliceSeq([]int{1, 4, 7}), nil, sliceSeq([]int{2, 5}), sliceSeq([]int{3, 6})},
+			expected: []int{1, 2, 3, 4, 5, 6, 7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := collectSeq(m.Merge(tt.seqs...)); !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMerger_Merge_Reuse(t *testing.T) {
+	m := NewMerger(strings.Compare)
+	for range 3 {
+		result := collectSeq(m.Merge(sliceSeq([]string{"a", "c"}), sliceSeq([]string{"b"})))
+		if !slices.Equal(result, []string{"a", "b", "c"}) {
+			t.Errorf("Expected [a b c], got %v", result)
+		}
+	}
+}
+
+func TestMerger_Merge_EarlyTermination(t *testing.T) {
+	m := NewMerger(cmp.Compare[int])
+	var result []int
+	for v := range m.Merge(sliceSeq([]int{1, 3, 5}), sliceSeq([]int{2, 4, 6})) {
+		result = append(result, v)
+		if len(result) == 3 {
+			break
+		}
+	}
+	if !slices.Equal(result, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", result)
+	}
+}
+
+func TestWithTieBreak(t *testing.T) {
+	type event struct {
+		time   int
+		id     string
+		source int
+	}
+
+	m := NewMerger(
+		func(a, b event) int { return cmp.Compare(a.time, b.time) },
+		WithTieBreak(func(a, b event) int { return strings.Compare(a.id, b.id) }),
+	)
+
+	result := collectSeq(m.Merge(
+		sliceSeq([]event{{1, "b", 0}, {2, "a", 0}, {2, "c", 0}}),
+		sliceSeq([]event{{1, "a", 1}, {2, "a", 1}, {2, "b", 1}}),
+	))
+
+	expected := []event{
+		{1, "a", 1},
+		{1, "b", 0},
+		{2, "a", 0}, // equal under both, falls back to source order
+		{2, "a", 1},
+		{2, "b", 1},
+		{2, "c", 0},
+	}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestWithTieBreak_TypeMismatch(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for mismatched tie-break type")
+		} else if !strings.Contains(r.(string), "type mismatch") {
+			t.Errorf("Expected panic message about type mismatch, got: %v", r)
+		}
+	}()
+	_ = NewMerger(cmp.Compare[int], WithTieBreak(strings.Compare))
+}
+
+func TestWithTieBreak_Nil(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil tie-break function")
+		}
+	}()
+	_ = WithTieBreak[int](nil)
+}
+
+func TestMergeChecked(t *testing.T) {
+	tests := []struct {
+		name     string
+		seqs     []iter.Seq[int]
+		expected []int
+		err      *OrderError
+	}{
+		{name: "no sequences"},
+		{
+			name:     "sorted",
+			seqs:     []iter.Seq[int]{sliceSeq([]int{1, 3}), nil, sliceSeq([]int{2, 4})},
+			expected: []int{1, 2, 3, 4},
+		},
+		{
+			name:     "unsorted",
+			seqs:     []iter.Seq[int]{sliceSeq([]int{1, 3, 5, 7}), sliceSeq([]int{2, 6, 4, 8})},
+			expected: []int{1, 2, 3, 5, 6},
+			err:      &OrderError{Source: 1, Position: 2, Prev: 6, Next: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				result []int
+				err    error
+			)
+			for v, e := range MergeChecked(cmp.Compare[int], tt.seqs...) {
+				if e != nil {
+					if err != nil {
+						t.Fatal("Expected at most one error")
+					}
+					err = e
+					continue
+				}
+				if err != nil {
+					t.Fatal("Expected no elements after the error")
+				}
+				result = append(result, v)
+			}
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+			if tt.err == nil {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			} else if orderErr, ok := err.(*OrderError); !ok || *orderErr != *tt.err {
+				t.Errorf("Expected error %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestMergeChecked_EarlyTermination(t *testing.T) {
+	var result []int
+	for v, err := range MergeChecked(cmp.Compare[int], sliceSeq([]int{1, 3, 2}), sliceSeq([]int{2})) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		result = append(result, v)
+		if len(result) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(result, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", result)
+	}
+}
+
+func TestWithUniqueKeys(t *testing.T) {
+	m := NewMerger(cmp.Compare[int], WithUniqueKeys())
+
+	// duplicates within a single source are permitted
+	result := collectSeq(m.Merge(sliceSeq([]int{1, 1, 4}), sliceSeq([]int{2, 3, 3})))
+	if !slices.Equal(result, []int{1, 1, 2, 3, 3, 4}) {
+		t.Errorf("Expected [1 1 2 3 3 4], got %v", result)
+	}
+
+	result = nil
+	var err error
+	for v, e := range m.MergeChecked(sliceSeq([]int{1, 3, 5}), sliceSeq([]int{2}), sliceSeq([]int{3, 4})) {
+		if e != nil {
+			err = e
+			break
+		}
+		result = append(result, v)
+	}
+	if !slices.Equal(result, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", result)
+	}
+	if dupErr, ok := err.(*DuplicateKeyError); !ok || *dupErr != (DuplicateKeyError{Key: 3, First: 0, Second: 2}) {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	defer func() {
+		if _, ok := recover().(*DuplicateKeyError); !ok {
+			t.Error("Expected panic with *DuplicateKeyError")
+		}
+	}()
+	for range m.Merge(sliceSeq([]int{1}), sliceSeq([]int{1})) {
+	}
+}
+
+func TestDuplicateKeyError_Error(t *testing.T) {
+	err := &DuplicateKeyError{Key: "k", First: 1, Second: 3}
+	expected := "kway: duplicate key k in sources 1 and 3"
+	if s := err.Error(); s != expected {
+		t.Errorf("Expected %q, got %q", expected, s)
+	}
+}
+
+func TestNewMerger2_NilCompareFunction(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil comparison function")
+		}
+	}()
+	_ = NewMerger2[int, string](nil)
+}
+
+func TestMerger2_Merge(t *testing.T) {
+	cmpFunc := func(a1 int, a2 string, b1 int, b2 string) int {
+		return cmp.Compare(a1, b1)
+	}
+
+	m := NewMerger2(cmpFunc, WithTieBreak2(func(a1 int, a2 string, b1 int, b2 string) int {
+		return strings.Compare(a2, b2)
+	}))
+
+	r1, r2 := collectSeq2(m.Merge())
+	if len(r1) != 0 || len(r2) != 0 {
+		t.Errorf("Expected empty result, got %v, %v", r1, r2)
+	}
+
+	r1, r2 = collectSeq2(m.Merge(
+		sliceSeq2([]int{1, 2, 3}, []string{"b", "a", "c"}),
+		nil,
+		sliceSeq2([]int{1, 3}, []string{"a", "b"}),
+	))
+	expected1 := []int{1, 1, 2, 3, 3}
+	expected2 := []string{"a", "b", "a", "b", "c"}
+	if !slices.Equal(r1, expected1) || !slices.Equal(r2, expected2) {
+		t.Errorf("Expected %v, %v; got %v, %v", expected1, expected2, r1, r2)
+	}
+}
+
+func TestMerger2_Options(t *testing.T) {
+	cmpFunc := func(a1 int, a2 string, b1 int, b2 string) int {
+		return cmp.Compare(a1, b1)
+	}
+
+	tests := []struct {
+		name  string
+		opts  []Option
+		check func(t *testing.T, r any)
+	}{
+		{
+			name: "verify sorted",
+			opts: []Option{WithVerifySorted()},
+			check: func(t *testing.T, r any) {
+				if err, ok := r.(*OrderError); !ok || err.Source != 1 {
+					t.Errorf("Expected *OrderError for source 1, got %v", r)
+				}
+			},
+		},
+		{
+			name: "unique keys",
+			opts: []Option{WithUniqueKeys()},
+			check: func(t *testing.T, r any) {
+				if err, ok := r.(*DuplicateKeyError); !ok || *err != (DuplicateKeyError{Key: 2, First: 0, Second: 1}) {
+					t.Errorf("Unexpected panic value: %v", r)
+				}
+			},
+		},
+		{
+			name: "comparator check",
+			opts: []Option{WithComparatorCheck(1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if tt.check != nil {
+					tt.check(t, r)
+				} else if r != nil {
+					t.Errorf("Unexpected panic: %v", r)
+				}
+			}()
+			for range NewMerger2(cmpFunc, tt.opts...).Merge(
+				sliceSeq2([]int{1, 2}, []string{"a", "b"}),
+				sliceSeq2([]int{2, 4, 3}, []string{"c", "d", "e"}),
+			) {
+			}
+		})
+	}
+}
+
+func TestWithTieBreak2_TypeMismatch(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for mismatched tie-break type")
+		}
+	}()
+	_ = NewMerger2(func(a1 int, a2 string, b1 int, b2 string) int { return 0 }, WithTieBreak(cmp.Compare[int]))
+}
+
+func TestWithProgress(t *testing.T) {
+	var calls []int64
+	m := NewMerger(cmp.Compare[int], WithProgress(2, func(emitted int64) {
+		calls = append(calls, emitted)
+	}))
+
+	_ = collectSeq(m.Merge(sliceSeq([]int{1, 3, 5, 7}), sliceSeq([]int{2, 4, 6})))
+	if !slices.Equal(calls, []int64{2, 4, 6}) {
+		t.Errorf("Expected [2 4 6], got %v", calls)
+	}
+
+	calls = nil
+	for v := range m.Merge(sliceSeq([]int{1, 2, 3})) {
+		if v == 2 {
+			break
+		}
+	}
+	if len(calls) != 0 {
+		t.Errorf("Expected no calls after stopping, got %v", calls)
+	}
+}
+
+func TestWithProgress_InvalidArguments(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"zero interval": func() { _ = WithProgress(0, func(int64) {}) },
+		"nil callback":  func() { _ = WithProgress(1, nil) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
+
+func TestMerger_Reset(t *testing.T) {
+	for _, strategy := range []Strategy{StrategyHeap, StrategyLoserTree, StrategyHeap4} {
+		t.Run(strategy.String(), func(t *testing.T) {
+			m := NewMerger(cmp.Compare[int], WithStrategy(strategy))
+			merge := func() []int {
+				return collectSeq(m.MergeCursors(
+					NewSortedSlice(cmp.Compare[int], []int{1, 4, 7}).Cursor(),
+					NewSortedSlice(cmp.Compare[int], []int{2, 5}).Cursor(),
+					NewSortedSlice(cmp.Compare[int], []int{3, 6, 9}).Cursor(),
+				))
+			}
+			expected := []int{1, 2, 3, 4, 5, 6, 7, 9}
+			if actual := merge(); !slices.Equal(actual, expected) {
+				t.Errorf("Expected %v, got %v", expected, actual)
+			}
+			buf := m.buffers.p.Load()
+			if buf == nil || cap(buf.pulls) < 3 || cap(buf.srcs) < 3 {
+				t.Fatal("Expected the buffers to be retained")
+			}
+			cleared := func() {
+				t.Helper()
+				for i, v := range buf.pulls[:cap(buf.pulls)] {
+					if v != nil || buf.srcs[:cap(buf.srcs)][i] != nil {
+						t.Errorf("Expected source %d to be cleared", i)
+					}
+				}
+				for _, v := range buf.items[:cap(buf.items)] {
+					if v != nil {
+						t.Errorf("Expected the items to be cleared, got %v", v)
+					}
+				}
+			}
+			cleared()
+			m.Reset()
+			if m.buffers.p.Load() != buf {
+				t.Fatal("Expected the buffers to be retained after Reset")
+			}
+			cleared()
+			if actual := merge(); !slices.Equal(actual, expected) {
+				t.Errorf("Expected %v, got %v", expected, actual)
+			}
+			if m.buffers.p.Load() != buf {
+				t.Error("Expected the buffers to be reused")
+			}
+		})
+	}
+}
+
+func TestMerger_Reset_allocations(t *testing.T) {
+	const k = 16
+	slices := make([][]int, k)
+	for i := range slices {
+		slices[i] = []int{i}
+	}
+	cursors := make([]Cursor[int], k)
+	merge := func(m *Merger[int]) {
+		for i, s := range slices {
+			cursors[i] = NewSortedSlice(cmp.Compare[int], s).Cursor()
+		}
+		for range m.MergeCursors(cursors...) {
+		}
+	}
+	m := NewMerger(cmp.Compare[int])
+	reused := testing.AllocsPerRun(100, func() { merge(m) })
+	fresh := testing.AllocsPerRun(100, func() { merge(NewMerger(cmp.Compare[int])) })
+	if reused+3 > fresh {
+		t.Errorf("Expected fewer allocations reusing the Merger, got %v vs %v", reused, fresh)
+	}
+}
+
+func TestMerger2_Reset(t *testing.T) {
+	m := NewMerger2(func(a1, a2, b1, b2 int) int { return cmp.Compare(a1, b1) })
+	for range 2 {
+		keys, _ := collectSeq2(m.Merge(sliceSeq2([]int{1, 3}, []int{0, 0}), sliceSeq2([]int{2}, []int{0})))
+		if expected := []int{1, 2, 3}; !slices.Equal(keys, expected) {
+			t.Errorf("Expected %v, got %v", expected, keys)
+		}
+		m.Reset()
+	}
+	if buf := m.buffers.p.Load(); buf == nil || buf.srcs[0] != nil {
+		t.Error("Expected the buffers to be retained, and cleared")
+	}
+}
+
+func BenchmarkMerger_Reset(b *testing.B) {
+	const k = 64
+	inputs := make([][]int, k)
+	for i := range inputs {
+		inputs[i] = []int{i, i + k}
+	}
+	cursors := make([]Cursor[int], k)
+	merge := func(m *Merger[int]) {
+		for i, s := range inputs {
+			cursors[i] = NewSortedSlice(cmp.Compare[int], s).Cursor()
+		}
+		for range m.MergeCursors(cursors...) {
+		}
+	}
+	b.Run("reused", func(b *testing.B) {
+		b.ReportAllocs()
+		m := NewMerger(cmp.Compare[int])
+		for b.Loop() {
+			merge(m)
+		}
+	})
+	b.Run("fresh", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			merge(NewMerger(cmp.Compare[int]))
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package kway
+
+import (
+	"expvar"
+	"fmt"
+)
+
+// Metrics are counters describing merges, updated by merges configured using
+// [WithMetrics]. The zero value is ready to use, and a Metrics may be shared
+// by any number of mergers, including concurrently.
+//
+// Metrics implements [expvar.Var], and may be published using
+// [expvar.Publish], to be exported as a JSON object.
+type Metrics struct {
+	// Merged is the total number of elements yielded by merges.
+	Merged expvar.Int
+	// Active is the number of merges currently being iterated.
+	Active expvar.Int
+	// SourcesExhausted is the total number of input sequences that ended,
+	// i.e. had no more elements, as opposed to being stopped.
+	SourcesExhausted expvar.Int
+	// Errors is the total number of violations reported by merges, e.g.
+	// [*OrderError] values.
+	Errors expvar.Int
+}
+
+var _ expvar.Var = (*Metrics)(nil)
+
+// String returns the metrics as a JSON object, implementing [expvar.Var].
+func (x *Metrics) String() string {
+	return fmt.Sprintf(`{"merged": %d, "active": %d, "sources_exhausted": %d, "errors": %d}`,
+		x.Merged.Value(), x.Active.Value(), x.SourcesExhausted.Value(), x.Errors.Value())
+}
+
+// WithMetrics enables updating of `metrics`, by each merge.
+func WithMetrics(metrics *Metrics) Option {
+	if metrics == nil {
+		panic("kway: nil metrics")
+	}
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+// metricsNext wraps next, counting the source as exhausted once it first
+// ends.
+func metricsNext[E any](metrics *Metrics, next func() (E, bool)) func() (E, bool) {
+	var done bool
+	return func() (E, bool) {
+		v, ok := next()
+		if !ok && !done {
+			done = true
+			metrics.SourcesExhausted.Add(1)
+		}
+		return v, ok
+	}
+}
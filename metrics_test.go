@@ -0,0 +1,87 @@
+package kway
+
+import (
+	"cmp"
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestMetrics_String(t *testing.T) {
+	var metrics Metrics
+	metrics.Merged.Add(10)
+	metrics.Active.Add(2)
+	metrics.SourcesExhausted.Add(3)
+	metrics.Errors.Add(1)
+
+	var decoded map[string]int64
+	if err := json.Unmarshal([]byte(metrics.String()), &decoded); err != nil {
+		t.Fatalf("Expected valid JSON, got %q: %v", metrics.String(), err)
+	}
+	expected := map[string]int64{"merged": 10, "active": 2, "sources_exhausted": 3, "errors": 1}
+	for k, v := range expected {
+		if decoded[k] != v {
+			t.Errorf("Expected %s = %d, got %d", k, v, decoded[k])
+		}
+	}
+}
+
+func TestWithMetrics(t *testing.T) {
+	var metrics Metrics
+	m := NewMerger(cmp.Compare[int], WithMetrics(&metrics))
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = collectSeq(m.Merge(sliceSeq([]int{1, 3}), sliceSeq([]int{2})))
+		}()
+	}
+	wg.Wait()
+
+	if v := metrics.Merged.Value(); v != 12 {
+		t.Errorf("Expected 12 merged, got %d", v)
+	}
+	if v := metrics.SourcesExhausted.Value(); v != 8 {
+		t.Errorf("Expected 8 sources exhausted, got %d", v)
+	}
+	if v := metrics.Active.Value(); v != 0 {
+		t.Errorf("Expected 0 active, got %d", v)
+	}
+
+	for range m.Merge(sliceSeq([]int{1, 2})) {
+		if v := metrics.Active.Value(); v != 1 {
+			t.Errorf("Expected 1 active during iteration, got %d", v)
+		}
+		break
+	}
+	if v := metrics.Active.Value(); v != 0 {
+		t.Errorf("Expected 0 active after iteration, got %d", v)
+	}
+
+	for range NewMerger(cmp.Compare[int], WithMetrics(&metrics)).MergeChecked(sliceSeq([]int{2, 1})) {
+	}
+	if v := metrics.Errors.Value(); v != 1 {
+		t.Errorf("Expected 1 error, got %d", v)
+	}
+}
+
+func TestWithMetrics_Nil(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil metrics")
+		}
+	}()
+	_ = WithMetrics(nil)
+}
+
+func TestMetricsNext(t *testing.T) {
+	var metrics Metrics
+	next := metricsNext(&metrics, func() (int, bool) { return 0, false })
+	next()
+	next()
+	if v := metrics.SourcesExhausted.Value(); v != 1 {
+		t.Errorf("Expected 1 source exhausted, got %d", v)
+	}
+}
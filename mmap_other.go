@@ -0,0 +1,18 @@
+//go:build !unix
+
+package kway
+
+import (
+	"io"
+	"os"
+)
+
+// mapFile reads the first n bytes of f, as memory-mapping is not supported,
+// returning them, and a no-op function to release them.
+func mapFile(f *os.File, n int) ([]byte, func() error, error) {
+	data := make([]byte, n)
+	if _, err := io.ReadFull(f, data); err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return nil }, nil
+}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package kway
+
+import (
+	"os"
+	"syscall"
+)
+
+// mapFile maps the first n bytes of f, read-only, returning the mapping, and
+// a function to unmap it.
+func mapFile(f *os.File, n int) ([]byte, func() error, error) {
+	data, err := syscall.Mmap(int(f.Fd()), 0, n, syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
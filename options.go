@@ -0,0 +1,116 @@
+package kway
+
+// Option configures a [Merger].
+type Option func(*options)
+
+type options struct {
+	verifySorted    bool
+	checkComparator int
+	tieBreak        any
+	priority        []int
+	uniqueKeys      bool
+	stats           *Stats
+	progressEvery   int64
+	progress        func(emitted int64)
+	metrics         *Metrics
+	trace           func(event TraceEvent)
+	parallelism     int
+	limiter         *Limiter
+	prefetch        int
+	writeBuffer     int
+	flushEvery      int64
+	yieldBatch      int
+	strategy        Strategy
+	recording       *Recording
+	maxBuffered     int
+	hooks           SourceHooks
+	maxPerKey       int
+	summary         any
+}
+
+func newOptions(opts []Option) (o options) {
+	o.checkComparator = debugCheckComparator
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithVerifySorted enables verification that each input sequence is sorted,
+// as elements are pulled from it. A violation is reported as an
+// [*OrderError], identifying the offending source and pair of elements.
+func WithVerifySorted() Option {
+	return func(o *options) {
+		o.verifySorted = true
+	}
+}
+
+// WithComparatorCheck enables validation of the comparison function, for
+// every nth comparison, panicking with a [*ComparatorError] if it is found to
+// be inconsistent. Validation of a comparison, between elements `a` and `b`,
+// checks that cmp(a, a) and cmp(b, b) are 0, and that cmp(a, b) has the
+// opposite sign of cmp(b, a). A value of 0 disables validation.
+//
+// Validation of every comparison is enabled by default, including for
+// [Merge] and [Merge2], if built with the kwaydebug build tag.
+func WithComparatorCheck(every int) Option {
+	if every < 0 {
+		panic("kway: negative comparator check interval")
+	}
+	return func(o *options) {
+		o.checkComparator = every
+	}
+}
+
+// WithTieBreak configures a secondary comparison function, consulted when the
+// primary comparison function considers two elements equal, before falling
+// back to the documented stability behavior of ordering by input sequence.
+// The element type of `cmp` must match that of the [Merger].
+//
+// The input sequences must each be sorted according to the combined ordering.
+func WithTieBreak[T any](cmp func(a, b T) int) Option {
+	if cmp == nil {
+		panic("kway: nil tie-break comparison function")
+	}
+	return func(o *options) {
+		o.tieBreak = cmp
+	}
+}
+
+// WithTieBreak2 is the [Merger2] equivalent of [WithTieBreak].
+func WithTieBreak2[T1 any, T2 any](cmp func(a1 T1, a2 T2, b1 T1, b2 T2) int) Option {
+	if cmp == nil {
+		panic("kway: nil tie-break comparison function")
+	}
+	return func(o *options) {
+		o.tieBreak = cmp
+	}
+}
+
+// WithUniqueKeys enables enforcement that elements comparing equal, according
+// to the primary comparison function, originate from at most one input
+// sequence, e.g. for merging partitions that are expected to be disjoint. A
+// violation is reported as a [*DuplicateKeyError]. Duplicates within a single
+// input sequence are permitted.
+func WithUniqueKeys() Option {
+	return func(o *options) {
+		o.uniqueKeys = true
+	}
+}
+
+// WithProgress configures a callback, called with the number of elements
+// emitted by the merge so far, each time it reaches a multiple of `every`. It
+// is called from the goroutine iterating the merged sequence, after the
+// element has been yielded, and must not block for long.
+func WithProgress(every int, fn func(emitted int64)) Option {
+	if every <= 0 {
+		panic("kway: progress interval must be positive")
+	}
+	if fn == nil {
+		panic("kway: nil progress callback")
+	}
+	return func(o *options) {
+		o.progressEvery = int64(every)
+		o.progress = fn
+	}
+}
@@ -0,0 +1,139 @@
+package kway
+
+import (
+	"iter"
+)
+
+// OrderedIterator is the minimal interface required to merge an ordered
+// container, such as a B-tree, whose iterator advances in ascending order.
+// It is deliberately small, such that the iterators of libraries, e.g.
+// github.com/google/btree or github.com/tidwall/btree, may be adapted with a
+// few lines of code, without this package importing them. It has the same
+// method as [Cursor], so may be merged directly, see [MergeCursors].
+//
+// Iterators able to skip forward efficiently should also implement
+// [OrderedSeeker]. See [NewOrderedCursor] and [OrderedFunc].
+type OrderedIterator[T any] interface {
+	// Next returns the next element and true, or false if there are no more
+	// elements.
+	Next() (T, bool)
+}
+
+// OrderedSeeker is an [OrderedIterator] that may skip forward, by key.
+type OrderedSeeker[T any] interface {
+	OrderedIterator[T]
+	// SeekGE positions the iterator before the first element greater than
+	// or equal to key, such that Next returns it. Keys less than or equal to
+	// the last element returned by Next, or any previous key, need not be
+	// supported, and are not passed by [OrderedCursor].
+	SeekGE(key T)
+}
+
+// OrderedFunc returns an [OrderedIterator] calling `next`, which implements
+// [OrderedSeeker], calling `seekGE`, if it is not nil, e.g. to adapt an
+// iterator with differently named methods, or to restart a callback-based
+// iteration, from a key, via [iter.Pull].
+func OrderedFunc[T any](next func() (T, bool), seekGE func(key T)) OrderedIterator[T] {
+	if next == nil {
+		panic("kway: nil next function")
+	}
+	if seekGE == nil {
+		return orderedFunc[T](next)
+	}
+	return orderedSeekFunc[T]{next, seekGE}
+}
+
+type orderedFunc[T any] func() (T, bool)
+
+func (x orderedFunc[T]) Next() (T, bool) { return x() }
+
+type orderedSeekFunc[T any] struct {
+	next   func() (T, bool)
+	seekGE func(key T)
+}
+
+func (x orderedSeekFunc[T]) Next() (T, bool) { return x.next() }
+
+func (x orderedSeekFunc[T]) SeekGE(key T) { x.seekGE(key) }
+
+// OrderedCursor is a [Cursor] over an [OrderedIterator], which may skip
+// forward, using [OrderedCursor.SeekGE], whether or not the iterator
+// implements [OrderedSeeker], e.g. for [IntersectCursors].
+type OrderedCursor[T any] struct {
+	cmp    func(a, b T) int
+	it     OrderedIterator[T]
+	seekGE func(key T)
+	// buffered is the next element, read ahead by an emulated seek
+	buffered T
+	ok       bool
+	// floor is the last element returned, or key sought, if any, such that
+	// the remaining elements are greater than or equal to it
+	floor    T
+	hasFloor bool
+}
+
+var _ OrderedSeeker[any] = (*OrderedCursor[any])(nil)
+
+// NewOrderedCursor returns an [OrderedCursor] over `it`, which must be sorted
+// according to `cmp`.
+func NewOrderedCursor[T any](cmp func(a, b T) int, it OrderedIterator[T]) *OrderedCursor[T] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	if it == nil {
+		panic("kway: nil iterator")
+	}
+	x := &OrderedCursor[T]{cmp: cmp, it: it}
+	if s, ok := it.(OrderedSeeker[T]); ok {
+		x.seekGE = s.SeekGE
+	}
+	return x
+}
+
+// Next returns the next element of the iterator.
+func (x *OrderedCursor[T]) Next() (v T, ok bool) {
+	if x.ok {
+		v, ok = x.buffered, true
+		x.buffered, x.ok = *new(T), false
+	} else {
+		v, ok = x.it.Next()
+	}
+	if ok {
+		x.floor, x.hasFloor = v, true
+	}
+	return v, ok
+}
+
+// SeekGE positions the cursor before the first element greater than or
+// equal to key, using the iterator's SeekGE method, if any, or else by
+// reading ahead, element-at-a-time. Keys not exceeding the last element
+// returned, or any previous key, have no effect.
+func (x *OrderedCursor[T]) SeekGE(key T) {
+	if (x.ok && x.cmp(x.buffered, key) >= 0) || (x.hasFloor && x.cmp(x.floor, key) >= 0) {
+		return
+	}
+	x.floor, x.hasFloor = key, true
+	if x.seekGE != nil {
+		x.buffered, x.ok = *new(T), false
+		x.seekGE(key)
+		return
+	}
+	for {
+		v, ok := x.it.Next()
+		if !ok || x.cmp(v, key) >= 0 {
+			x.buffered, x.ok = v, ok
+			return
+		}
+	}
+}
+
+// All returns a sequence of the remaining elements, consuming the cursor.
+func (x *OrderedCursor[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v, ok := x.Next(); ok; v, ok = x.Next() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
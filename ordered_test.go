@@ -0,0 +1,164 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"testing"
+)
+
+// treeIter mimics the iterator of a third-party ordered container, with a
+// position-and-item style interface, like that of github.com/tidwall/btree.
+type treeIter struct {
+	items []int
+	i     int
+	seeks int
+}
+
+func (x *treeIter) Seek(key int) bool {
+	x.seeks++
+	x.i, _ = slices.BinarySearch(x.items, key)
+	x.i--
+	return x.i+1 < len(x.items)
+}
+
+func (x *treeIter) Next() bool {
+	x.i++
+	return x.i < len(x.items)
+}
+
+func (x *treeIter) Item() int { return x.items[x.i] }
+
+// sliceNext returns a function returning each element of s.
+func sliceNext(s []int) func() (int, bool) {
+	return func() (int, bool) {
+		if len(s) == 0 {
+			return 0, false
+		}
+		v := s[0]
+		s = s[1:]
+		return v, true
+	}
+}
+
+// adaptTree adapts it, per the documentation of OrderedIterator.
+func adaptTree(it *treeIter) OrderedIterator[int] {
+	it.i = -1
+	return OrderedFunc(func() (int, bool) {
+		if !it.Next() {
+			return 0, false
+		}
+		return it.Item(), true
+	}, func(key int) { it.Seek(key) })
+}
+
+// adaptAscend adapts a callback-based container, like github.com/google/btree,
+// restarting its iteration on each seek.
+func adaptAscend(items []int) OrderedIterator[int] {
+	ascend := func(pivot int, fn func(int) bool) {
+		i, _ := slices.BinarySearch(items, pivot)
+		for _, v := range items[i:] {
+			if !fn(v) {
+				return
+			}
+		}
+	}
+	seq := func(pivot int) iter.Seq[int] {
+		return func(yield func(int) bool) { ascend(pivot, yield) }
+	}
+	next, stop := iter.Pull(seq(items[0]))
+	return OrderedFunc(func() (int, bool) { return next() }, func(key int) {
+		stop()
+		next, stop = iter.Pull(seq(key))
+	})
+}
+
+func TestOrderedIterator_merge(t *testing.T) {
+	a := adaptTree(&treeIter{items: []int{1, 4, 7}})
+	b := adaptAscend([]int{2, 5, 8})
+	c := NewOrderedCursor(cmp.Compare[int], OrderedFunc(sliceNext([]int{3, 6}), nil))
+	expected := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	if result := collectSeq(MergeCursors[int](cmp.Compare[int], a, b, c)); !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestOrderedCursor(t *testing.T) {
+	tests := []struct {
+		name string
+		it   func([]int) OrderedIterator[int]
+	}{
+		{"seeker", func(s []int) OrderedIterator[int] { return adaptTree(&treeIter{items: s}) }},
+		{"emulated", func(s []int) OrderedIterator[int] {
+			return OrderedFunc(sliceNext(s), nil)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewOrderedCursor(cmp.Compare[int], tt.it([]int{1, 3, 5, 7, 9}))
+			var result []int
+			if v, ok := c.Next(); ok {
+				result = append(result, v)
+			}
+			c.SeekGE(4)
+			c.SeekGE(2)
+			if v, ok := c.Next(); ok {
+				result = append(result, v)
+			}
+			c.SeekGE(8)
+			result = append(result, collectSeq(c.All())...)
+			if !slices.Equal(result, []int{1, 5, 9}) {
+				t.Errorf("Expected [1 5 9], got %v", result)
+			}
+			c.SeekGE(10)
+			if v, ok := c.Next(); ok {
+				t.Errorf("Expected no element, got %d", v)
+			}
+		})
+	}
+}
+
+func TestOrderedCursor_intersect(t *testing.T) {
+	large := make([]int, 1<<16)
+	for i := range large {
+		large[i] = i * 2
+	}
+	tree := &treeIter{items: large}
+	result := collectSeq(IntersectCursors(cmp.Compare[int],
+		NewOrderedCursor(cmp.Compare[int], adaptTree(tree)),
+		NewSortedSlice(cmp.Compare[int], []int{3, 10, 50000}).Cursor(),
+	))
+	if !slices.Equal(result, []int{10, 50000}) {
+		t.Errorf("Expected [10 50000], got %v", result)
+	}
+	if tree.seeks == 0 || tree.seeks > 3 {
+		t.Errorf("Expected at most 3 seeks, got %d", tree.seeks)
+	}
+
+	plain := NewOrderedCursor(cmp.Compare[int], OrderedFunc(sliceNext([]int{1, 2, 3, 4}), nil))
+	if result := collectSeq(IntersectCursors[int](cmp.Compare[int], plain, OrderedFunc(sliceNext([]int{2, 4}), nil))); !slices.Equal(result, []int{2, 4}) {
+		t.Errorf("Expected [2 4], got %v", result)
+	}
+}
+
+func TestOrderedCursor_panics(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		f     func()
+		panic string
+	}{
+		{"nil next", func() { OrderedFunc[int](nil, nil) }, "kway: nil next function"},
+		{"nil cmp", func() { NewOrderedCursor[int](nil, OrderedFunc(sliceNext([]int{}), nil)) }, "kway: nil comparison function"},
+		{"nil iterator", func() { NewOrderedCursor[int](cmp.Compare[int], nil) }, "kway: nil iterator"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tt.panic {
+					t.Errorf("Expected %q, got %v", tt.panic, r)
+				}
+			}()
+			tt.f()
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package kway
+
+import (
+	"iter"
+)
+
+// Pair is a key-value pair, allowing the elements of an [iter.Seq2] to be
+// used as the elements of an [iter.Seq], e.g. to merge using [Merge], rather
+// than [Merge2]. See [PairsOf] and [FromPairs].
+type Pair[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// PairsOf returns a sequence of the pairs yielded by `seq`.
+func PairsOf[K any, V any](seq iter.Seq2[K, V]) iter.Seq[Pair[K, V]] {
+	if seq == nil {
+		return nil
+	}
+	return func(yield func(Pair[K, V]) bool) {
+		for k, v := range seq {
+			if !yield(Pair[K, V]{k, v}) {
+				return
+			}
+		}
+	}
+}
+
+// FromPairs returns a sequence of the keys and values of the pairs yielded
+// by `seq`, the inverse of [PairsOf].
+func FromPairs[K any, V any](seq iter.Seq[Pair[K, V]]) iter.Seq2[K, V] {
+	if seq == nil {
+		return nil
+	}
+	return func(yield func(K, V) bool) {
+		for p := range seq {
+			if !yield(p.Key, p.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns a sequence of the keys yielded by `seq`, e.g. to project the
+// output of [Merge2]. Like the other adapters, [Values] and [Flip], it calls
+// `seq` directly, with an adapted yield function, rather than ranging over
+// it, adding no allocations per element, and minimal overhead.
+func Keys[K any, V any](seq iter.Seq2[K, V]) iter.Seq[K] {
+	if seq == nil {
+		return nil
+	}
+	return func(yield func(K) bool) {
+		seq(func(k K, _ V) bool { return yield(k) })
+	}
+}
+
+// Values returns a sequence of the values yielded by `seq`, see [Keys].
+func Values[K any, V any](seq iter.Seq2[K, V]) iter.Seq[V] {
+	if seq == nil {
+		return nil
+	}
+	return func(yield func(V) bool) {
+		seq(func(_ K, v V) bool { return yield(v) })
+	}
+}
+
+// Flip returns a sequence of the pairs yielded by `seq`, with each key and
+// value swapped, see [Keys].
+func Flip[K any, V any](seq iter.Seq2[K, V]) iter.Seq2[V, K] {
+	if seq == nil {
+		return nil
+	}
+	return func(yield func(V, K) bool) {
+		seq(func(k K, v V) bool { return yield(v, k) })
+	}
+}
+
+// PairCompare adapts a comparison function for [Merge2] to compare pairs,
+// for use with [Merge] and [PairsOf].
+func PairCompare[K any, V any](cmp func(a1 K, a2 V, b1 K, b2 V) int) func(a, b Pair[K, V]) int {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	return func(a, b Pair[K, V]) int {
+		return cmp(a.Key, a.Value, b.Key, b.Value)
+	}
+}
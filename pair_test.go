@@ -0,0 +1,128 @@
+package kway
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestPairsOf(t *testing.T) {
+	pairs := collectSeq(PairsOf(sliceSeq2([]string{"a", "b"}, []int{1, 2})))
+	expected := []Pair[string, int]{{"a", 1}, {"b", 2}}
+	if !slices.Equal(pairs, expected) {
+		t.Errorf("Expected %v, got %v", expected, pairs)
+	}
+
+	keys, values := collectSeq2(FromPairs(sliceSeq(expected)))
+	if !slices.Equal(keys, []string{"a", "b"}) || !slices.Equal(values, []int{1, 2}) {
+		t.Errorf("Expected [a b] [1 2], got %v %v", keys, values)
+	}
+
+	if PairsOf[string, int](nil) != nil || FromPairs[string, int](nil) != nil {
+		t.Error("Expected nil sequences to be preserved")
+	}
+}
+
+func TestPairsOf_EarlyTermination(t *testing.T) {
+	for range PairsOf(sliceSeq2([]int{1, 2}, []int{1, 2})) {
+		break
+	}
+	for range FromPairs(sliceSeq([]Pair[int, int]{{1, 1}, {2, 2}})) {
+		break
+	}
+}
+
+func TestPairCompare(t *testing.T) {
+	cmpFunc := func(a1 string, a2 int, b1 string, b2 int) int { return cmp.Compare(a1, b1) }
+	a := sliceSeq2([]string{"a", "c"}, []int{1, 3})
+	b := sliceSeq2([]string{"b", "c"}, []int{2, 30})
+
+	expectedKeys, expectedValues := collectSeq2(Merge2(cmpFunc, a, b))
+	keys, values := collectSeq2(FromPairs(Merge(PairCompare(cmpFunc), PairsOf(a), PairsOf(b))))
+	if !slices.Equal(keys, expectedKeys) || !slices.Equal(values, expectedValues) {
+		t.Errorf("Expected %v %v, got %v %v", expectedKeys, expectedValues, keys, values)
+	}
+}
+
+func TestPairCompare_NilCompareFunction(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil comparison function")
+		}
+	}()
+	PairCompare[int, int](nil)
+}
+
+func TestKeysValuesFlip(t *testing.T) {
+	merged := Merge2(func(a1 string, _ int, b1 string, _ int) int { return cmp.Compare(a1, b1) },
+		sliceSeq2([]string{"a", "c"}, []int{1, 3}),
+		sliceSeq2([]string{"b"}, []int{2}),
+	)
+	if v := collectSeq(Keys(merged)); !slices.Equal(v, []string{"a", "b", "c"}) {
+		t.Errorf("Expected [a b c], got %v", v)
+	}
+	if v := collectSeq(Values(merged)); !slices.Equal(v, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", v)
+	}
+	var flipped []Pair[int, string]
+	for v, k := range Flip(merged) {
+		flipped = append(flipped, Pair[int, string]{v, k})
+	}
+	if expected := []Pair[int, string]{{1, "a"}, {2, "b"}, {3, "c"}}; !slices.Equal(flipped, expected) {
+		t.Errorf("Expected %v, got %v", expected, flipped)
+	}
+	if Keys[int, int](nil) != nil || Values[int, int](nil) != nil || Flip[int, int](nil) != nil {
+		t.Error("Expected nil sequences to be preserved")
+	}
+}
+
+func TestKeysValuesFlip_EarlyTermination(t *testing.T) {
+	seq := sliceSeq2([]string{"a", "b", "c"}, []int{1, 2, 3})
+	var keys []string
+	for k := range Keys(seq) {
+		keys = append(keys, k)
+		break
+	}
+	var values []int
+	for v := range Values(seq) {
+		values = append(values, v)
+		break
+	}
+	var flipped []int
+	for v := range Flip(seq) {
+		flipped = append(flipped, v)
+		break
+	}
+	if !slices.Equal(keys, []string{"a"}) || !slices.Equal(values, []int{1}) || !slices.Equal(flipped, []int{1}) {
+		t.Errorf("Unexpected results: %v, %v, %v", keys, values, flipped)
+	}
+}
+
+func BenchmarkKeys(b *testing.B) {
+	input := make([]int, 1000)
+	for i := range input {
+		input[i] = i
+	}
+	seq := sliceSeq2(input, input)
+	b.Run("Keys", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			for range Keys(seq) {
+			}
+		}
+	})
+	b.Run("range", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			keys := func(yield func(int) bool) {
+				for k := range seq {
+					if !yield(k) {
+						return
+					}
+				}
+			}
+			for range keys {
+			}
+		}
+	})
+}
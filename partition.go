@@ -0,0 +1,269 @@
+package kway
+
+import (
+	"iter"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// WithParallelism configures the maximum number of partitions merged
+// concurrently, by [Merger.MergePartitioned]. Defaults to
+// [runtime.GOMAXPROCS].
+func WithParallelism(n int) Option {
+	if n <= 0 {
+		panic("kway: parallelism must be positive")
+	}
+	return func(o *options) {
+		o.parallelism = n
+	}
+}
+
+// MergePartitioned performs a k-way merge of the provided sorted sources, per
+// [Merger.Merge], by splitting the key space into ranges, merging each range
+// concurrently, then concatenating the results. The output is identical to
+// merging the sources directly.
+//
+// The `bounds` must be sorted, and split the key space into len(bounds)+1
+// ranges: the elements less than bounds[0], the elements greater than or
+// equal to bounds[0] and less than bounds[1], and so on, with the final range
+// being the elements greater than or equal to the last bound. See
+// [SampleBounds] to derive bounds from a sample of the data. Each range is
+// read from the sources using [Seekable.Seek], and must fit in memory.
+//
+// At most [WithParallelism] ranges are merged or buffered at once, with
+// results yielded in order, as they become available. Options that observe
+// individual merges, i.e. [WithStats], [WithTrace] and [WithSourceHooks],
+// are not applied. If configured, using [WithLimiter], a slot is also held
+// for each range that is being merged or buffered.
+//
+// If configured, using [WithMaxBuffered], fewer ranges are merged
+// concurrently, such that the elements held by each merge fit within the
+// limit, with the remainder bounding the elements of the ranges buffered at
+// once, beyond which the merge panics with a [*BufferLimitError].
+//
+// Panics, e.g. from [WithVerifySorted], are propagated to the goroutine
+// iterating the returned sequence.
+func (m *Merger[T]) MergePartitioned(bounds []T, srcs ...Seekable[T]) iter.Seq[T] {
+	if !slices.IsSortedFunc(bounds, m.cmp) {
+		panic("kway: partition bounds must be sorted")
+	}
+	workers := m.opts.parallelism
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	// the merger used for each partition
+	partition := *m
+	partition.opts.stats = nil
+	partition.opts.trace = nil
+	partition.opts.progress = nil
+	partition.opts.progressEvery = 0
+	partition.opts.hooks = SourceHooks{}
+	partition.summary = nil
+
+	// the elements that may be buffered by ranges, if limited
+	var budget int64
+	limit := m.opts.maxBuffered
+	var limitErr error
+	if limit > 0 {
+		fixed := partition.opts.fixedBuffered(countSeekables(srcs), partition.opts.verifySorted)
+		if fixed > limit {
+			limitErr = &BufferLimitError{Limit: limit, Required: fixed}
+		}
+		workers = max(1, min(workers, limit/max(fixed, 1)))
+		budget = int64(limit - workers*fixed)
+		partition.opts.maxBuffered = 0
+		partition.opts.yieldBatch = 0
+	}
+
+	type result struct {
+		values   []T
+		panicked bool
+		err      any
+	}
+
+	return func(yield func(T) bool) {
+		if limitErr != nil {
+			panic(limitErr)
+		}
+		results := make([]chan result, len(bounds)+1)
+		for i := range results {
+			results[i] = make(chan result, 1)
+		}
+		var (
+			stopped  atomic.Bool
+			buffered atomic.Int64 // elements held by results, if limited
+			wg       sync.WaitGroup
+			sem      = make(chan struct{}, workers)
+			done     = make(chan struct{})
+			limiter  = m.opts.limiter
+			acquired int // limiter slots, written by the dispatcher
+			released int
+		)
+		if limiter != nil {
+			// runs after wg.Wait
+			defer func() { limiter.release(acquired - released) }()
+		}
+		defer wg.Wait()
+		defer close(done)
+		defer stopped.Store(true)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range results {
+				select {
+				case sem <- struct{}{}:
+				case <-done:
+					return
+				}
+				if limiter != nil {
+					if !limiter.acquire(done, 1) {
+						return
+					}
+					acquired++
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					var r result
+					defer func() {
+						if v := recover(); v != nil {
+							r.panicked, r.err = true, v
+						}
+						results[p] <- r
+					}()
+					sources := make([]pullSource[T], len(srcs))
+					for i, src := range srcs {
+						if src != nil {
+							sources[i] = m.partitionSource(src, bounds, p)
+						}
+					}
+					for v := range partition.merge(sources, partition.opts.verifySorted, panicError) {
+						if stopped.Load() {
+							break
+						}
+						if limit > 0 && buffered.Add(1) > budget {
+							panic(&BufferLimitError{Limit: limit, Required: limit - int(budget) + int(buffered.Load())})
+						}
+						r.values = append(r.values, v.v)
+					}
+				}()
+			}
+		}()
+
+		if m.summary != nil {
+			m.summary.reset()
+		}
+		var emitted int64
+		for _, ch := range results {
+			r := <-ch
+			<-sem
+			if limiter != nil {
+				limiter.release(1)
+				released++
+			}
+			if r.panicked {
+				panic(r.err)
+			}
+			for _, v := range r.values {
+				if m.summary != nil {
+					m.summary.add(v)
+				}
+				if !yield(v) {
+					return
+				}
+				emitted++
+				if m.opts.progress != nil && emitted%m.opts.progressEvery == 0 {
+					m.opts.progress(emitted)
+				}
+			}
+			if limit > 0 {
+				buffered.Add(-int64(len(r.values)))
+			}
+		}
+	}
+}
+
+// partitionSource returns the elements of src within the partition p, using a
+// cursor, if src is a [SortedSlice].
+func (m *Merger[T]) partitionSource(src Seekable[T], bounds []T, p int) pullSource[T] {
+	s, ok := src.(*SortedSlice[T])
+	if !ok {
+		return seqSource(m.partitionSeq(src, bounds, p))
+	}
+	lo, hi := 0, len(s.s)
+	if p != 0 {
+		lo, _ = slices.BinarySearchFunc(s.s, bounds[p-1], m.cmp)
+	}
+	if p != len(bounds) {
+		hi, _ = slices.BinarySearchFunc(s.s, bounds[p], m.cmp)
+	}
+	return cursorSource[T](&SliceCursor[T]{cmp: s.cmp, s: s.s[lo:hi]})
+}
+
+// partitionSeq returns the elements of src within the partition p. As src
+// seeks per its own comparison function, the elements are also compared
+// against both bounds of the partition.
+func (m *Merger[T]) partitionSeq(src Seekable[T], bounds []T, p int) iter.Seq[T] {
+	if p == 0 {
+		if p == len(bounds) {
+			return src.All()
+		}
+		return m.partitionRange(src.All(), nil, &bounds[p])
+	}
+	if p == len(bounds) {
+		return m.partitionRange(src.Seek(bounds[p-1]), &bounds[p-1], nil)
+	}
+	return m.partitionRange(src.Seek(bounds[p-1]), &bounds[p-1], &bounds[p])
+}
+
+// partitionRange returns the elements of seq within [lo, hi), where a nil
+// bound is unbounded.
+func (m *Merger[T]) partitionRange(seq iter.Seq[T], lo, hi *T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		skip := lo != nil
+		for v := range seq {
+			if skip {
+				if m.cmp(v, *lo) < 0 {
+					continue
+				}
+				skip = false
+			}
+			if hi != nil && m.cmp(v, *hi) >= 0 || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// MergePartitioned performs a k-way merge of the provided sorted sources,
+// merging ranges of the key space concurrently. It is equivalent to
+// [Merger.MergePartitioned], using a [Merger] without options.
+func MergePartitioned[T any](cmp func(a, b T) int, bounds []T, srcs ...Seekable[T]) iter.Seq[T] {
+	return NewMerger(cmp).MergePartitioned(bounds, srcs...)
+}
+
+// SampleBounds returns up to n-1 bounds, splitting the key space into n
+// ranges, of approximately equal size, based on a sample of the data, for
+// use with [Merger.MergePartitioned]. The sample is not modified. Duplicate
+// bounds are omitted.
+func SampleBounds[T any](cmp func(a, b T) int, n int, sample []T) []T {
+	if n <= 0 {
+		panic("kway: partition count must be positive")
+	}
+	if len(sample) == 0 {
+		return nil
+	}
+	sorted := slices.SortedStableFunc(slices.Values(sample), cmp)
+	bounds := make([]T, 0, n-1)
+	for i := 1; i < n; i++ {
+		v := sorted[i*len(sorted)/n]
+		if len(bounds) == 0 || cmp(bounds[len(bounds)-1], v) != 0 {
+			bounds = append(bounds, v)
+		}
+	}
+	return bounds
+}
@@ -0,0 +1,144 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestMergePartitioned(t *testing.T) {
+	type stableValue struct {
+		value int
+		seqID int
+	}
+	cmpFunc := func(a, b stableValue) int { return cmp.Compare(a.value, b.value) }
+
+	var (
+		srcs []Seekable[stableValue]
+		seqs []iter.Seq[stableValue]
+	)
+	for i := range 5 {
+		var s []stableValue
+		for j := 0; j < 200; j++ {
+			s = append(s, stableValue{j * (i + 1) / 3, i})
+		}
+		var src Seekable[stableValue] = NewSortedSlice(cmpFunc, s)
+		if i%2 != 0 {
+			// hide the concrete type, to exercise the generic path
+			src = struct{ Seekable[stableValue] }{src}
+		}
+		srcs = append(srcs, src)
+		seqs = append(seqs, slices.Values(s))
+	}
+	srcs = append(srcs, nil)
+	seqs = append(seqs, nil)
+
+	expected := collectSeq(Merge(cmpFunc, seqs...))
+
+	for _, bounds := range [][]stableValue{
+		nil,
+		{{100, 0}},
+		{{10, 0}, {50, 0}, {50, 0}, {200, 0}, {1000, 0}},
+		SampleBounds(cmpFunc, 7, expected),
+	} {
+		for _, parallelism := range []int{1, 2, 8} {
+			m := NewMerger(cmpFunc, WithParallelism(parallelism))
+			if result := collectSeq(m.MergePartitioned(bounds, srcs...)); !slices.Equal(result, expected) {
+				t.Errorf("Bounds %v, parallelism %d: unexpected result", bounds, parallelism)
+			}
+		}
+	}
+}
+
+func TestMergePartitioned_EarlyTermination(t *testing.T) {
+	src := NewSortedSlice(cmp.Compare[int], []int{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	var result []int
+	for v := range MergePartitioned(cmp.Compare[int], []int{3, 6}, src, src) {
+		result = append(result, v)
+		if len(result) == 7 {
+			break
+		}
+	}
+	if !slices.Equal(result, []int{1, 1, 2, 2, 3, 3, 4}) {
+		t.Errorf("Unexpected result: %v", result)
+	}
+}
+
+func TestMergePartitioned_Panic(t *testing.T) {
+	m := NewMerger(cmp.Compare[int], WithVerifySorted(), WithParallelism(2))
+	unsorted := NewSortedSlice(cmp.Compare[int], []int{1, 5, 2, 8, 9})
+	defer func() {
+		if _, ok := recover().(*OrderError); !ok {
+			t.Error("Expected panic with *OrderError")
+		}
+	}()
+	for range m.MergePartitioned([]int{7}, unsorted) {
+	}
+}
+
+func TestMergePartitioned_Progress(t *testing.T) {
+	var calls []int64
+	m := NewMerger(cmp.Compare[int], WithProgress(3, func(n int64) { calls = append(calls, n) }))
+	src := NewSortedSlice(cmp.Compare[int], []int{1, 2, 3, 4, 5, 6, 7})
+	_ = collectSeq(m.MergePartitioned([]int{4}, src))
+	if !slices.Equal(calls, []int64{3, 6}) {
+		t.Errorf("Expected [3 6], got %v", calls)
+	}
+}
+
+func TestMergePartitioned_SourceComparison(t *testing.T) {
+	// the sources compare by key, coarser than the merger, which compares by
+	// key then seq, such that each bound splits a run of equal keys
+	type record struct{ key, seq int }
+	byKey := func(a, b record) int { return cmp.Compare(a.key, b.key) }
+	byKeySeq := func(a, b record) int {
+		if v := cmp.Compare(a.key, b.key); v != 0 {
+			return v
+		}
+		return cmp.Compare(a.seq, b.seq)
+	}
+	var s []record
+	for key := range 3 {
+		for seq := range 4 {
+			s = append(s, record{key, seq})
+		}
+	}
+	bounds := []record{{1, 2}, {2, 1}}
+	for _, src := range []Seekable[record]{
+		NewSortedSlice(byKey, s),
+		struct{ Seekable[record] }{NewSortedSlice(byKey, s)},
+	} {
+		if result := collectSeq(NewMerger(byKeySeq).MergePartitioned(bounds, src)); !slices.Equal(result, s) {
+			t.Errorf("Expected %v, got %v", s, result)
+		}
+	}
+}
+
+func TestMergePartitioned_UnsortedBounds(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for unsorted bounds")
+		}
+	}()
+	_ = MergePartitioned(cmp.Compare[int], []int{2, 1})
+}
+
+func TestSampleBounds(t *testing.T) {
+	sample := []int{9, 1, 8, 2, 7, 3, 6, 4, 5, 0}
+	if v := SampleBounds(cmp.Compare[int], 5, sample); !slices.Equal(v, []int{2, 4, 6, 8}) {
+		t.Errorf("Expected [2 4 6 8], got %v", v)
+	}
+	if !slices.Equal(sample, []int{9, 1, 8, 2, 7, 3, 6, 4, 5, 0}) {
+		t.Error("Expected sample to be unmodified")
+	}
+	if v := SampleBounds(cmp.Compare[int], 4, []int{1, 1, 1, 2}); !slices.Equal(v, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", v)
+	}
+	if v := SampleBounds(cmp.Compare[int], 1, sample); len(v) != 0 {
+		t.Errorf("Expected no bounds, got %v", v)
+	}
+	if v := SampleBounds(cmp.Compare[int], 3, nil); v != nil {
+		t.Errorf("Expected nil, got %v", v)
+	}
+}
@@ -0,0 +1,49 @@
+package kway
+
+import (
+	"iter"
+)
+
+// WithMaxPerKey limits the output of the Merger's merges to the first `n`
+// elements of each distinct key, i.e. run of elements comparing equal,
+// according to the primary comparison function, skipping the rest, without
+// yielding them. A value of 0 disables the limit.
+//
+// The elements of each key are yielded in merged order, i.e. by any
+// [WithTieBreak] comparison, then by source, such that, e.g., the latest `n`
+// versions of each key may be retained, when compacting records sorted by
+// key, using a tie-break ordering versions from newest to oldest. Skipped
+// elements are not counted as yielded by [WithStats], nor recorded by
+// [WithRecording].
+func WithMaxPerKey(n int) Option {
+	if n < 0 {
+		panic("kway: negative max per key")
+	}
+	return func(o *options) {
+		o.maxPerKey = n
+	}
+}
+
+// limitPerKey returns seq, skipping elements after the first n of each run
+// of equal elements.
+func limitPerKey[E any](seq iter.Seq[E], equal func(a, b E) bool, n int) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		var (
+			prev  E
+			count int
+		)
+		for v := range seq {
+			if count != 0 && equal(prev, v) {
+				if count == n {
+					continue
+				}
+				count++
+			} else {
+				prev, count = v, 1
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
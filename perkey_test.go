@@ -0,0 +1,79 @@
+package kway
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestWithMaxPerKey(t *testing.T) {
+	type version struct {
+		key     string
+		version int
+	}
+	byKey := func(a, b version) int { return cmp.Compare(a.key, b.key) }
+	newest := func(a, b version) int { return cmp.Compare(b.version, a.version) }
+	a := []version{{"a", 5}, {"a", 3}, {"b", 1}, {"c", 9}, {"c", 8}, {"c", 7}}
+	b := []version{{"a", 4}, {"b", 2}, {"c", 6}, {"d", 1}}
+	for _, tc := range []struct {
+		n        int
+		expected []version
+	}{
+		{1, []version{{"a", 5}, {"b", 2}, {"c", 9}, {"d", 1}}},
+		{2, []version{{"a", 5}, {"a", 4}, {"b", 2}, {"b", 1}, {"c", 9}, {"c", 8}, {"d", 1}}},
+		{0, []version{{"a", 5}, {"a", 4}, {"a", 3}, {"b", 2}, {"b", 1}, {"c", 9}, {"c", 8}, {"c", 7}, {"c", 6}, {"d", 1}}},
+	} {
+		var stats Stats
+		m := NewMerger(byKey, WithTieBreak(newest), WithMaxPerKey(tc.n), WithStats(&stats))
+		actual := collectSeq(m.Merge(slices.Values(a), slices.Values(b)))
+		if !slices.Equal(actual, tc.expected) {
+			t.Errorf("Limit %d: expected %v, got %v", tc.n, tc.expected, actual)
+		}
+		if stats.Yielded != int64(len(tc.expected)) {
+			t.Errorf("Limit %d: expected %d yielded, got %d", tc.n, len(tc.expected), stats.Yielded)
+		}
+	}
+}
+
+func TestWithMaxPerKey_Stable(t *testing.T) {
+	// without a tie-break, elements of each key are ordered by source
+	m := NewMerger(func(a, b [2]int) int { return cmp.Compare(a[0], b[0]) }, WithMaxPerKey(2))
+	actual := collectSeq(m.Merge(slices.Values([][2]int{{1, 0}, {2, 0}}), slices.Values([][2]int{{1, 1}, {1, 1}, {2, 1}}), slices.Values([][2]int{{2, 2}})))
+	if expected := [][2]int{{1, 0}, {1, 1}, {2, 0}, {2, 1}}; !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+}
+
+func TestWithMaxPerKey_Merger2(t *testing.T) {
+	m := NewMerger2(func(a1, a2, b1, b2 int) int { return cmp.Compare(a2, b2) }, WithMaxPerKey(1))
+	var actual []int
+	for i, v := range m.Merge(slices.All([]int{1, 1, 2}), slices.All([]int{1, 3})) {
+		actual = append(actual, i, v)
+	}
+	if expected := []int{0, 1, 2, 2, 1, 3}; !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+}
+
+func TestWithMaxPerKey_Negative(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "kway: negative max per key" {
+			t.Errorf("Expected panic, got %v", r)
+		}
+	}()
+	WithMaxPerKey(-1)
+}
+
+func TestLimitPerKey_EarlyTermination(t *testing.T) {
+	seq := limitPerKey(slices.Values([]int{1, 1, 1, 2, 2, 3}), func(a, b int) bool { return a == b }, 1)
+	var actual []int
+	for v := range seq {
+		actual = append(actual, v)
+		if v == 2 {
+			break
+		}
+	}
+	if expected := []int{1, 2}; !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+}
@@ -0,0 +1,174 @@
+package kway
+
+import (
+	"iter"
+)
+
+// wrappedValue is implemented by the wrapped element types used internally.
+type wrappedValue interface {
+	// index is the index of the source sequence
+	index() int
+	// key is the value reported as the key, by errors
+	key() any
+}
+
+// failure reports violations detected during a merge.
+type failure struct {
+	fail   func(err error)
+	failed bool
+}
+
+// report passes err to the fail function, which may panic. If it returns, no
+// further elements will be yielded by the merge.
+func (x *failure) report(err error) {
+	x.failed = true
+	x.fail(err)
+}
+
+// pullSource opens a source for a single merge, returning its next function,
+// and a stop function, which may be nil.
+type pullSource[E any] func() (next func() (E, bool), stop func())
+
+// seqSource returns a pullSource for seq, pulled via [iter.Pull].
+func seqSource[T any](seq iter.Seq[T]) pullSource[T] {
+	return func() (func() (T, bool), func()) { return iter.Pull(seq) }
+}
+
+// cursorSource returns a pullSource for c, which requires no coroutine.
+func cursorSource[T any](c Cursor[T]) pullSource[T] {
+	return func() (func() (T, bool), func()) { return c.Next, nil }
+}
+
+// mergePipeline merges the wrapped sources, applying the options which do
+// not depend on the element type. The returned sequence must be iterated at
+// most once. Violations must be reported via f. The buffers, buf, which may
+// be nil, are used by the merge, and released once it completes. The
+// summary, which may be nil, accumulates the elements yielded.
+func mergePipeline[E wrappedValue](o *options, cmp func(a, b E) int, equal func(a, b E) bool, srcs []pullSource[E], f *failure, buf *mergeBuffers[E], summary summarizer[E]) iter.Seq[E] {
+	if o.priority != nil {
+		cmp = priorityCompare(o.priority, cmp)
+	}
+
+	stats := o.stats
+	if stats != nil {
+		*stats = Stats{Sources: make([]SourceStats, len(srcs))}
+		cmp = statsCompare(stats, cmp)
+	}
+
+	metrics := o.metrics
+	if metrics != nil {
+		fail := f.fail
+		f.fail = func(err error) {
+			metrics.Errors.Add(1)
+			fail(err)
+		}
+	}
+
+	trace := o.trace
+	if trace != nil {
+		cmp = traceCompare(trace, cmp)
+	}
+
+	hooks := o.hooks
+	out := func(yield func(E) bool) {
+		if buf == nil {
+			buf = new(mergeBuffers[E])
+		}
+		// released after the sources are stopped
+		defer buf.release()
+		pulls := reuse(buf.pulls, len(srcs))
+		buf.pulls = pulls
+		for i, src := range srcs {
+			if src == nil {
+				continue
+			}
+			next, stop := src()
+			if hooks.OnStop != nil {
+				// runs after stop
+				defer hooks.OnStop(i)
+			}
+			if stop != nil {
+				defer stop()
+			}
+			if hooks.OnOpen != nil {
+				hooks.OnOpen(i)
+			}
+			if hooks.OnExhausted != nil {
+				next = exhaustedNext(hooks.OnExhausted, i, next)
+			}
+			if stats != nil {
+				next = statsNext(stats, &stats.Sources[i], next)
+			}
+			if metrics != nil {
+				next = metricsNext(metrics, next)
+			}
+			if trace != nil {
+				next = traceNext(trace, i, next)
+			}
+			pulls[i] = next
+		}
+		state := mergeState[E]{cmp: cmp, strategy: o.strategy, items: buf.items, nodes: buf.nodes, done: buf.done}
+		if stats != nil {
+			state.moves = &stats.Moves
+		}
+		state.merge(pulls, yield)
+		buf.items, buf.nodes, buf.done = state.items, state.nodes, state.done
+	}
+
+	if trace != nil {
+		out = tracePop(trace, out)
+	}
+
+	if o.uniqueKeys {
+		out = checkUnique(out, equal, E.key, f.report)
+	}
+
+	if o.maxPerKey > 0 {
+		out = limitPerKey(out, equal, o.maxPerKey)
+	}
+
+	progress, progressEvery := o.progress, o.progressEvery
+	recording := o.recording
+	merged := func(yield func(E) bool) {
+		if recording != nil {
+			recording.reset()
+		}
+		if summary != nil {
+			summary.reset()
+		}
+		if metrics != nil {
+			metrics.Active.Add(1)
+			defer metrics.Active.Add(-1)
+		}
+		var emitted int64
+		for v := range out {
+			if f.failed {
+				return
+			}
+			if stats != nil {
+				stats.Yielded++
+				stats.Sources[v.index()].Yielded++
+			}
+			if metrics != nil {
+				metrics.Merged.Add(1)
+			}
+			if recording != nil {
+				recording.add(v.index())
+			}
+			if summary != nil {
+				summary.add(v)
+			}
+			if !yield(v) {
+				return
+			}
+			emitted++
+			if progress != nil && emitted%progressEvery == 0 {
+				progress(emitted)
+			}
+		}
+	}
+	if o.yieldBatch > 1 {
+		return batchYield(merged, o.yieldBatch)
+	}
+	return merged
+}
@@ -0,0 +1,205 @@
+package kway
+
+import (
+	"iter"
+	"math/bits"
+	"sync/atomic"
+)
+
+// WithPrefetch enables read-ahead of each input sequence, by up to `n`
+// elements, each sequence being iterated by its own goroutine. This allows
+// slow sources, such as those backed by the network, to make progress
+// concurrently with the merge. A value of 0 disables prefetching.
+//
+// Prefetching applies to the sequences passed to [Merger.Merge],
+// [Merger.MergeChecked] and [Merger2.Merge]. If configured, using
+// [WithLimiter], a slot is acquired for each sequence, up to the size of the
+// limiter, at the start of each iteration. Panics from prefetched sequences
+// are propagated to the goroutine iterating the merge.
+func WithPrefetch(n int) Option {
+	if n < 0 {
+		panic("kway: negative prefetch size")
+	}
+	return func(o *options) {
+		o.prefetch = n
+	}
+}
+
+// prefetchSources returns the sources for seqs, prefetched if configured,
+// and a function to release any limiter slots, to be called after the merge.
+func prefetchSources[T any](o *options, seqs []iter.Seq[T]) ([]pullSource[T], func()) {
+	if o.prefetch <= 0 {
+		return seqSources(seqs), func() {}
+	}
+	srcs := make([]pullSource[T], len(seqs))
+	var n int
+	for i, seq := range seqs {
+		if seq != nil {
+			srcs[i] = prefetchSource(seq, o.prefetch)
+			n++
+		}
+	}
+	return srcs, o.acquirePrefetch(n)
+}
+
+// acquirePrefetch acquires a limiter slot for each of n prefetched
+// sequences, if configured, returning a function to release them. At most
+// every slot is acquired.
+func (o *options) acquirePrefetch(n int) func() {
+	limiter := o.limiter
+	if limiter == nil || o.prefetch <= 0 {
+		return func() {}
+	}
+	n = min(n, limiter.size)
+	limiter.acquire(nil, n)
+	return func() { limiter.release(n) }
+}
+
+// prefetchSource returns a pullSource that iterates seq from a separate
+// goroutine, buffering up to size elements, in a spscRing.
+func prefetchSource[T any](seq iter.Seq[T], size int) pullSource[T] {
+	return func() (func() (T, bool), func()) {
+		r := newSPSCRing[T](size)
+		go r.produce(seq)
+		return r.pop, r.stop
+	}
+}
+
+// prefetchPull2 is the [iter.Seq2] equivalent of prefetchSource, returning
+// the opened source.
+func prefetchPull2[T1 any, T2 any](seq iter.Seq2[T1, T2], size int) (func() (T1, T2, bool), func()) {
+	next, stop := prefetchSource(PairsOf(seq), size)()
+	return func() (T1, T2, bool) {
+		v, ok := next()
+		return v.Key, v.Value, ok
+	}, stop
+}
+
+// spscRing is a lock-free single-producer/single-consumer ring buffer. The
+// producer and consumer only block, using the signal channels, when the ring
+// is full or empty, respectively, and only signal the other side if it is
+// waiting.
+type spscRing[T any] struct {
+	buf  []T
+	mask uint64
+	// head is the index of the next element to pop, written by the consumer
+	head atomic.Uint64
+	// tail is the index of the next element to push, written by the producer
+	tail atomic.Uint64
+
+	producerWaiting atomic.Bool
+	consumerWaiting atomic.Bool
+	// notFull and notEmpty wake the producer and consumer, respectively
+	notFull  chan struct{}
+	notEmpty chan struct{}
+
+	// closed is set by the producer, after its final push
+	closed atomic.Bool
+	// stopped is set by the consumer, to stop the producer
+	stopped atomic.Bool
+	// done is closed once the producer has returned
+	done chan struct{}
+
+	panicked bool
+	panicVal any
+}
+
+func newSPSCRing[T any](size int) *spscRing[T] {
+	if size < 1 {
+		size = 1
+	}
+	n := uint64(1) << bits.Len(uint(size-1))
+	return &spscRing[T]{
+		buf:      make([]T, n),
+		mask:     n - 1,
+		notFull:  make(chan struct{}, 1),
+		notEmpty: make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+}
+
+// produce pushes the elements of seq, until it ends, or the consumer stops.
+func (r *spscRing[T]) produce(seq iter.Seq[T]) {
+	defer close(r.done)
+	defer func() {
+		if v := recover(); v != nil {
+			r.panicked, r.panicVal = true, v
+		}
+		r.closed.Store(true)
+		signal(r.notEmpty)
+	}()
+	for v := range seq {
+		if !r.push(v) {
+			return
+		}
+	}
+}
+
+func (r *spscRing[T]) push(v T) bool {
+	size := uint64(len(r.buf))
+	for {
+		if r.stopped.Load() {
+			return false
+		}
+		t := r.tail.Load()
+		if t-r.head.Load() < size {
+			r.buf[t&r.mask] = v
+			r.tail.Store(t + 1)
+			if r.consumerWaiting.Load() {
+				signal(r.notEmpty)
+			}
+			return true
+		}
+		r.producerWaiting.Store(true)
+		if t-r.head.Load() == size && !r.stopped.Load() {
+			<-r.notFull
+		}
+		r.producerWaiting.Store(false)
+	}
+}
+
+func (r *spscRing[T]) pop() (v T, ok bool) {
+	for {
+		h := r.head.Load()
+		if h != r.tail.Load() {
+			i := h & r.mask
+			v = r.buf[i]
+			r.buf[i] = *new(T)
+			r.head.Store(h + 1)
+			if r.producerWaiting.Load() {
+				signal(r.notFull)
+			}
+			return v, true
+		}
+		if r.closed.Load() {
+			if h != r.tail.Load() {
+				continue
+			}
+			if r.panicked {
+				r.panicked = false
+				panic(r.panicVal)
+			}
+			return v, false
+		}
+		r.consumerWaiting.Store(true)
+		if h == r.tail.Load() && !r.closed.Load() {
+			<-r.notEmpty
+		}
+		r.consumerWaiting.Store(false)
+	}
+}
+
+// stop stops the producer, and waits for it to return.
+func (r *spscRing[T]) stop() {
+	r.stopped.Store(true)
+	signal(r.notFull)
+	<-r.done
+}
+
+// signal wakes the waiter on ch, if any, without blocking.
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
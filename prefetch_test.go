@@ -0,0 +1,257 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithPrefetch(t *testing.T) {
+	var seqs []iter.Seq[int]
+	for i := range 5 {
+		var s []int
+		for j := range 300 {
+			s = append(s, j*(i+1)/3)
+		}
+		seqs = append(seqs, sliceSeq(s))
+	}
+	seqs = append(seqs, nil)
+	expected := collectSeq(Merge(cmp.Compare[int], seqs...))
+
+	for _, n := range []int{0, 1, 3, 64} {
+		if result := collectSeq(NewMerger(cmp.Compare[int], WithPrefetch(n)).Merge(seqs...)); !slices.Equal(result, expected) {
+			t.Errorf("Prefetch %d: unexpected result", n)
+		}
+	}
+}
+
+func TestWithPrefetch_ReadAhead(t *testing.T) {
+	var pulled atomic.Int64
+	seq := func(yield func(int) bool) {
+		for i := range 100 {
+			pulled.Add(1)
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	for range NewMerger(cmp.Compare[int], WithPrefetch(10)).Merge(seq) {
+		deadline := time.Now().Add(time.Second)
+		for pulled.Load() < 10 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		// 1 consumed, up to 16 (rounded to a power of two) buffered, and 1 blocked
+		if n := pulled.Load(); n < 10 || n > 18 {
+			t.Errorf("Expected the source to be read ahead, pulled %d", n)
+		}
+		break
+	}
+}
+
+func TestWithPrefetch_EarlyTermination(t *testing.T) {
+	stopped := make([]bool, 3)
+	var seqs []iter.Seq[int]
+	for i := range stopped {
+		seqs = append(seqs, func(yield func(int) bool) {
+			defer func() { stopped[i] = true }()
+			for j := 0; ; j++ {
+				if !yield(j) {
+					return
+				}
+			}
+		})
+	}
+	before := runtime.NumGoroutine()
+	var result []int
+	for v := range NewMerger(cmp.Compare[int], WithPrefetch(4)).Merge(seqs...) {
+		result = append(result, v)
+		if len(result) == 5 {
+			break
+		}
+	}
+	if !slices.Equal(result, []int{0, 0, 0, 1, 1}) {
+		t.Errorf("Expected [0 0 0 1 1], got %v", result)
+	}
+	for i, v := range stopped {
+		if !v {
+			t.Errorf("Expected source %d to be stopped", i)
+		}
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("Expected producers to have exited, got %d goroutines vs %d", n, before)
+	}
+}
+
+func TestWithPrefetch_Panic(t *testing.T) {
+	m := NewMerger(cmp.Compare[int], WithPrefetch(2))
+	var result []int
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("Expected panic %q, got %v", "boom", r)
+			}
+		}()
+		for v := range m.Merge(sliceSeq([]int{1, 2, 3}), func(yield func(int) bool) {
+			yield(2)
+			panic("boom")
+		}) {
+			result = append(result, v)
+		}
+	}()
+	if !slices.Equal(result, []int{1, 2, 2}) {
+		t.Errorf("Expected [1 2 2], got %v", result)
+	}
+}
+
+func TestWithPrefetch_Checked(t *testing.T) {
+	var result []int
+	var err error
+	for v, e := range NewMerger(cmp.Compare[int], WithPrefetch(2)).MergeChecked(sliceSeq([]int{1, 3, 2}), sliceSeq([]int{2})) {
+		if e != nil {
+			err = e
+			break
+		}
+		result = append(result, v)
+	}
+	if !slices.Equal(result, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", result)
+	}
+	if err == nil {
+		t.Error("Expected an error")
+	}
+}
+
+func TestWithPrefetch_Merger2(t *testing.T) {
+	m := NewMerger2(func(a1 int, a2 string, b1 int, b2 string) int { return cmp.Compare(a1, b1) }, WithPrefetch(2))
+	keys, values := collectSeq2(m.Merge(
+		sliceSeq2([]int{1, 3, 5}, []string{"a", "c", "e"}),
+		sliceSeq2([]int{2, 4}, []string{"b", "d"}),
+	))
+	if !slices.Equal(keys, []int{1, 2, 3, 4, 5}) || !slices.Equal(values, []string{"a", "b", "c", "d", "e"}) {
+		t.Errorf("Unexpected result: %v %v", keys, values)
+	}
+}
+
+func TestWithPrefetch_Limiter(t *testing.T) {
+	l := NewLimiter(4)
+	m := NewMerger(cmp.Compare[int], WithPrefetch(2), WithLimiter(l))
+	for range m.Merge(sliceSeq([]int{1}), nil, sliceSeq([]int{2})) {
+		if l.InUse() != 2 {
+			t.Errorf("Expected InUse() = 2, got %d", l.InUse())
+		}
+	}
+	if l.InUse() != 0 {
+		t.Errorf("Expected InUse() = 0, got %d", l.InUse())
+	}
+}
+
+func TestWithPrefetch_LimiterExceeded(t *testing.T) {
+	l := NewLimiter(2)
+	m := NewMerger(cmp.Compare[int], WithPrefetch(2), WithLimiter(l))
+	var result []int
+	for v := range m.Merge(sliceSeq([]int{1, 4}), sliceSeq([]int{2}), sliceSeq([]int{3})) {
+		if l.InUse() != 2 {
+			t.Errorf("Expected InUse() = 2, got %d", l.InUse())
+		}
+		result = append(result, v)
+	}
+	if !slices.Equal(result, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected [1 2 3 4], got %v", result)
+	}
+	if l.InUse() != 0 {
+		t.Errorf("Expected InUse() = 0, got %d", l.InUse())
+	}
+}
+
+func TestWithPrefetch_Negative(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for negative prefetch size")
+		}
+	}()
+	WithPrefetch(-1)
+}
+
+func TestSPSCRing(t *testing.T) {
+	for _, size := range []int{1, 2, 5, 1024} {
+		r := newSPSCRing[int](size)
+		go r.produce(func(yield func(int) bool) {
+			for i := range 100000 {
+				if !yield(i) {
+					return
+				}
+			}
+		})
+		var n int
+		for {
+			v, ok := r.pop()
+			if !ok {
+				break
+			}
+			if v != n {
+				t.Fatalf("Size %d: expected %d, got %d", size, n, v)
+			}
+			n++
+		}
+		r.stop()
+		if n != 100000 {
+			t.Errorf("Size %d: expected 100000 elements, got %d", size, n)
+		}
+	}
+}
+
+// chanPrefetch is a channel-based equivalent of spscRing, for comparison.
+func chanPrefetch[T any](seq iter.Seq[T], size int) (func() (T, bool), func()) {
+	ch := make(chan T, size)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer close(ch)
+		for v := range seq {
+			select {
+			case ch <- v:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return func() (T, bool) {
+			v, ok := <-ch
+			return v, ok
+		}, func() {
+			close(stop)
+			<-done
+		}
+}
+
+func BenchmarkPrefetch(b *testing.B) {
+	seq := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	for _, size := range []int{1, 64, 1024} {
+		b.Run("ring/"+strconv.Itoa(size), func(b *testing.B) {
+			next, stop := prefetchSource(seq, size)()
+			defer stop()
+			for b.Loop() {
+				next()
+			}
+		})
+		b.Run("chan/"+strconv.Itoa(size), func(b *testing.B) {
+			next, stop := chanPrefetch(seq, size)
+			defer stop()
+			for b.Loop() {
+				next()
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package kway
+
+import (
+	"cmp"
+	"slices"
+)
+
+// AppendMergedOrdered performs a k-way merge of the provided sorted slices,
+// ordered per [cmp.Compare], appending the output to `dst`, and returning the
+// extended slice. As for [AppendMerged], elements that compare equal are
+// ordered by input slice, which is observable only for floating-point zeros
+// of differing sign.
+//
+// Merges of exactly two slices of int32, int64, uint64 or float64 use a
+// specialized inner loop, which selects each element without branching on
+// the comparison, and is unrolled, making it substantially faster than the
+// general merge for unpredictable, finely interleaved inputs, such as integer
+// key runs. Other merges are performed per [MergeSliceRuns].
+func AppendMergedOrdered[T cmp.Ordered](dst []T, slices ...[]T) []T {
+	if len(slices) == 2 {
+		switch d := any(dst).(type) {
+		case []int32:
+			return any(appendMergedTwo(d, any(slices[0]).([]int32), any(slices[1]).([]int32))).([]T)
+		case []int64:
+			return any(appendMergedTwo(d, any(slices[0]).([]int64), any(slices[1]).([]int64))).([]T)
+		case []uint64:
+			return any(appendMergedTwo(d, any(slices[0]).([]uint64), any(slices[1]).([]uint64))).([]T)
+		case []float64:
+			return any(appendMergedTwo(d, any(slices[0]).([]float64), any(slices[1]).([]float64))).([]T)
+		}
+	}
+	for run := range MergeSliceRuns(cmp.Compare[T], slices...) {
+		dst = append(dst, run...)
+	}
+	return dst
+}
+
+// appendMergedTwo appends the two-way merge of a and b to dst. Each step
+// stores the lesser head, and advances the index of its slice, using the
+// result of the comparison arithmetically, which the compiler lowers to
+// conditional moves, rather than branches.
+func appendMergedTwo[T int32 | int64 | uint64 | float64](dst, a, b []T) []T {
+	n := len(dst)
+	dst = slices.Grow(dst, len(a)+len(b))[:n+len(a)+len(b)]
+	out := dst[n:]
+	var i, j, k int
+	// each step advances either i or j, so four steps are always in bounds
+	for i+4 <= len(a) && j+4 <= len(b) {
+		for range 4 {
+			x, y := a[i], b[j]
+			t := before(y, x)
+			out[k] = selectIf(t, y, x)
+			k++
+			i += 1 - t
+			j += t
+		}
+	}
+	for i < len(a) && j < len(b) {
+		x, y := a[i], b[j]
+		t := before(y, x)
+		out[k] = selectIf(t, y, x)
+		k++
+		i += 1 - t
+		j += t
+	}
+	k += copy(out[k:], a[i:])
+	copy(out[k:], b[j:])
+	return dst
+}
+
+// before returns 1 if x orders strictly before y, per [cmp.Less], and 0
+// otherwise. For integers, the NaN check is eliminated by the compiler.
+func before[T int32 | int64 | uint64 | float64](x, y T) int {
+	var t int
+	if x < y || (x != x && y == y) {
+		t = 1
+	}
+	return t
+}
+
+// selectIf returns x if t is 1, or y if t is 0.
+func selectIf[T int32 | int64 | uint64 | float64](t int, x, y T) T {
+	if t != 0 {
+		return x
+	}
+	return y
+}
@@ -0,0 +1,108 @@
+package kway
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestAppendMergedOrdered(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	sorted := func(n, max int) []int64 {
+		s := make([]int64, n)
+		for i := range s {
+			s[i] = int64(r.IntN(max)) - int64(max)/2
+		}
+		slices.Sort(s)
+		return s
+	}
+	for _, tc := range []struct {
+		name string
+		a, b []int64
+	}{
+		{"empty", nil, nil},
+		{"left", sorted(10, 100), nil},
+		{"right", nil, sorted(10, 100)},
+		{"short", sorted(3, 10), sorted(2, 10)},
+		{"interleaved", sorted(1000, 1000), sorted(1000, 1000)},
+		{"duplicates", sorted(500, 8), sorted(700, 8)},
+		{"disjoint", []int64{1, 2, 3, 4, 5, 6}, []int64{7, 8, 9, 10, 11}},
+		{"extremes", []int64{math.MinInt64, 0, math.MaxInt64}, []int64{math.MinInt64, math.MaxInt64}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			expected := append([]int64{-1}, slices.Sorted(slices.Values(append(slices.Clone(tc.a), tc.b...)))...)
+			if actual := AppendMergedOrdered([]int64{-1}, tc.a, tc.b); !slices.Equal(actual, expected) {
+				t.Errorf("Expected %v, got %v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestAppendMergedOrdered_types(t *testing.T) {
+	if actual, expected := AppendMergedOrdered(nil, []int32{1, 3, 5, 7, 9}, []int32{2, 3, 4, 10}), []int32{1, 2, 3, 3, 4, 5, 7, 9, 10}; !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+	if actual, expected := AppendMergedOrdered(nil, []uint64{0, math.MaxUint64}, []uint64{1, 2, 3, 4, 5}), []uint64{0, 1, 2, 3, 4, 5, math.MaxUint64}; !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+	type key int64
+	if actual, expected := AppendMergedOrdered(nil, []key{1, 4}, []key{2, 3}), []key{1, 2, 3, 4}; !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+	if actual, expected := AppendMergedOrdered(nil, []string{"a", "c"}, []string{"b"}, []string{"d"}), []string{"a", "b", "c", "d"}; !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+}
+
+func TestAppendMergedOrdered_float64(t *testing.T) {
+	nan := math.NaN()
+	negZero := math.Copysign(0, -1)
+	a := []float64{nan, negZero, 1, 2, 2, math.Inf(1)}
+	b := []float64{nan, nan, math.Inf(-1), 0, 2, 3, 4, 5}
+	actual := AppendMergedOrdered(nil, a, b)
+	expected := MergeToSlice(cmp.Compare[float64], slices.Values(a), slices.Values(b))
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, actual)
+	}
+	for i := range expected {
+		if cmp.Compare(actual[i], expected[i]) != 0 || math.Signbit(actual[i]) != math.Signbit(expected[i]) {
+			t.Errorf("Expected %v, got %v", expected, actual)
+			break
+		}
+	}
+}
+
+func BenchmarkAppendMergedOrdered(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 2))
+	const n = 1 << 16
+	x, y := make([]int64, n), make([]int64, n)
+	for i := range n {
+		x[i], y[i] = r.Int64N(n*4), r.Int64N(n*4)
+	}
+	slices.Sort(x)
+	slices.Sort(y)
+	dst := make([]int64, 0, 2*n)
+	b.Run("two-way", func(b *testing.B) {
+		for b.Loop() {
+			dst = AppendMergedOrdered(dst[:0], x, y)
+		}
+	})
+	b.Run("MergeSliceRuns", func(b *testing.B) {
+		for b.Loop() {
+			dst = dst[:0]
+			for run := range MergeSliceRuns(cmp.Compare[int64], x, y) {
+				dst = append(dst, run...)
+			}
+		}
+	})
+	b.Run("MergeCursors", func(b *testing.B) {
+		for b.Loop() {
+			dst = dst[:0]
+			for v := range MergeCursors(cmp.Compare[int64], NewSortedSlice(cmp.Compare[int64], x).Cursor(), NewSortedSlice(cmp.Compare[int64], y).Cursor()) {
+				dst = append(dst, v)
+			}
+		}
+	})
+}
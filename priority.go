@@ -0,0 +1,79 @@
+package kway
+
+// WithPriority marks the input sequences at the given indexes as
+// authoritative, such that their elements precede those of other sequences,
+// when they compare equal, regardless of the positions of the sequences. For
+// example, WithPriority(2) causes the elements of the third sequence to win
+// ties, e.g. to let the primary region's record precede those of replicas,
+// without reordering the arguments of the merge.
+//
+// Priority sequences precede each other in the order of `sources`. Priority
+// is consulted after any tie break, e.g. per [WithTieBreak], and remaining
+// ties fall back to ordering by input sequence, as documented by [Merge].
+// Indexes beyond the number of input sequences are ignored.
+func WithPriority(sources ...int) Option {
+	var rank []int
+	for i, source := range sources {
+		if source < 0 {
+			panic("kway: negative source index")
+		}
+		if source >= len(rank) {
+			rank = append(rank, make([]int, source+1-len(rank))...)
+		}
+		if rank[source] != 0 {
+			panic("kway: duplicate priority source")
+		}
+		rank[source] = len(sources) - i
+	}
+	return func(o *options) {
+		o.priority = rank
+	}
+}
+
+// WithStabilityOrder orders the elements of the input sequences that compare
+// equal by the given rank of each sequence, in place of their positions,
+// where ranks[i] is the rank of the sequence at index i, and ranks must be a
+// permutation of 0 through len(ranks)-1. For example, WithStabilityOrder(1,
+// 2, 0) orders the ties of three sequences a, b and c as c, a, then b, e.g.
+// where the data arrives in a fixed structural order, but the preferred order
+// of ties differs.
+//
+// It is equivalent to [WithPriority], listing the sequences in order of
+// rank, and replaces any such option. Sequences beyond len(ranks) follow
+// those ranked, ordered by position.
+func WithStabilityOrder(ranks ...int) Option {
+	sources := make([]int, len(ranks))
+	seen := make([]bool, len(ranks))
+	for i, r := range ranks {
+		if r < 0 || r >= len(ranks) || seen[r] {
+			panic("kway: invalid stability order")
+		}
+		seen[r] = true
+		sources[r] = i
+	}
+	return WithPriority(sources...)
+}
+
+// priorityCompare wraps cmp to order equal elements by the rank of their
+// source, where rank is indexed by source, and higher ranks precede lower.
+func priorityCompare[E wrappedValue](rank []int, cmp func(a, b E) int) func(a, b E) int {
+	sourceRank := func(i int) int {
+		if i < len(rank) {
+			return rank[i]
+		}
+		return 0
+	}
+	return func(a, b E) int {
+		if v := cmp(a, b); v != 0 {
+			return v
+		}
+		ra, rb := sourceRank(a.index()), sourceRank(b.index())
+		switch {
+		case ra > rb:
+			return -1
+		case ra < rb:
+			return 1
+		}
+		return 0
+	}
+}
@@ -0,0 +1,162 @@
+package kway
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+type priorityRecord struct {
+	key    int
+	source string
+}
+
+func comparePriorityRecord(a, b priorityRecord) int { return cmp.Compare(a.key, b.key) }
+
+func TestWithPriority(t *testing.T) {
+	replica1 := sliceSeq([]priorityRecord{{1, "replica1"}, {2, "replica1"}, {3, "replica1"}})
+	replica2 := sliceSeq([]priorityRecord{{2, "replica2"}, {3, "replica2"}})
+	primary := sliceSeq([]priorityRecord{{1, "primary"}, {3, "primary"}})
+
+	tests := []struct {
+		name     string
+		sources  []int
+		expected []priorityRecord
+	}{
+		{
+			name:    "none",
+			sources: nil,
+			expected: []priorityRecord{
+				{1, "replica1"}, {1, "primary"},
+				{2, "replica1"}, {2, "replica2"},
+				{3, "replica1"}, {3, "replica2"}, {3, "primary"},
+			},
+		},
+		{
+			name:    "primary",
+			sources: []int{2},
+			expected: []priorityRecord{
+				{1, "primary"}, {1, "replica1"},
+				{2, "replica1"}, {2, "replica2"},
+				{3, "primary"}, {3, "replica1"}, {3, "replica2"},
+			},
+		},
+		{
+			name:    "ordered",
+			sources: []int{2, 1},
+			expected: []priorityRecord{
+				{1, "primary"}, {1, "replica1"},
+				{2, "replica2"}, {2, "replica1"},
+				{3, "primary"}, {3, "replica2"}, {3, "replica1"},
+			},
+		},
+		{
+			name:    "out of range",
+			sources: []int{5, 2},
+			expected: []priorityRecord{
+				{1, "primary"}, {1, "replica1"},
+				{2, "replica1"}, {2, "replica2"},
+				{3, "primary"}, {3, "replica1"}, {3, "replica2"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMerger(comparePriorityRecord, WithPriority(tt.sources...))
+			result := collectSeq(m.Merge(replica1, replica2, primary))
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestWithPriority_tieBreak(t *testing.T) {
+	// the tie break takes precedence over priority
+	m := NewMerger(comparePriorityRecord,
+		WithTieBreak(func(a, b priorityRecord) int { return cmp.Compare(a.source, b.source) }),
+		WithPriority(1),
+	)
+	result := collectSeq(m.Merge(
+		sliceSeq([]priorityRecord{{1, "a"}, {2, "b"}}),
+		sliceSeq([]priorityRecord{{1, "b"}, {2, "b"}}),
+	))
+	expected := []priorityRecord{{1, "a"}, {1, "b"}, {2, "b"}, {2, "b"}}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestWithPriority_merge2(t *testing.T) {
+	m := NewMerger2(func(a1 int, a2 string, b1 int, b2 string) int { return cmp.Compare(a1, b1) }, WithPriority(1))
+	var result []string
+	for _, v := range m.Merge(
+		sliceSeq2([]int{1, 2}, []string{"a1", "a2"}),
+		sliceSeq2([]int{1, 2}, []string{"b1", "b2"}),
+	) {
+		result = append(result, v)
+	}
+	expected := []string{"b1", "a1", "b2", "a2"}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestWithPriority_panics(t *testing.T) {
+	for _, sources := range [][]int{{-1}, {1, 1}} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected panic for %v", sources)
+				}
+			}()
+			WithPriority(sources...)
+		}()
+	}
+}
+
+func TestWithStabilityOrder(t *testing.T) {
+	seq := func(source string) func(yield func(priorityRecord) bool) {
+		return sliceSeq([]priorityRecord{{1, source}, {2, source}})
+	}
+
+	tests := []struct {
+		name     string
+		ranks    []int
+		expected []string
+	}{
+		{"none", nil, []string{"a", "b", "c", "d"}},
+		{"identity", []int{0, 1, 2, 3}, []string{"a", "b", "c", "d"}},
+		{"permutation", []int{1, 2, 0}, []string{"c", "a", "b", "d"}},
+		{"reversed", []int{3, 2, 1, 0}, []string{"d", "c", "b", "a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMerger(comparePriorityRecord, WithStabilityOrder(tt.ranks...))
+			var result []string
+			for v := range m.Merge(seq("a"), seq("b"), seq("c"), seq("d")) {
+				if v.key == 1 {
+					result = append(result, v.source)
+				}
+			}
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestWithStabilityOrder_panics(t *testing.T) {
+	for _, ranks := range [][]int{{-1}, {1}, {0, 0}, {0, 2}} {
+		func() {
+			defer func() {
+				if r := recover(); r != "kway: invalid stability order" {
+					t.Errorf("Expected panic for %v, got %v", ranks, r)
+				}
+			}()
+			WithStabilityOrder(ranks...)
+		}()
+	}
+}
@@ -0,0 +1,146 @@
+package kway
+
+import (
+	"iter"
+	"math"
+	"slices"
+)
+
+// QuantileSketch estimates quantiles of a stream of elements, using the
+// Greenwald-Khanna algorithm, in space proportional to
+// (1/epsilon)·log(epsilon·n), for n elements, e.g. to report percentiles of
+// a merge, without materializing it, or a second pass. See [NewQuantileSketch]
+// and [QuantileSketch.Observe].
+//
+// The elements need not be added in sorted order, so a sketch may, e.g.,
+// observe values other than those the merge is ordered by, though sorted
+// elements (such as the output of a merge) are added in constant time.
+//
+// A QuantileSketch is not safe for concurrent use.
+type QuantileSketch[T any] struct {
+	cmp     func(a, b T) int
+	epsilon float64
+	tuples  []quantileTuple[T]
+	n       int
+	// inserts is the number of elements added since the last compression
+	inserts int
+}
+
+// quantileTuple is a summary element: v is an observed element, g is the
+// difference between the minimum rank of v and that of the previous tuple,
+// and delta is the difference between the maximum and minimum rank of v.
+type quantileTuple[T any] struct {
+	v        T
+	g, delta int
+}
+
+// NewQuantileSketch returns a [QuantileSketch], ordering elements per `cmp`,
+// estimating the rank of quantiles to within `epsilon`·n, e.g. 0.001 for a
+// rank error of 0.1%. The epsilon must be in the range (0, 1).
+func NewQuantileSketch[T any](cmp func(a, b T) int, epsilon float64) *QuantileSketch[T] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	if !(epsilon > 0 && epsilon < 1) {
+		panic("kway: invalid epsilon")
+	}
+	return &QuantileSketch[T]{cmp: cmp, epsilon: epsilon}
+}
+
+// Add adds v to the sketch.
+func (x *QuantileSketch[T]) Add(v T) {
+	i := len(x.tuples)
+	if i != 0 && x.cmp(v, x.tuples[i-1].v) < 0 {
+		i, _ = slices.BinarySearchFunc(x.tuples, v, func(t quantileTuple[T], v T) int {
+			if x.cmp(t.v, v) <= 0 {
+				return -1
+			}
+			return 1
+		})
+	}
+	var delta int
+	if i != 0 && i != len(x.tuples) {
+		delta = x.band()
+	}
+	x.tuples = slices.Insert(x.tuples, i, quantileTuple[T]{v: v, g: 1, delta: delta})
+	x.n++
+	x.inserts++
+	if float64(x.inserts) >= 1/(2*x.epsilon) {
+		x.compress()
+	}
+}
+
+// Observe returns a sequence yielding the elements of seq, unchanged, adding
+// each to the sketch, as it is yielded, such that the sketch may be queried
+// during, or after, its iteration, e.g. for the output of [Merge]. The
+// sketch is not reset, so it accumulates over each iteration, see
+// [QuantileSketch.Reset].
+func (x *QuantileSketch[T]) Observe(seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			x.Add(v)
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Count returns the number of elements added to the sketch.
+func (x *QuantileSketch[T]) Count() int { return x.n }
+
+// Quantile returns an element whose rank, among those added, is within
+// epsilon·n of `q`·n, e.g. the approximate median, for a `q` of 0.5, or
+// false if the sketch is empty. The `q` is clamped to the range [0, 1].
+func (x *QuantileSketch[T]) Quantile(q float64) (v T, ok bool) {
+	if x.n == 0 {
+		return v, false
+	}
+	q = min(max(q, 0), 1)
+	rank := max(math.Ceil(q*float64(x.n)), 1)
+	bound := rank + x.epsilon*float64(x.n)
+	var rmin int
+	for i, t := range x.tuples {
+		rmin += t.g
+		if float64(rmin+t.delta) > bound {
+			return x.tuples[i-1].v, true
+		}
+	}
+	return x.tuples[len(x.tuples)-1].v, true
+}
+
+// Reset removes all elements from the sketch.
+func (x *QuantileSketch[T]) Reset() {
+	clear(x.tuples)
+	x.tuples = x.tuples[:0]
+	x.n, x.inserts = 0, 0
+}
+
+// band returns the maximum permitted uncertainty of a tuple's rank.
+func (x *QuantileSketch[T]) band() int {
+	return int(2 * x.epsilon * float64(x.n))
+}
+
+// compress merges adjacent tuples, while the uncertainty of the merged rank
+// remains within the permitted band, retaining the first and last tuples.
+// The tuples are compacted in a single pass, from the end, with w the index
+// of the last tuple retained.
+func (x *QuantileSketch[T]) compress() {
+	x.inserts = 0
+	if len(x.tuples) < 3 {
+		return
+	}
+	band := x.band()
+	w := len(x.tuples) - 1
+	for i := w - 1; i >= 1; i-- {
+		if next := &x.tuples[w]; x.tuples[i].g+next.g+next.delta <= band {
+			next.g += x.tuples[i].g
+		} else {
+			w--
+			x.tuples[w] = x.tuples[i]
+		}
+	}
+	n := 1 + copy(x.tuples[1:], x.tuples[w:])
+	clear(x.tuples[n:])
+	x.tuples = x.tuples[:n]
+}
@@ -0,0 +1,213 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// checkQuantiles verifies that each quantile of sketch, which has observed
+// the elements of sorted, is within the rank error bound.
+func checkQuantiles(t *testing.T, sketch *QuantileSketch[int], sorted []int, epsilon float64) {
+	t.Helper()
+	n := float64(len(sorted))
+	for _, q := range []float64{0, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 1} {
+		v, ok := sketch.Quantile(q)
+		if !ok {
+			t.Fatalf("Expected quantile %v", q)
+		}
+		// the ranks of v span [lo+1, hi]
+		lo, _ := slices.BinarySearch(sorted, v)
+		hi, _ := slices.BinarySearch(sorted, v+1)
+		rank := max(math.Ceil(q*n), 1)
+		if float64(hi) < rank-epsilon*n || float64(lo+1) > rank+epsilon*n {
+			t.Errorf("Quantile %v: Expected rank %v ± %v, got %v (ranks %d to %d)", q, rank, epsilon*n, v, lo+1, hi)
+		}
+	}
+}
+
+func TestQuantileSketch(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	tests := []struct {
+		name    string
+		values  func(n int) []int
+		epsilon float64
+	}{
+		{
+			name: "sorted",
+			values: func(n int) []int {
+				s := make([]int, n)
+				for i := range s {
+					s[i] = i
+				}
+				return s
+			},
+			epsilon: 0.01,
+		},
+		{
+			name: "reversed",
+			values: func(n int) []int {
+				s := make([]int, n)
+				for i := range s {
+					s[i] = n - i
+				}
+				return s
+			},
+			epsilon: 0.01,
+		},
+		{
+			name: "random",
+			values: func(n int) []int {
+				s := make([]int, n)
+				for i := range s {
+					s[i] = rng.IntN(1000)
+				}
+				return s
+			},
+			epsilon: 0.005,
+		},
+		{
+			name:    "constant",
+			values:  func(n int) []int { return make([]int, n) },
+			epsilon: 0.1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, n := range []int{1, 2, 10, 1000, 20000} {
+				values := tt.values(n)
+				sketch := NewQuantileSketch(cmp.Compare[int], tt.epsilon)
+				for _, v := range values {
+					sketch.Add(v)
+				}
+				if sketch.Count() != n {
+					t.Errorf("Expected count %d, got %d", n, sketch.Count())
+				}
+				slices.Sort(values)
+				checkQuantiles(t, sketch, values, tt.epsilon)
+			}
+		})
+	}
+}
+
+func TestQuantileSketch_Space(t *testing.T) {
+	sketch := NewQuantileSketch(cmp.Compare[int], 0.01)
+	rng := rand.New(rand.NewPCG(3, 4))
+	for range 100000 {
+		sketch.Add(rng.Int())
+	}
+	if len(sketch.tuples) > 1000 {
+		t.Errorf("Expected at most 1000 tuples, got %d", len(sketch.tuples))
+	}
+}
+
+func TestQuantileSketch_compress(t *testing.T) {
+	// each tuple is merged into the next retained tuple, per the reference
+	// implementation, deleting tuples one at a time
+	reference := func(tuples []quantileTuple[int], band int) []quantileTuple[int] {
+		tuples = slices.Clone(tuples)
+		for i := len(tuples) - 2; i >= 1; i-- {
+			if next := &tuples[i+1]; tuples[i].g+next.g+next.delta <= band {
+				next.g += tuples[i].g
+				tuples = slices.Delete(tuples, i, i+1)
+			}
+		}
+		return tuples
+	}
+	rng := rand.New(rand.NewPCG(5, 6))
+	for n := range 20 {
+		for range 10 {
+			sketch := NewQuantileSketch(cmp.Compare[int], 0.1)
+			for i := range n {
+				sketch.tuples = append(sketch.tuples, quantileTuple[int]{v: i, g: 1 + rng.IntN(3), delta: rng.IntN(4)})
+				sketch.n += sketch.tuples[i].g
+			}
+			expected := reference(sketch.tuples, sketch.band())
+			tuples := sketch.tuples
+			sketch.compress()
+			if !slices.Equal(sketch.tuples, expected) {
+				t.Errorf("Expected %v, got %v", expected, sketch.tuples)
+			}
+			for _, v := range tuples[len(sketch.tuples):] {
+				if v != (quantileTuple[int]{}) {
+					t.Errorf("Expected the removed tuples to be cleared, got %v", v)
+				}
+			}
+		}
+	}
+}
+
+func TestQuantileSketch_Observe(t *testing.T) {
+	sketch := NewQuantileSketch(cmp.Compare[int], 0.01)
+	var seqs []iter.Seq[int]
+	var all []int
+	for i := range 4 {
+		s := make([]int, 1000)
+		for j := range s {
+			s[j] = j*4 + i
+		}
+		all = append(all, s...)
+		seqs = append(seqs, slices.Values(s))
+	}
+	slices.Sort(all)
+	var n int
+	for v := range sketch.Observe(Merge(cmp.Compare[int], seqs...)) {
+		n++
+		if n == 2000 {
+			if m, _ := sketch.Quantile(1); m != v {
+				t.Errorf("Expected maximum %d, got %d", v, m)
+			}
+		}
+	}
+	if n != len(all) {
+		t.Errorf("Expected %d elements, got %d", len(all), n)
+	}
+	checkQuantiles(t, sketch, all, 0.01)
+
+	sketch.Reset()
+	if _, ok := sketch.Quantile(0.5); ok || sketch.Count() != 0 {
+		t.Errorf("Expected empty sketch, got count %d", sketch.Count())
+	}
+	for range sketch.Observe(slices.Values([]int{1, 2, 3})) {
+		break
+	}
+	if sketch.Count() != 1 {
+		t.Errorf("Expected count 1, got %d", sketch.Count())
+	}
+}
+
+func TestNewQuantileSketch_Panics(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		cmp     func(a, b int) int
+		epsilon float64
+		panic   string
+	}{
+		{"nil cmp", nil, 0.1, "kway: nil comparison function"},
+		{"zero epsilon", cmp.Compare[int], 0, "kway: invalid epsilon"},
+		{"one epsilon", cmp.Compare[int], 1, "kway: invalid epsilon"},
+		{"nan epsilon", cmp.Compare[int], math.NaN(), "kway: invalid epsilon"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tt.panic {
+					t.Errorf("Expected %q, got %v", tt.panic, r)
+				}
+			}()
+			NewQuantileSketch(tt.cmp, tt.epsilon)
+		})
+	}
+}
+
+func BenchmarkQuantileSketch_Add(b *testing.B) {
+	sketch := NewQuantileSketch(cmp.Compare[int], 0.001)
+	var i int
+	for b.Loop() {
+		sketch.Add(i)
+		i++
+	}
+}
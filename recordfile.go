@@ -0,0 +1,81 @@
+package kway
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// RecordFile is a sorted array of fixed-size records, stored in a file,
+// memory-mapped where supported, such that merges over files larger than
+// memory use minimal heap. Each record is decoded as accessed, directly from
+// the mapping, without copying. It is an [Indexed] collection, so may be
+// merged or sought via [NewSortedIndex]. It is safe for concurrent use,
+// until closed.
+//
+// On platforms that do not support memory-mapping, the file is read into
+// memory, instead.
+type RecordFile[T any] struct {
+	data   []byte
+	size   int
+	decode func(record []byte) T
+	unmap  func() error
+}
+
+var _ Indexed[any] = (*RecordFile[any])(nil)
+
+// OpenRecordFile opens the file at `path`, as a [RecordFile] of records of
+// `size` bytes, decoded by `decode`, which must not retain the record, as
+// it refers directly to the mapping. The file's length must be a multiple of
+// `size`.
+func OpenRecordFile[T any](path string, size int, decode func(record []byte) T) (*RecordFile[T], error) {
+	if size <= 0 {
+		panic("kway: record size must be positive")
+	}
+	if decode == nil {
+		panic("kway: nil decode function")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	n := info.Size()
+	if n%int64(size) != 0 {
+		return nil, fmt.Errorf("kway: record file %s has length %d, not a multiple of the record size %d", path, n, size)
+	}
+	if int64(int(n)) != n {
+		return nil, fmt.Errorf("kway: record file %s is too large to map", path)
+	}
+	x := &RecordFile[T]{size: size, decode: decode, unmap: func() error { return nil }}
+	if n != 0 {
+		if x.data, x.unmap, err = mapFile(f, int(n)); err != nil {
+			return nil, fmt.Errorf("kway: mapping record file %s: %w", path, err)
+		}
+	}
+	return x, nil
+}
+
+// Len returns the number of records.
+func (x *RecordFile[T]) Len() int { return len(x.data) / x.size }
+
+// At returns the record at index i.
+func (x *RecordFile[T]) At(i int) T {
+	off := i * x.size
+	return x.decode(x.data[off : off+x.size : off+x.size])
+}
+
+// Close unmaps the file. The records must not be accessed after it is
+// closed.
+func (x *RecordFile[T]) Close() error {
+	if x.unmap == nil {
+		return errors.New("kway: record file already closed")
+	}
+	err := x.unmap()
+	x.data, x.unmap = nil, nil
+	return err
+}
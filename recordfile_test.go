@@ -0,0 +1,120 @@
+package kway
+
+import (
+	"cmp"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeRecords writes values as big-endian uint64 records, to a file in
+// dir, returning its path.
+func writeRecords(t *testing.T, dir, name string, values []uint64) string {
+	t.Helper()
+	var data []byte
+	for _, v := range values {
+		data = binary.BigEndian.AppendUint64(data, v)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func openUint64s(t *testing.T, path string) *RecordFile[uint64] {
+	t.Helper()
+	x, err := OpenRecordFile(path, 8, binary.BigEndian.Uint64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = x.Close() })
+	return x
+}
+
+func TestRecordFile(t *testing.T) {
+	dir := t.TempDir()
+	a := openUint64s(t, writeRecords(t, dir, "a", []uint64{1, 4, 7, 10}))
+	b := openUint64s(t, writeRecords(t, dir, "b", []uint64{2, 3, 11}))
+	empty := openUint64s(t, writeRecords(t, dir, "empty", nil))
+
+	if a.Len() != 4 || a.At(2) != 7 || empty.Len() != 0 {
+		t.Errorf("Expected 4 records, with 7 at 2, got %d, %d", a.Len(), a.At(2))
+	}
+	x, y, z := NewSortedIndex(cmp.Compare[uint64], a), NewSortedIndex(cmp.Compare[uint64], b), NewSortedIndex(cmp.Compare[uint64], empty)
+	expected := []uint64{1, 2, 3, 4, 7, 10, 11}
+	if result := collectSeq(MergeCursors(cmp.Compare[uint64], x.Cursor(), y.Cursor(), z.Cursor())); !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+	if result := collectSeq(Merge(cmp.Compare[uint64], x.Seek(4), y.Seek(4))); !slices.Equal(result, []uint64{4, 7, 10, 11}) {
+		t.Errorf("Expected [4 7 10 11], got %v", result)
+	}
+}
+
+func TestRecordFile_zeroCopy(t *testing.T) {
+	path := writeRecords(t, t.TempDir(), "a", []uint64{1, 2})
+	var records [][]byte
+	x, err := OpenRecordFile(path, 8, func(record []byte) []byte {
+		records = append(records, record)
+		return record
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer x.Close()
+	x.At(0)
+	x.At(1)
+	if &records[0][0] != &x.data[0] || &records[1][0] != &x.data[8] {
+		t.Error("Expected records to share the mapping")
+	}
+	if cap(records[0]) != 8 {
+		t.Errorf("Expected records to be capped, got capacity %d", cap(records[0]))
+	}
+}
+
+func TestRecordFile_errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := OpenRecordFile(filepath.Join(dir, "missing"), 8, binary.BigEndian.Uint64); !os.IsNotExist(err) {
+		t.Errorf("Expected not exist error, got %v", err)
+	}
+	path := filepath.Join(dir, "partial")
+	if err := os.WriteFile(path, make([]byte, 12), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenRecordFile(path, 8, binary.BigEndian.Uint64); err == nil || !strings.Contains(err.Error(), "not a multiple") {
+		t.Errorf("Expected length error, got %v", err)
+	}
+	x, err := OpenRecordFile(writeRecords(t, dir, "a", []uint64{1}), 8, binary.BigEndian.Uint64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := x.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := x.Close(); err == nil {
+		t.Error("Expected error closing twice")
+	}
+}
+
+func TestOpenRecordFile_panics(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		f     func()
+		panic string
+	}{
+		{"zero size", func() { _, _ = OpenRecordFile("x", 0, binary.BigEndian.Uint64) }, "kway: record size must be positive"},
+		{"nil decode", func() { _, _ = OpenRecordFile[uint64]("x", 8, nil) }, "kway: nil decode function"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tt.panic {
+					t.Errorf("Expected %q, got %v", tt.panic, r)
+				}
+			}()
+			tt.f()
+		})
+	}
+}
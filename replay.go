@@ -0,0 +1,160 @@
+package kway
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+)
+
+// Recording is a compact record of the decisions made by a merge, i.e. the
+// source of each element yielded, see [WithRecording]. Elements are taken
+// from each source in order, so the nth element from a source is its nth
+// element, and consecutive elements from the same source are stored as a
+// single run. A recording may be serialized, via [Recording.MarshalBinary],
+// and used to re-drive the same sources in the same order, via [Replay],
+// independent of the comparison function, or of timing.
+//
+// The zero value is an empty recording.
+type Recording struct {
+	runs []recordedRun
+	n    int64
+}
+
+type recordedRun struct {
+	source int
+	n      int64
+}
+
+// ErrInvalidRecording is returned by [Recording.UnmarshalBinary], for data
+// that is not a valid recording.
+var ErrInvalidRecording = errors.New("kway: invalid recording")
+
+// WithRecording enables recording of the source of each element yielded by
+// the Merger's merges, into `rec`, which is reset at the start of each
+// iteration of the merged sequence. Concurrent iteration of merges sharing
+// the same `rec` is not supported.
+func WithRecording(rec *Recording) Option {
+	if rec == nil {
+		panic("kway: nil recording")
+	}
+	return func(o *options) {
+		o.recording = rec
+	}
+}
+
+// reset empties the recording, retaining its capacity.
+func (x *Recording) reset() {
+	x.runs = x.runs[:0]
+	x.n = 0
+}
+
+// add records an element from source.
+func (x *Recording) add(source int) {
+	if i := len(x.runs) - 1; i >= 0 && x.runs[i].source == source {
+		x.runs[i].n++
+	} else {
+		x.runs = append(x.runs, recordedRun{source, 1})
+	}
+	x.n++
+}
+
+// Len returns the number of elements recorded.
+func (x *Recording) Len() int64 { return x.n }
+
+// All yields the source of each element recorded, and the ordinal of the
+// element, within its source, starting at 0.
+func (x *Recording) All() iter.Seq2[int, int64] {
+	return func(yield func(int, int64) bool) {
+		var ordinals []int64
+		for _, run := range x.runs {
+			if run.source >= len(ordinals) {
+				ordinals = append(ordinals, make([]int64, run.source+1-len(ordinals))...)
+			}
+			for range run.n {
+				if !yield(run.source, ordinals[run.source]) {
+					return
+				}
+				ordinals[run.source]++
+			}
+		}
+	}
+}
+
+// MarshalBinary encodes the recording as a sequence of runs, each a pair of
+// unsigned varints: the source index, and the number of elements.
+func (x *Recording) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, len(x.runs)*2)
+	for _, run := range x.runs {
+		b = binary.AppendUvarint(b, uint64(run.source))
+		b = binary.AppendUvarint(b, uint64(run.n))
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes a recording encoded by [Recording.MarshalBinary],
+// replacing the contents of x, or returns [ErrInvalidRecording].
+func (x *Recording) UnmarshalBinary(data []byte) error {
+	var runs []recordedRun
+	var total int64
+	for len(data) != 0 {
+		source, i := binary.Uvarint(data)
+		if i <= 0 || source > math.MaxInt {
+			return ErrInvalidRecording
+		}
+		data = data[i:]
+		n, i := binary.Uvarint(data)
+		if i <= 0 || n == 0 || n > uint64(math.MaxInt64-total) {
+			return ErrInvalidRecording
+		}
+		data = data[i:]
+		runs = append(runs, recordedRun{int(source), int64(n)})
+		total += int64(n)
+	}
+	x.runs, x.n = runs, total
+	return nil
+}
+
+// Replay re-drives the provided sources, yielding their elements in the
+// order recorded by `rec`, without comparing them, e.g. to reproduce the
+// output of a merge. The sources must be those of the recorded merge, in the
+// same order, and yield the same elements.
+//
+// Elements are yielded with a nil error. If a source ends before its
+// recorded elements, or is missing, i.e. nil, or out of range, the zero
+// value is yielded with an error, and iteration stops. Elements remaining in
+// the sources, after the recording, are not consumed.
+func Replay[T any](rec *Recording, seqs ...iter.Seq[T]) iter.Seq2[T, error] {
+	if rec == nil {
+		panic("kway: nil recording")
+	}
+	return func(yield func(T, error) bool) {
+		pulls := make([]func() (T, bool), len(seqs))
+		ordinals := make([]int64, len(seqs))
+		for _, run := range rec.runs {
+			if run.source >= len(seqs) || seqs[run.source] == nil {
+				yield(*new(T), fmt.Errorf("kway: replay: source %d not provided", run.source))
+				return
+			}
+			next := pulls[run.source]
+			if next == nil {
+				var stop func()
+				next, stop = iter.Pull(seqs[run.source])
+				defer stop()
+				pulls[run.source] = next
+			}
+			for range run.n {
+				v, ok := next()
+				if !ok {
+					yield(*new(T), fmt.Errorf("kway: replay: source %d ended after %d elements", run.source, ordinals[run.source]))
+					return
+				}
+				ordinals[run.source]++
+				if !yield(v, nil) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package kway
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWithRecording(t *testing.T) {
+	var rec Recording
+	m := NewMerger(cmp.Compare[int], WithRecording(&rec))
+	seqs := []iter.Seq[int]{
+		slices.Values([]int{1, 2, 3, 10}),
+		nil,
+		slices.Values([]int{4, 5, 11}),
+	}
+	expected := collectSeq(m.Merge(seqs...))
+	if rec.Len() != int64(len(expected)) {
+		t.Errorf("Expected %d, got %d", len(expected), rec.Len())
+	}
+	if len(rec.runs) != 4 {
+		t.Errorf("Expected 4 runs, got %v", rec.runs)
+	}
+
+	type decision struct {
+		source  int
+		ordinal int64
+	}
+	var decisions []decision
+	for source, ordinal := range rec.All() {
+		decisions = append(decisions, decision{source, ordinal})
+	}
+	if e := []decision{{0, 0}, {0, 1}, {0, 2}, {2, 0}, {2, 1}, {0, 3}, {2, 2}}; !slices.Equal(decisions, e) {
+		t.Errorf("Expected %v, got %v", e, decisions)
+	}
+
+	var actual []int
+	for v, err := range Replay(&rec, seqs...) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual = append(actual, v)
+	}
+	if !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+
+	// reset per iteration
+	_ = collectSeq(m.Merge(slices.Values([]int{1})))
+	if rec.Len() != 1 || len(rec.runs) != 1 {
+		t.Errorf("Expected the recording to be reset, got %v", rec.runs)
+	}
+}
+
+func TestWithRecording_EarlyTermination(t *testing.T) {
+	var rec Recording
+	m := NewMerger(cmp.Compare[int], WithRecording(&rec))
+	for v := range m.Merge(slices.Values([]int{1, 3}), slices.Values([]int{2, 4})) {
+		if v == 2 {
+			break
+		}
+	}
+	if rec.Len() != 2 {
+		t.Errorf("Expected 2, got %d", rec.Len())
+	}
+}
+
+func TestWithRecording_Nil(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "kway: nil recording" {
+			t.Errorf("Expected panic, got %v", r)
+		}
+	}()
+	WithRecording(nil)
+}
+
+// TestReplay_ordering reproduces an order that depends on something other
+// than the elements, i.e. the priority of the sources.
+func TestReplay_ordering(t *testing.T) {
+	var rec Recording
+	inputs := [][]string{{"a", "b"}, {"a", "c"}, {"b"}}
+	seqs := func() []iter.Seq[string] {
+		var seqs []iter.Seq[string]
+		for _, s := range inputs {
+			seqs = append(seqs, slices.Values(s))
+		}
+		return seqs
+	}
+	expected := collectSeq(NewMerger(func(a, b string) int { return strings.Compare(a[:1], b[:1]) }, WithPriority(2, 1, 0), WithRecording(&rec)).Merge(seqs()...))
+
+	data, err := rec.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Recording
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	var actual []string
+	var sources []int
+	replayed := Replay(&decoded, seqs()...)
+	for v, err := range replayed {
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual = append(actual, v)
+	}
+	for source := range decoded.All() {
+		sources = append(sources, source)
+	}
+	if !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+	if e := []int{1, 0, 2, 0, 1}; !slices.Equal(sources, e) {
+		t.Errorf("Expected %v, got %v", e, sources)
+	}
+}
+
+func TestReplay_errors(t *testing.T) {
+	rec := Recording{runs: []recordedRun{{0, 1}, {1, 2}}, n: 3}
+	for _, tc := range []struct {
+		name string
+		seqs []iter.Seq[int]
+		err  string
+	}{
+		{"short", []iter.Seq[int]{slices.Values([]int{1}), slices.Values([]int{2})}, "kway: replay: source 1 ended after 1 elements"},
+		{"missing", []iter.Seq[int]{slices.Values([]int{1})}, "kway: replay: source 1 not provided"},
+		{"nil", []iter.Seq[int]{slices.Values([]int{1}), nil}, "kway: replay: source 1 not provided"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var values []int
+			var err error
+			for v, e := range Replay(&rec, tc.seqs...) {
+				if e != nil {
+					err = e
+					break
+				}
+				values = append(values, v)
+			}
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("Expected %q, got %v", tc.err, err)
+			}
+			if len(values) == 0 || values[0] != 1 {
+				t.Errorf("Expected the elements before the error, got %v", values)
+			}
+		})
+	}
+
+	defer func() {
+		if r := recover(); r != "kway: nil recording" {
+			t.Errorf("Expected panic, got %v", r)
+		}
+	}()
+	Replay[int](nil)
+}
+
+func TestRecording_UnmarshalBinary(t *testing.T) {
+	rec := Recording{runs: []recordedRun{{0, 300}, {7, 1}, {0, 2}}, n: 303}
+	data, err := rec.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 7 {
+		t.Errorf("Expected a compact encoding, got %d bytes", len(data))
+	}
+	var decoded Recording
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(decoded.runs, rec.runs) || decoded.Len() != rec.Len() {
+		t.Errorf("Expected %v, got %v", rec, decoded)
+	}
+	for _, data := range [][]byte{
+		{0x80},
+		{0x01},
+		{0x01, 0x00},
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x01},
+	} {
+		if err := decoded.UnmarshalBinary(data); !errors.Is(err, ErrInvalidRecording) {
+			t.Errorf("Expected %v for %x, got %v", ErrInvalidRecording, data, err)
+		}
+	}
+	if err := decoded.UnmarshalBinary(nil); err != nil || decoded.Len() != 0 {
+		t.Errorf("Expected an empty recording, got %v, %v", decoded, err)
+	}
+}
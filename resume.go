@@ -0,0 +1,160 @@
+package kway
+
+import (
+	"encoding/binary"
+	"errors"
+	"iter"
+)
+
+// TokenCodec encodes and decodes the elements recorded by resumption
+// tokens, see [ResumableMerge].
+type TokenCodec[T any] struct {
+	// Append appends the encoding of v to dst, returning the extended slice.
+	Append func(dst []byte, v T) []byte
+	// Decode decodes an element encoded by Append.
+	Decode func(data []byte) (T, error)
+}
+
+// ErrInvalidToken is returned by [ResumeMerge], for a token that is not
+// valid, or does not match the number of sources.
+var ErrInvalidToken = errors.New("kway: invalid resumption token")
+
+// tokenVersion is the first byte of every token.
+const tokenVersion = 1
+
+// ResumableMerge is a k-way merge of [Seekable] sources, per [Merge], which
+// may be checkpointed, at any point between elements, using
+// [ResumableMerge.Token], and later resumed, possibly by another process,
+// using [ResumeMerge], e.g. for resumable exports, or backfills.
+//
+// The position is recorded as the last element yielded, along with the
+// number of elements equal to it, that have been yielded from each source.
+// Resuming seeks each source to that element, skipping those already
+// yielded, so the sources must contain the same elements when resumed, at
+// least from the position onward. A ResumableMerge is not safe for
+// concurrent use.
+type ResumableMerge[T any] struct {
+	cmp   func(a, b T) int
+	codec TokenCodec[T]
+	srcs  []Seekable[T]
+	// last is the last element yielded, if ok
+	last T
+	ok   bool
+	// equal is the number of elements equal to last, yielded from each source
+	equal []uint64
+}
+
+// NewResumableMerge returns a [ResumableMerge] of `srcs`, positioned at the
+// start, which must each be sorted according to `cmp`. Nil sources are
+// ignored. The `codec` is used to encode the position, in tokens.
+func NewResumableMerge[T any](cmp func(a, b T) int, codec TokenCodec[T], srcs ...Seekable[T]) *ResumableMerge[T] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	if codec.Append == nil || codec.Decode == nil {
+		panic("kway: incomplete token codec")
+	}
+	return &ResumableMerge[T]{
+		cmp:   cmp,
+		codec: codec,
+		srcs:  srcs,
+		equal: make([]uint64, len(srcs)),
+	}
+}
+
+// ResumeMerge returns a [ResumableMerge], per [NewResumableMerge],
+// positioned per `token`, as returned by [ResumableMerge.Token], for a merge
+// of the same sources, in the same order. It returns [ErrInvalidToken] if the
+// token is malformed, or any error returned by the codec.
+func ResumeMerge[T any](cmp func(a, b T) int, codec TokenCodec[T], token []byte, srcs ...Seekable[T]) (*ResumableMerge[T], error) {
+	x := NewResumableMerge(cmp, codec, srcs...)
+	if len(token) < 2 || token[0] != tokenVersion {
+		return nil, ErrInvalidToken
+	}
+	data := token[1:]
+	k, n := binary.Uvarint(data)
+	if n <= 0 || k != uint64(len(srcs)) {
+		return nil, ErrInvalidToken
+	}
+	data = data[n:]
+	if len(data) == 0 {
+		return nil, ErrInvalidToken
+	}
+	x.ok, data = data[0] != 0, data[1:]
+	if x.ok {
+		for i := range x.equal {
+			if x.equal[i], n = binary.Uvarint(data); n <= 0 {
+				return nil, ErrInvalidToken
+			}
+			data = data[n:]
+		}
+		var err error
+		if x.last, err = codec.Decode(data); err != nil {
+			return nil, err
+		}
+	} else if len(data) != 0 {
+		return nil, ErrInvalidToken
+	}
+	return x, nil
+}
+
+// Token returns a token recording the current position, i.e. after the last
+// element yielded, from which the merge may be resumed, via [ResumeMerge].
+func (x *ResumableMerge[T]) Token() []byte {
+	b := binary.AppendUvarint([]byte{tokenVersion}, uint64(len(x.srcs)))
+	if !x.ok {
+		return append(b, 0)
+	}
+	b = append(b, 1)
+	for _, n := range x.equal {
+		b = binary.AppendUvarint(b, n)
+	}
+	return x.codec.Append(b, x.last)
+}
+
+// All returns a sequence of the remaining elements, in merged order,
+// advancing the position as each element is yielded. If iteration stops
+// early, a subsequent iteration continues from the position.
+func (x *ResumableMerge[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seqs := make([]iter.Seq[T], len(x.srcs))
+		for i, src := range x.srcs {
+			switch {
+			case src == nil:
+			case x.ok:
+				seqs[i] = x.seek(src, x.last, x.equal[i])
+			default:
+				seqs[i] = src.All()
+			}
+		}
+		for i, v := range MergeIndexed(x.cmp, seqs...) {
+			if x.ok && x.cmp(v, x.last) == 0 {
+				x.equal[i]++
+			} else {
+				clear(x.equal)
+				x.last, x.ok = v, true
+				x.equal[i] = 1
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// seek returns the elements of src greater than or equal to key, skipping
+// the first n that are equal to key.
+func (x *ResumableMerge[T]) seek(src Seekable[T], key T, n uint64) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range src.Seek(key) {
+			if n != 0 && x.cmp(v, key) == 0 {
+				n--
+				continue
+			}
+			n = 0
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
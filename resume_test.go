@@ -0,0 +1,128 @@
+package kway
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"slices"
+	"testing"
+)
+
+type resumeItem struct{ key, src, n int }
+
+func compareResumeItems(a, b resumeItem) int { return cmp.Compare(a.key, b.key) }
+
+var resumeItemCodec = TokenCodec[resumeItem]{
+	Append: func(dst []byte, v resumeItem) []byte { return binary.AppendVarint(dst, int64(v.key)) },
+	Decode: func(data []byte) (resumeItem, error) {
+		v, n := binary.Varint(data)
+		if n != len(data) {
+			return resumeItem{}, errors.New("invalid key")
+		}
+		return resumeItem{key: int(v)}, nil
+	},
+}
+
+func TestResumableMerge(t *testing.T) {
+	// duplicates, within and across sources
+	inputs := [][]int{{1, 2, 2, 2, 5}, {2, 2, 3}, nil, {0, 2, 5, 5}}
+	srcs := make([]Seekable[resumeItem], len(inputs))
+	for i, keys := range inputs {
+		if keys == nil {
+			continue
+		}
+		var s []resumeItem
+		for n, key := range keys {
+			s = append(s, resumeItem{key, i, n})
+		}
+		srcs[i] = NewSortedSlice(compareResumeItems, s)
+	}
+	expected := collectSeq(NewResumableMerge(compareResumeItems, resumeItemCodec, srcs...).All())
+	if len(expected) != 12 || !slices.IsSortedFunc(expected, compareResumeItems) {
+		t.Fatalf("Unexpected merge: %v", expected)
+	}
+
+	for stop := 0; stop <= len(expected); stop++ {
+		x := NewResumableMerge(compareResumeItems, resumeItemCodec, srcs...)
+		var actual []resumeItem
+		if stop != 0 {
+			for v := range x.All() {
+				actual = append(actual, v)
+				if len(actual) == stop {
+					break
+				}
+			}
+		}
+		token := x.Token()
+		resumed, err := ResumeMerge(compareResumeItems, resumeItemCodec, token, srcs...)
+		if err != nil {
+			t.Fatalf("stop %d: %v", stop, err)
+		}
+		actual = append(actual, collectSeq(resumed.All())...)
+		if !slices.Equal(actual, expected) {
+			t.Errorf("stop %d: Expected %v, got %v", stop, expected, actual)
+		}
+		// the resumed merge may itself be checkpointed
+		if again, err := ResumeMerge(compareResumeItems, resumeItemCodec, resumed.Token(), srcs...); err != nil || len(collectSeq(again.All())) != 0 {
+			t.Errorf("stop %d: Expected the completed merge to remain complete, got %v", stop, err)
+		}
+	}
+
+	// iterating again continues from the position
+	x := NewResumableMerge(compareResumeItems, resumeItemCodec, srcs...)
+	for range x.All() {
+		break
+	}
+	if actual := collectSeq(x.All()); !slices.Equal(actual, expected[1:]) {
+		t.Errorf("Expected %v, got %v", expected[1:], actual)
+	}
+}
+
+func TestResumeMerge_invalid(t *testing.T) {
+	srcs := []Seekable[resumeItem]{NewSortedSlice(compareResumeItems, []resumeItem{{1, 0, 0}})}
+	valid := NewResumableMerge(compareResumeItems, resumeItemCodec, srcs...)
+	for range valid.All() {
+	}
+	token := valid.Token()
+	for _, tc := range []struct {
+		name  string
+		token []byte
+		srcs  []Seekable[resumeItem]
+		err   string
+	}{
+		{"empty", nil, srcs, ErrInvalidToken.Error()},
+		{"version", append([]byte{2}, token[1:]...), srcs, ErrInvalidToken.Error()},
+		{"sources", token, append(srcs, nil), ErrInvalidToken.Error()},
+		{"truncated", token[:2], srcs, ErrInvalidToken.Error()},
+		{"counts", token[:3], srcs, ErrInvalidToken.Error()},
+		{"trailing", []byte{tokenVersion, 1, 0, 0}, srcs, ErrInvalidToken.Error()},
+		{"key", append(token, 0), srcs, "invalid key"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ResumeMerge(compareResumeItems, resumeItemCodec, tc.token, tc.srcs...); err == nil || err.Error() != tc.err {
+				t.Errorf("Expected %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestNewResumableMerge_panics(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		cmp   func(a, b resumeItem) int
+		codec TokenCodec[resumeItem]
+		err   string
+	}{
+		{"cmp", nil, resumeItemCodec, "kway: nil comparison function"},
+		{"codec", compareResumeItems, TokenCodec[resumeItem]{Append: resumeItemCodec.Append}, "kway: incomplete token codec"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tc.err {
+					t.Errorf("Expected %q, got %v", tc.err, r)
+				}
+			}()
+			NewResumableMerge(tc.cmp, tc.codec)
+		})
+	}
+}
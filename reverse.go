@@ -0,0 +1,41 @@
+package kway
+
+import (
+	"iter"
+)
+
+// ReverseSlice returns a sequence of the elements of `s`, from last to first,
+// allowing a slice sorted in descending order to participate in an
+// ascending merge, without copying it.
+func ReverseSlice[T any](s []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(s) - 1; i >= 0; i-- {
+			if !yield(s[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Reverse returns a [BidiCursor] that steps `c` in the opposite direction,
+// from its current position: Next calls c.Prev, and Prev calls c.Next. This
+// allows a cursor sorted in descending order, positioned at its end, to
+// participate in an ascending merge, e.g. via [MergeCursors] or
+// [NewMergedCursor]. Reversing a reversed cursor returns the original.
+func Reverse[T any](c BidiCursor[T]) BidiCursor[T] {
+	if c == nil {
+		panic("kway: nil cursor")
+	}
+	if r, ok := c.(reverseCursor[T]); ok {
+		return r.c
+	}
+	return reverseCursor[T]{c}
+}
+
+type reverseCursor[T any] struct {
+	c BidiCursor[T]
+}
+
+func (x reverseCursor[T]) Next() (T, bool) { return x.c.Prev() }
+
+func (x reverseCursor[T]) Prev() (T, bool) { return x.c.Next() }
@@ -0,0 +1,78 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestReverseSlice(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []iter.Seq[int]
+		expected []int
+	}{
+		{
+			name:     "empty",
+			input:    []iter.Seq[int]{ReverseSlice[int](nil)},
+			expected: nil,
+		},
+		{
+			name:     "single",
+			input:    []iter.Seq[int]{ReverseSlice([]int{3, 2, 1})},
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:     "mixed directions",
+			input:    []iter.Seq[int]{ReverseSlice([]int{9, 5, 1}), sliceSeq([]int{4, 8}), ReverseSlice([]int{6, 2})},
+			expected: []int{1, 2, 4, 5, 6, 8, 9},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := collectSeq(Merge(cmp.Compare[int], tt.input...)); !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	for range ReverseSlice([]int{2, 1}) {
+		break
+	}
+}
+
+func TestReverse(t *testing.T) {
+	descending := NewSortedSlice(func(a, b int) int { return cmp.Compare(b, a) }, []int{7, 5, 3}).Cursor()
+	descending.Seek(-1) // the end
+
+	x := NewMergedCursor[int](cmp.Compare[int], Reverse[int](descending), NewSortedSlice(cmp.Compare[int], []int{4, 6}).Cursor())
+	var result []int
+	for {
+		v, ok := x.Next()
+		if !ok {
+			break
+		}
+		result = append(result, v)
+	}
+	if !slices.Equal(result, []int{3, 4, 5, 6, 7}) {
+		t.Errorf("Expected [3 4 5 6 7], got %v", result)
+	}
+	if v, ok := x.Prev(); !ok || v != 7 {
+		t.Errorf("Expected (7, true), got (%d, %v)", v, ok)
+	}
+
+	if c := Reverse(Reverse[int](descending)); c != BidiCursor[int](descending) {
+		t.Error("Expected reversing twice to return the original cursor")
+	}
+}
+
+func TestReverse_NilCursor(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil cursor")
+		}
+	}()
+	Reverse[int](nil)
+}
@@ -0,0 +1,36 @@
+package kway
+
+import (
+	"iter"
+)
+
+// MergeRLE performs a k-way merge of the provided sorted input sequences, like
+// [Merge], but run-length encodes the output. Each yielded pair is the first
+// element of a run of consecutive elements that compare equal under `cmp`,
+// along with the length of that run.
+//
+// Because the merge is stable, the yielded element is the one originating from
+// the lowest-indexed sequence that contributed to the run. See [Merge] for
+// details on the comparison function.
+func MergeRLE[T any](cmp func(a, b T) int, seqs ...iter.Seq[T]) iter.Seq2[T, int] {
+	merged := Merge(cmp, seqs...)
+	return func(yield func(T, int) bool) {
+		var (
+			run T
+			n   int
+		)
+		for v := range merged {
+			if n != 0 && cmp(run, v) == 0 {
+				n++
+				continue
+			}
+			if n != 0 && !yield(run, n) {
+				return
+			}
+			run, n = v, 1
+		}
+		if n != 0 {
+			yield(run, n)
+		}
+	}
+}
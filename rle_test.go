@@ -0,0 +1,111 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMergeRLE_NilCompareFunction(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil comparison function")
+		} else if !strings.Contains(r.(string), "nil comparison function") {
+			t.Errorf("Expected panic message about nil comparison function, got: %v", r)
+		}
+	}()
+
+	_ = MergeRLE[int](nil, sliceSeq([]int{1}))
+}
+
+func TestMergeRLE(t *testing.T) {
+	tests := []struct {
+		name           string
+		seqs           [][]int
+		expectedValues []int
+		expectedCounts []int
+	}{
+		{
+			name: "no sequences",
+		},
+		{
+			name: "empty sequences",
+			seqs: [][]int{{}, {}},
+		},
+		{
+			name:           "single sequence",
+			seqs:           [][]int{{1, 1, 2, 3, 3, 3}},
+			expectedValues: []int{1, 2, 3},
+			expectedCounts: []int{2, 1, 3},
+		},
+		{
+			name:           "runs spanning sequences",
+			seqs:           [][]int{{1, 2, 2, 5}, {2, 3}, {1, 5, 5}},
+			expectedValues: []int{1, 2, 3, 5},
+			expectedCounts: []int{2, 3, 1, 3},
+		},
+		{
+			name:           "all distinct",
+			seqs:           [][]int{{1, 3}, {2, 4}},
+			expectedValues: []int{1, 2, 3, 4},
+			expectedCounts: []int{1, 1, 1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seqs []iter.Seq[int]
+			for _, s := range tt.seqs {
+				seqs = append(seqs, sliceSeq(s))
+			}
+			var values, counts []int
+			for v, n := range MergeRLE(cmp.Compare[int], seqs...) {
+				values = append(values, v)
+				counts = append(counts, n)
+			}
+			if !slices.Equal(values, tt.expectedValues) || !slices.Equal(counts, tt.expectedCounts) {
+				t.Errorf("Expected %v, %v; got %v, %v", tt.expectedValues, tt.expectedCounts, values, counts)
+			}
+		})
+	}
+}
+
+func TestMergeRLE_Stability(t *testing.T) {
+	type stableValue struct {
+		value int
+		seqID int
+	}
+
+	cmpFunc := func(a, b stableValue) int {
+		return cmp.Compare(a.value, b.value)
+	}
+
+	var values []stableValue
+	for v := range MergeRLE(cmpFunc,
+		sliceSeq([]stableValue{{2, 1}}),
+		sliceSeq([]stableValue{{1, 2}, {2, 2}}),
+		sliceSeq([]stableValue{{1, 3}}),
+	) {
+		values = append(values, v)
+	}
+
+	expected := []stableValue{{1, 2}, {2, 1}}
+	if !slices.Equal(values, expected) {
+		t.Errorf("Expected %v, got %v", expected, values)
+	}
+}
+
+func TestMergeRLE_EarlyTermination(t *testing.T) {
+	var values []int
+	for v := range MergeRLE(cmp.Compare[int], sliceSeq([]int{1, 1, 2, 3}), sliceSeq([]int{2, 4})) {
+		values = append(values, v)
+		if len(values) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(values, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", values)
+	}
+}
@@ -0,0 +1,115 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/joeycumines/go-kway/heap"
+)
+
+// MergeRuns performs a k-way merge of the provided sorted input sequences,
+// per [Merge], yielding each maximal run of consecutive elements of the
+// output originating from the same input sequence as a single batch, e.g.
+// for batch-oriented writers or encoders, avoiding per-element overhead.
+// Concatenating the batches gives the output of [Merge].
+//
+// The batch is reused, and must not be retained beyond each iteration. The
+// elements of each run are buffered until it ends, so see [MergeSliceRuns]
+// to merge slices without buffering.
+func MergeRuns[T any](cmp func(a, b T) int, seqs ...iter.Seq[T]) iter.Seq[[]T] {
+	merged := MergeIndexed(cmp, seqs...)
+	return func(yield func([]T) bool) {
+		var (
+			batch  []T
+			source int
+		)
+		for i, v := range merged {
+			if len(batch) != 0 && i != source {
+				if !yield(batch) {
+					return
+				}
+				clear(batch)
+				batch = batch[:0]
+			}
+			batch, source = append(batch, v), i
+		}
+		if len(batch) != 0 {
+			yield(batch)
+		}
+	}
+}
+
+// runSource is the position of a source of [MergeSliceRuns].
+type runSource[T any] struct {
+	s []T
+	i int
+}
+
+// MergeSliceRuns performs a k-way merge of the provided sorted slices, per
+// [MergeRuns], yielding each run as a subslice of its input, without
+// copying. The end of each run is located by exponential search, per
+// [SliceCursor.Seek], such that merging long runs costs O(log n) comparisons
+// per run, rather than per element.
+//
+// The subslices are capped, such that appending to them does not modify the
+// inputs.
+func MergeSliceRuns[T any](cmpFunc func(a, b T) int, slices ...[]T) iter.Seq[[]T] {
+	if cmpFunc == nil {
+		panic("kway: nil comparison function")
+	}
+	// after, for an element of the source with the greater index, is true
+	// if a follows b, in the merged order, i.e. is greater than b
+	after := func(a, b T) int {
+		if cmpFunc(a, b) <= 0 {
+			return -1
+		}
+		return 1
+	}
+	return func(yield func([]T) bool) {
+		srcs := make([]runSource[T], 0, len(slices))
+		for i, s := range slices {
+			if len(s) != 0 {
+				srcs = append(srcs, runSource[T]{s, i})
+			}
+		}
+		h := heap.New(func(a, b runSource[T]) int {
+			if c := cmpFunc(a.s[0], b.s[0]); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.i, b.i)
+		}, srcs)
+		for h.Len() != 0 {
+			srcs = h.Slice()
+			top := &srcs[0]
+			end := len(top.s)
+			if len(srcs) > 1 {
+				// the run ends at the head of the next source, the lesser of
+				// the children of the top, located from the start of the run
+				next := srcs[1]
+				if len(srcs) > 2 && srcs[2].less(next, cmpFunc) {
+					next = srcs[2]
+				}
+				if next.i < top.i {
+					end = gallop(cmpFunc, top.s, 0, next.s[0])
+				} else {
+					end = gallop(after, top.s, 0, next.s[0])
+				}
+			}
+			if !yield(top.s[:end:end]) {
+				return
+			}
+			if end == len(top.s) {
+				h.Pop()
+			} else {
+				top.s = top.s[end:]
+				h.Fix(0)
+			}
+		}
+	}
+}
+
+// less returns true if x precedes y, in the merged order.
+func (x runSource[T]) less(y runSource[T], cmp func(a, b T) int) bool {
+	c := cmp(x.s[0], y.s[0])
+	return c < 0 || (c == 0 && x.i < y.i)
+}
@@ -0,0 +1,146 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestMergeRuns(t *testing.T) {
+	type value struct {
+		key, source int
+	}
+	cmpFunc := func(a, b value) int { return cmp.Compare(a.key, b.key) }
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 300 {
+		inputs := make([][]value, rng.IntN(5))
+		var seqs []iter.Seq[value]
+		for i := range inputs {
+			n := rng.IntN(30)
+			for range n {
+				inputs[i] = append(inputs[i], value{rng.IntN(40), i})
+			}
+			slices.SortFunc(inputs[i], cmpFunc)
+			seqs = append(seqs, slices.Values(inputs[i]))
+		}
+		expected := collectSeq(Merge(cmpFunc, seqs...))
+
+		for name, runs := range map[string]iter.Seq[[]value]{
+			"seqs":   MergeRuns(cmpFunc, seqs...),
+			"slices": MergeSliceRuns(cmpFunc, inputs...),
+		} {
+			var (
+				result []value
+				prev   = -1
+			)
+			for run := range runs {
+				if len(run) == 0 {
+					t.Fatalf("%s: Expected non-empty run", name)
+				}
+				for _, v := range run {
+					if v.source != run[0].source {
+						t.Fatalf("%s: Expected run from a single source, got %v", name, run)
+					}
+				}
+				if run[0].source == prev {
+					t.Fatalf("%s: Expected maximal runs, got consecutive runs from source %d", name, prev)
+				}
+				prev = run[0].source
+				result = append(result, run...)
+			}
+			if !slices.Equal(result, expected) {
+				t.Fatalf("%s %v: Expected %v, got %v", name, inputs, expected, result)
+			}
+		}
+	}
+}
+
+func TestMergeSliceRuns(t *testing.T) {
+	a := []int{1, 2, 3, 7, 8}
+	b := []int{3, 4, 5, 6, 9}
+	var runs [][]int
+	for run := range MergeSliceRuns(cmp.Compare[int], a, nil, b) {
+		runs = append(runs, run)
+	}
+	expected := [][]int{{1, 2, 3}, {3, 4, 5, 6}, {7, 8}, {9}}
+	if !slices.EqualFunc(runs, expected, slices.Equal) {
+		t.Fatalf("Expected %v, got %v", expected, runs)
+	}
+	if &runs[0][0] != &a[0] || &runs[1][0] != &b[0] {
+		t.Error("Expected runs to be subslices of the inputs")
+	}
+	if cap(runs[0]) != 3 {
+		t.Errorf("Expected capacity 3, got %d", cap(runs[0]))
+	}
+}
+
+func TestMergeSliceRuns_comparisons(t *testing.T) {
+	a := make([]int, 1<<16)
+	b := make([]int, 1<<16)
+	for i := range a {
+		a[i], b[i] = i, i+len(a)
+	}
+	var n int
+	cmpFunc := func(x, y int) int {
+		n++
+		return cmp.Compare(x, y)
+	}
+	var runs int
+	for range MergeSliceRuns(cmpFunc, b, a) {
+		runs++
+	}
+	if runs != 2 {
+		t.Errorf("Expected 2 runs, got %d", runs)
+	}
+	if n > 100 {
+		t.Errorf("Expected at most 100 comparisons, got %d", n)
+	}
+}
+
+func TestMergeRuns_earlyExit(t *testing.T) {
+	a, b := []int{1, 3}, []int{2, 4}
+	for _, runs := range []iter.Seq[[]int]{
+		MergeRuns(cmp.Compare[int], slices.Values(a), slices.Values(b)),
+		MergeSliceRuns(cmp.Compare[int], a, b),
+	} {
+		var result [][]int
+		for run := range runs {
+			result = append(result, slices.Clone(run))
+			if len(result) == 2 {
+				break
+			}
+		}
+		if !slices.EqualFunc(result, [][]int{{1}, {2}}, slices.Equal) {
+			t.Errorf("Expected [[1] [2]], got %v", result)
+		}
+	}
+}
+
+func BenchmarkMergeRuns(b *testing.B) {
+	inputs := make([][]int, 4)
+	for i := range inputs {
+		inputs[i] = make([]int, 100_000)
+		for j := range inputs[i] {
+			// runs of 1000 elements per source
+			inputs[i][j] = (j/1000*4+i)*1000 + j%1000
+		}
+	}
+	b.Run("Merge", func(b *testing.B) {
+		seqs := make([]iter.Seq[int], len(inputs))
+		for i, s := range inputs {
+			seqs[i] = slices.Values(s)
+		}
+		for b.Loop() {
+			for range Merge(cmp.Compare[int], seqs...) {
+			}
+		}
+	})
+	b.Run("MergeSliceRuns", func(b *testing.B) {
+		for b.Loop() {
+			for range MergeSliceRuns(cmp.Compare[int], inputs...) {
+			}
+		}
+	})
+}
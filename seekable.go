@@ -0,0 +1,54 @@
+package kway
+
+import (
+	"iter"
+	"slices"
+)
+
+// Seekable is a sorted input sequence that can be iterated from an arbitrary
+// position, by key. Implementations must be safe for concurrent use, and the
+// returned sequences must be independent of each other.
+type Seekable[T any] interface {
+	// All returns a sequence of all elements, in sorted order.
+	All() iter.Seq[T]
+	// Seek returns a sequence of the elements greater than or equal to key,
+	// in sorted order.
+	Seek(key T) iter.Seq[T]
+}
+
+// SortedSlice is a [Seekable] backed by a sorted slice.
+type SortedSlice[T any] struct {
+	cmp func(a, b T) int
+	s   []T
+}
+
+var _ Seekable[any] = (*SortedSlice[any])(nil)
+
+// NewSortedSlice returns a [SortedSlice] for `s`, which must be sorted
+// according to `cmp`. The slice is not copied, and must not be modified
+// while in use.
+func NewSortedSlice[T any](cmp func(a, b T) int, s []T) *SortedSlice[T] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	return &SortedSlice[T]{cmp: cmp, s: s}
+}
+
+// Len returns the number of elements.
+func (x *SortedSlice[T]) Len() int { return len(x.s) }
+
+// All returns a sequence of all elements.
+func (x *SortedSlice[T]) All() iter.Seq[T] { return slices.Values(x.s) }
+
+// Seek returns a sequence of the elements greater than or equal to key,
+// located by binary search.
+func (x *SortedSlice[T]) Seek(key T) iter.Seq[T] {
+	i, _ := slices.BinarySearchFunc(x.s, key, x.cmp)
+	return slices.Values(x.s[i:])
+}
+
+// Cursor returns a [SliceCursor] over all elements, which may be merged
+// without creating coroutines, see [MergeCursors].
+func (x *SortedSlice[T]) Cursor() *SliceCursor[T] {
+	return &SliceCursor[T]{cmp: x.cmp, s: x.s}
+}
@@ -0,0 +1,39 @@
+package kway
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestSortedSlice(t *testing.T) {
+	s := NewSortedSlice(cmp.Compare[int], []int{1, 3, 3, 5, 7})
+
+	if s.Len() != 5 {
+		t.Errorf("Expected Len() = 5, got %d", s.Len())
+	}
+	if v := collectSeq(s.All()); !slices.Equal(v, []int{1, 3, 3, 5, 7}) {
+		t.Errorf("Unexpected All(): %v", v)
+	}
+
+	for key, expected := range map[int][]int{
+		0: {1, 3, 3, 5, 7},
+		3: {3, 3, 5, 7},
+		4: {5, 7},
+		7: {7},
+		8: nil,
+	} {
+		if v := collectSeq(s.Seek(key)); !slices.Equal(v, expected) {
+			t.Errorf("Seek(%d): expected %v, got %v", key, expected, v)
+		}
+	}
+}
+
+func TestNewSortedSlice_NilCompareFunction(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil comparison function")
+		}
+	}()
+	_ = NewSortedSlice[int](nil, nil)
+}
@@ -0,0 +1,142 @@
+package kway
+
+import (
+	"iter"
+	"sync"
+)
+
+// Shard returns `n` sequences that together yield the elements of `seq`,
+// which is iterated at most once, distributing each element to exactly one
+// of them, e.g. for parallel consumers that each require sorted input. As
+// each returned sequence yields a subsequence of `seq`, in order, each is
+// sorted if `seq` is sorted.
+//
+// The returned sequences may be consumed concurrently, from separate
+// goroutines, and at most `size` elements are buffered for each. Elements are
+// distributed round-robin, skipping the turn of any consumer whose buffer is
+// full, or that has stopped, such that a slow consumer, or one that never
+// starts, does not block the others. Consequently, the distribution depends
+// on the relative pace of the consumers, and the sequences may also be
+// consumed one after another, from a single goroutine.
+//
+// Each returned sequence may only be iterated once. Elements buffered for a
+// consumer that stops early are discarded, and `seq` is stopped once every
+// consumer has stopped.
+func Shard[T any](seq iter.Seq[T], n int, size int) []iter.Seq[T] {
+	if n < 0 {
+		panic("kway: negative shard count")
+	}
+	if size < 1 {
+		panic("kway: shard buffer size must be positive")
+	}
+	if n == 0 {
+		return nil
+	}
+	x := &shardState[T]{
+		seq:    seq,
+		size:   size,
+		bufs:   make([][]T, n),
+		left:   make([]bool, n),
+		active: n,
+	}
+	x.cond.L = &x.mu
+	seqs := make([]iter.Seq[T], n)
+	for i := range seqs {
+		seqs[i] = func(yield func(T) bool) { x.consume(i, yield) }
+	}
+	return seqs
+}
+
+type shardState[T any] struct {
+	mu      sync.Mutex
+	cond    sync.Cond
+	seq     iter.Seq[T]
+	next    func() (T, bool)
+	stop    func()
+	bufs    [][]T // per consumer
+	left    []bool
+	turn    int // the consumer to receive the next element
+	size    int
+	active  int
+	pulling bool
+	done    bool
+}
+
+func (x *shardState[T]) consume(c int, yield func(T) bool) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.left[c] {
+		return
+	}
+	defer x.leave(c)
+	for {
+		if buf := x.bufs[c]; len(buf) != 0 {
+			v := buf[0]
+			buf[0] = *new(T)
+			x.bufs[c] = buf[1:]
+			x.mu.Unlock()
+			ok := yield(v)
+			x.mu.Lock()
+			if !ok {
+				return
+			}
+			continue
+		}
+		if x.done {
+			return
+		}
+		if x.pulling {
+			x.cond.Wait()
+			continue
+		}
+		x.pull()
+	}
+}
+
+// pull fetches the next element from the source, with the lock released
+// while doing so, and distributes it. As the buffer of the consumer pulling
+// is empty, there is always a consumer to receive it. Must be called with
+// the lock held.
+func (x *shardState[T]) pull() {
+	x.pulling = true
+	x.mu.Unlock()
+	var (
+		v  T
+		ok bool
+	)
+	defer func() {
+		x.mu.Lock()
+		x.pulling = false
+		if ok {
+			for range x.bufs {
+				c := x.turn
+				x.turn = (x.turn + 1) % len(x.bufs)
+				if !x.left[c] && len(x.bufs[c]) < x.size {
+					x.bufs[c] = append(x.bufs[c], v)
+					break
+				}
+			}
+		} else {
+			x.done = true
+		}
+		x.cond.Broadcast()
+	}()
+	if x.next == nil {
+		x.next, x.stop = iter.Pull(x.seq)
+	}
+	v, ok = x.next()
+}
+
+func (x *shardState[T]) leave(c int) {
+	x.left[c] = true
+	clear(x.bufs[c])
+	x.bufs[c] = nil
+	x.active--
+	x.cond.Broadcast()
+	if x.active == 0 {
+		x.done = true
+		if x.stop != nil {
+			x.stop()
+		}
+	}
+}
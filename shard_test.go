@@ -0,0 +1,136 @@
+package kway
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestShard_InvalidArguments(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		n, size int
+		msg     string
+	}{
+		{"negative count", -1, 1, "negative shard count"},
+		{"zero size", 2, 0, "buffer size must be positive"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("Expected panic")
+				} else if !strings.Contains(r.(string), tt.msg) {
+					t.Errorf("Expected panic message containing %q, got: %v", tt.msg, r)
+				}
+			}()
+			_ = Shard(sliceSeq([]int{1}), tt.n, tt.size)
+		})
+	}
+}
+
+func TestShard_Zero(t *testing.T) {
+	if seqs := Shard(sliceSeq([]int{1}), 0, 1); seqs != nil {
+		t.Errorf("Expected nil, got %v", seqs)
+	}
+}
+
+func TestShard_Concurrent(t *testing.T) {
+	input := make([]int, 1000)
+	for i := range input {
+		input[i] = i / 3
+	}
+	var iterations atomic.Int32
+	seq := func(yield func(int) bool) {
+		iterations.Add(1)
+		for _, v := range input {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+
+	seqs := Shard(seq, 4, 8)
+	results := make([][]int, len(seqs))
+	var wg sync.WaitGroup
+	for i, seq := range seqs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = collectSeq(seq)
+		}()
+	}
+	wg.Wait()
+
+	if n := iterations.Load(); n != 1 {
+		t.Errorf("Expected source to be iterated once, got %d", n)
+	}
+	var all []int
+	for i, result := range results {
+		if !slices.IsSorted(result) {
+			t.Errorf("Consumer %d: expected sorted elements, got %v", i, result)
+		}
+		all = append(all, result...)
+	}
+	slices.Sort(all)
+	if !slices.Equal(all, input) {
+		t.Errorf("Expected every element exactly once, got %v", all)
+	}
+
+	// sequences may only be iterated once
+	if result := collectSeq(seqs[0]); len(result) != 0 {
+		t.Errorf("Expected no elements on second iteration, got %v", result)
+	}
+}
+
+func TestShard_SkipsFullBuffers(t *testing.T) {
+	seqs := Shard(sliceSeq([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}), 3, 2)
+	// consumed one after another, the first takes the turns of the others,
+	// once their buffers are full
+	var results [][]int
+	for _, seq := range seqs {
+		results = append(results, collectSeq(seq))
+	}
+	expected := [][]int{{0, 3, 6, 7, 8, 9}, {1, 4}, {2, 5}}
+	if !slices.EqualFunc(results, expected, slices.Equal) {
+		t.Errorf("Expected %v, got %v", expected, results)
+	}
+}
+
+func TestShard_EarlyStop(t *testing.T) {
+	var stopped atomic.Bool
+	seq := func(yield func(int) bool) {
+		defer stopped.Store(true)
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	seqs := Shard(seq, 2, 4)
+
+	// a consumer that stops early no longer receives elements
+	for v := range seqs[0] {
+		if v == 2 {
+			break
+		}
+	}
+	if stopped.Load() {
+		t.Fatal("Expected source to remain active while a consumer remains")
+	}
+	var result []int
+	for v := range seqs[1] {
+		result = append(result, v)
+		if v == 10 {
+			break
+		}
+	}
+	if expected := []int{1, 3, 4, 5, 6, 7, 8, 9, 10}; !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+	if !stopped.Load() {
+		t.Error("Expected source to be stopped once every consumer stopped")
+	}
+}
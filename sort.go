@@ -0,0 +1,160 @@
+package kway
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"slices"
+)
+
+// SortSeq returns a sequence of the elements of `seq`, sorted per `cmp`,
+// which buffers every element, on each iteration, then sorts them, e.g. to
+// normalize an unsorted input, prior to merging it with sorted ones. The sort
+// is stable. See [SortSeqExternal] to sort inputs that may not fit in memory.
+func SortSeq[T any](cmp func(a, b T) int, seq iter.Seq[T]) iter.Seq[T] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	return func(yield func(T) bool) {
+		for _, v := range slices.SortedStableFunc(seq, cmp) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SortSeq2 is the [iter.Seq2] equivalent of [SortSeq], for [Merge2].
+func SortSeq2[T1 any, T2 any](cmp func(a1 T1, a2 T2, b1 T1, b2 T2) int, seq iter.Seq2[T1, T2]) iter.Seq2[T1, T2] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	return func(yield func(T1, T2) bool) {
+		var pairs []Pair[T1, T2]
+		for k, v := range seq {
+			pairs = append(pairs, Pair[T1, T2]{k, v})
+		}
+		slices.SortStableFunc(pairs, func(a, b Pair[T1, T2]) int { return cmp(a.Key, a.Value, b.Key, b.Value) })
+		for _, p := range pairs {
+			if !yield(p.Key, p.Value) {
+				return
+			}
+		}
+	}
+}
+
+// ExternalSort configures [SortSeqExternal].
+type ExternalSort[T any] struct {
+	// Threshold is the maximum number of elements sorted in memory, beyond
+	// which each sorted run of Threshold elements is spilled to a temporary
+	// file, with the runs merged once `seq` is exhausted.
+	Threshold int
+	// Dir is the directory of the temporary files, per [os.CreateTemp].
+	Dir string
+	// Encode writes an element to a spilled run.
+	Encode func(w io.Writer, v T) error
+	// Decode reads the next element written by Encode, returning [io.EOF],
+	// at the end of the run. The reader is buffered, and implements
+	// [io.ByteReader].
+	Decode func(r io.Reader) (T, error)
+}
+
+// SortSeqExternal returns a sequence of the elements of `seq`, sorted per
+// `cmp`, per [SortSeq], unless there are more than `ext.Threshold` elements,
+// in which case they are sorted externally, by spilling sorted runs to
+// temporary files, which are merged per [Merge], then removed once iteration
+// ends. Only the final run, and the current element of each spilled run, are
+// held in memory. The sort is stable. Keyed sequences may be sorted via
+// [PairsOf].
+//
+// Elements are yielded with a nil error. If spilling or reading a run fails,
+// the zero value is yielded with the error, and iteration stops.
+func SortSeqExternal[T any](cmp func(a, b T) int, seq iter.Seq[T], ext ExternalSort[T]) iter.Seq2[T, error] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	if ext.Threshold <= 0 {
+		panic("kway: sort threshold must be positive")
+	}
+	if ext.Encode == nil || ext.Decode == nil {
+		panic("kway: nil encode or decode function")
+	}
+	return func(yield func(T, error) bool) {
+		var (
+			buf   []T
+			files []*os.File
+		)
+		defer func() {
+			for _, f := range files {
+				_ = f.Close()
+				_ = os.Remove(f.Name())
+			}
+		}()
+		spill := func() error {
+			slices.SortStableFunc(buf, cmp)
+			f, err := os.CreateTemp(ext.Dir, "kway-sort-*")
+			if err != nil {
+				return err
+			}
+			files = append(files, f)
+			w := bufio.NewWriter(f)
+			for _, v := range buf {
+				if err := ext.Encode(w, v); err != nil {
+					return err
+				}
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			clear(buf)
+			buf = buf[:0]
+			_, err = f.Seek(0, io.SeekStart)
+			return err
+		}
+		for v := range seq {
+			if len(buf) == ext.Threshold {
+				if err := spill(); err != nil {
+					yield(*new(T), err)
+					return
+				}
+			}
+			buf = append(buf, v)
+		}
+		slices.SortStableFunc(buf, cmp)
+
+		var err error
+		seqs := make([]iter.Seq[T], 0, len(files)+1)
+		for _, f := range files {
+			r := bufio.NewReader(f)
+			seqs = append(seqs, func(yield func(T) bool) {
+				for {
+					v, e := ext.Decode(r)
+					if e != nil {
+						if !errors.Is(e, io.EOF) && err == nil {
+							err = e
+						}
+						return
+					}
+					if !yield(v) {
+						return
+					}
+				}
+			})
+		}
+		// the final run is the latest, preserving stability
+		seqs = append(seqs, slices.Values(buf))
+		for v := range Merge(cmp, seqs...) {
+			if err != nil {
+				break
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(*new(T), err)
+		}
+	}
+}
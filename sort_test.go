@@ -0,0 +1,186 @@
+package kway
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"testing"
+)
+
+type sortRecord struct {
+	key, seq int
+}
+
+func compareSortRecord(a, b sortRecord) int { return cmp.Compare(a.key, b.key) }
+
+func randomSortRecords(r *rand.Rand, n int) []sortRecord {
+	s := make([]sortRecord, n)
+	for i := range s {
+		s[i] = sortRecord{r.IntN(20), i}
+	}
+	return s
+}
+
+func sortRecordExternal(threshold int, dir string) ExternalSort[sortRecord] {
+	return ExternalSort[sortRecord]{
+		Threshold: threshold,
+		Dir:       dir,
+		Encode: func(w io.Writer, v sortRecord) error {
+			return binary.Write(w, binary.LittleEndian, [2]int64{int64(v.key), int64(v.seq)})
+		},
+		Decode: func(r io.Reader) (sortRecord, error) {
+			var v [2]int64
+			err := binary.Read(r, binary.LittleEndian, &v)
+			return sortRecord{int(v[0]), int(v[1])}, err
+		},
+	}
+}
+
+func TestSortSeq(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	input := randomSortRecords(r, 100)
+	expected := slices.SortedStableFunc(slices.Values(input), compareSortRecord)
+	if actual := collectSeq(SortSeq(compareSortRecord, slices.Values(input))); !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+	merged := collectSeq(Merge(cmp.Compare[int], SortSeq(cmp.Compare[int], slices.Values([]int{3, 1, 2})), slices.Values([]int{2, 4})))
+	if !slices.Equal(merged, []int{1, 2, 2, 3, 4}) {
+		t.Errorf("Expected [1 2 2 3 4], got %v", merged)
+	}
+	for v := range SortSeq(cmp.Compare[int], slices.Values([]int{3, 1, 2})) {
+		if v != 1 {
+			t.Errorf("Expected 1, got %v", v)
+		}
+		break
+	}
+}
+
+func TestSortSeq2(t *testing.T) {
+	keys, values := []int{3, 1, 3, 2, 1}, []string{"a", "b", "c", "d", "e"}
+	actualKeys, actualValues := collectSeq2(SortSeq2(func(a1 int, _ string, b1 int, _ string) int { return cmp.Compare(a1, b1) }, sliceSeq2(keys, values)))
+	if expected := []int{1, 1, 2, 3, 3}; !slices.Equal(actualKeys, expected) {
+		t.Errorf("Expected %v, got %v", expected, actualKeys)
+	}
+	if expected := []string{"b", "e", "d", "a", "c"}; !slices.Equal(actualValues, expected) {
+		t.Errorf("Expected %v, got %v", expected, actualValues)
+	}
+}
+
+func TestSortSeqExternal(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, tc := range []struct {
+		n, threshold int
+		runs         int
+	}{
+		{0, 1, 0},
+		{10, 10, 0},
+		{11, 10, 1},
+		{100, 7, 14},
+		{100, 1, 99},
+	} {
+		dir := t.TempDir()
+		input := randomSortRecords(r, tc.n)
+		expected := slices.SortedStableFunc(slices.Values(input), compareSortRecord)
+		var (
+			actual []sortRecord
+			runs   int
+		)
+		for v, err := range SortSeqExternal(compareSortRecord, slices.Values(input), sortRecordExternal(tc.threshold, dir)) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(actual) == 0 {
+				entries, _ := os.ReadDir(dir)
+				runs = len(entries)
+			}
+			actual = append(actual, v)
+		}
+		if !slices.Equal(actual, expected) {
+			t.Errorf("Expected %v, got %v", expected, actual)
+		}
+		if tc.n != 0 && runs != tc.runs {
+			t.Errorf("Expected %d spilled runs, got %d", tc.runs, runs)
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+			t.Errorf("Expected the runs to be removed, got %d", len(entries))
+		}
+	}
+}
+
+func TestSortSeqExternal_Errors(t *testing.T) {
+	input := slices.Values(randomSortRecords(rand.New(rand.NewPCG(1, 2)), 20))
+	expected := errors.New("failed")
+
+	ext := sortRecordExternal(5, t.TempDir())
+	ext.Encode = func(w io.Writer, v sortRecord) error { return expected }
+	for v, err := range SortSeqExternal(compareSortRecord, input, ext) {
+		if v != (sortRecord{}) || err != expected {
+			t.Errorf("Expected (zero, %v), got (%v, %v)", expected, v, err)
+		}
+	}
+
+	ext = sortRecordExternal(5, t.TempDir())
+	decode := ext.Decode
+	var decoded int
+	ext.Decode = func(r io.Reader) (sortRecord, error) {
+		if decoded++; decoded == 6 {
+			return sortRecord{}, expected
+		}
+		return decode(r)
+	}
+	var (
+		values int
+		errs   []error
+	)
+	for _, err := range SortSeqExternal(compareSortRecord, input, ext) {
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			values++
+		}
+	}
+	if len(errs) != 1 || errs[0] != expected {
+		t.Errorf("Expected [%v], got %v", expected, errs)
+	}
+	if values >= 20 {
+		t.Errorf("Expected iteration to stop, got %d elements", values)
+	}
+
+	ext = sortRecordExternal(5, "/nonexistent/directory")
+	for _, err := range SortSeqExternal(compareSortRecord, input, ext) {
+		if err == nil {
+			t.Error("Expected an error")
+		}
+	}
+}
+
+func TestSortSeq_Panics(t *testing.T) {
+	ext := sortRecordExternal(1, "")
+	for _, tc := range []struct {
+		f        func()
+		expected string
+	}{
+		{func() { SortSeq[int](nil, nil) }, "kway: nil comparison function"},
+		{func() { SortSeq2[int, int](nil, nil) }, "kway: nil comparison function"},
+		{func() { SortSeqExternal(nil, nil, ext) }, "kway: nil comparison function"},
+		{func() {
+			SortSeqExternal(compareSortRecord, nil, ExternalSort[sortRecord]{Encode: ext.Encode, Decode: ext.Decode})
+		}, "kway: sort threshold must be positive"},
+		{func() {
+			SortSeqExternal(compareSortRecord, nil, ExternalSort[sortRecord]{Threshold: 1, Encode: ext.Encode})
+		}, "kway: nil encode or decode function"},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != tc.expected {
+					t.Errorf("Expected %v, got %v", tc.expected, r)
+				}
+			}()
+			tc.f()
+		}()
+	}
+}
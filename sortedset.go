@@ -0,0 +1,107 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"strings"
+)
+
+// ScoredMember is an element of a sorted set, as in Redis, i.e. a member
+// with a score. See [MergeScored] and [UnionScored].
+type ScoredMember struct {
+	Member string
+	Score  float64
+}
+
+// CompareScored orders sorted set elements by score, then by member, as
+// Redis does, for use with [Merge].
+func CompareScored(a, b ScoredMember) int {
+	if c := cmp.Compare(a.Score, b.Score); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Member, b.Member)
+}
+
+// compareMembers orders sorted set elements by member.
+func compareMembers(a, b ScoredMember) int { return strings.Compare(a.Member, b.Member) }
+
+// MergeScored performs a k-way merge of the provided sorted sets, each
+// sorted per [CompareScored], e.g. as returned by ZRANGE with WITHSCORES.
+// Members present in more than one set are yielded once per set, see
+// [UnionScored] for aggregation.
+func MergeScored(seqs ...iter.Seq[ScoredMember]) iter.Seq[ScoredMember] {
+	return Merge(CompareScored, seqs...)
+}
+
+// Aggregate determines how [UnionScored] combines the scores of a member
+// present in more than one sorted set, as for the AGGREGATE option of
+// ZUNIONSTORE.
+type Aggregate int
+
+const (
+	// AggregateSum sums the scores.
+	AggregateSum Aggregate = iota
+	// AggregateMin takes the minimum score.
+	AggregateMin
+	// AggregateMax takes the maximum score.
+	AggregateMax
+)
+
+// UnionScored performs a k-way merge of the provided sorted sets, yielding
+// each member once, with the scores of the sets containing it, each
+// multiplied by the set's weight, combined per `agg`, i.e. a streaming
+// ZUNIONSTORE. A nil `weights` weighs each set 1, as does the default of
+// ZUNIONSTORE, otherwise it must have a weight for each set. As for Redis, a
+// sum that is not a number (e.g. of positive and negative infinity) is 0.
+//
+// Unlike [MergeScored], the sets must each be sorted by member, so that the
+// occurrences of each member are adjacent, and the members are yielded in
+// that order. The result may be ordered by score using [CompareScored], once
+// aggregated.
+func UnionScored(agg Aggregate, weights []float64, seqs ...iter.Seq[ScoredMember]) iter.Seq[ScoredMember] {
+	if agg < AggregateSum || agg > AggregateMax {
+		panic("kway: invalid aggregate")
+	}
+	if weights != nil && len(weights) != len(seqs) {
+		panic("kway: weights length mismatch")
+	}
+	merged := MergeIndexed(compareMembers, seqs...)
+	return func(yield func(ScoredMember) bool) {
+		var (
+			v  ScoredMember
+			ok bool
+		)
+		for i, e := range merged {
+			if weights != nil {
+				e.Score *= weights[i]
+			}
+			if !ok || v.Member != e.Member {
+				if ok && !yield(v.aggregated(agg)) {
+					return
+				}
+				v, ok = e, true
+				continue
+			}
+			switch agg {
+			case AggregateSum:
+				v.Score += e.Score
+			case AggregateMin:
+				v.Score = min(v.Score, e.Score)
+			case AggregateMax:
+				v.Score = max(v.Score, e.Score)
+			}
+		}
+		if ok {
+			yield(v.aggregated(agg))
+		}
+	}
+}
+
+// aggregated returns x, with a sum that is not a number replaced by 0.
+func (x ScoredMember) aggregated(agg Aggregate) ScoredMember {
+	if agg == AggregateSum && math.IsNaN(x.Score) {
+		x.Score = 0
+	}
+	return x
+}
@@ -0,0 +1,130 @@
+package kway
+
+import (
+	"iter"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestCompareScored(t *testing.T) {
+	tests := []struct {
+		a, b     ScoredMember
+		expected int
+	}{
+		{ScoredMember{"b", 1}, ScoredMember{"a", 2}, -1},
+		{ScoredMember{"b", 1}, ScoredMember{"a", 1}, 1},
+		{ScoredMember{"a", 1}, ScoredMember{"a", 1}, 0},
+		{ScoredMember{"a", math.Inf(-1)}, ScoredMember{"a", -1e300}, -1},
+	}
+	for _, tt := range tests {
+		if result := CompareScored(tt.a, tt.b); result != tt.expected {
+			t.Errorf("CompareScored(%v, %v): Expected %d, got %d", tt.a, tt.b, tt.expected, result)
+		}
+	}
+}
+
+func TestMergeScored(t *testing.T) {
+	a := []ScoredMember{{"x", 1}, {"y", 3}}
+	b := []ScoredMember{{"a", 1}, {"x", 2}, {"z", 4}}
+	expected := []ScoredMember{{"a", 1}, {"x", 1}, {"x", 2}, {"y", 3}, {"z", 4}}
+	if result := collectSeq(MergeScored(slices.Values(a), slices.Values(b))); !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestUnionScored(t *testing.T) {
+	sets := [][]ScoredMember{
+		{{"a", 1}, {"b", 2}, {"c", 3}},
+		{{"b", 10}, {"d", 4}},
+		{{"a", -5}, {"d", 1}},
+	}
+	tests := []struct {
+		name     string
+		agg      Aggregate
+		weights  []float64
+		expected []ScoredMember
+	}{
+		{
+			name:     "sum",
+			agg:      AggregateSum,
+			expected: []ScoredMember{{"a", -4}, {"b", 12}, {"c", 3}, {"d", 5}},
+		},
+		{
+			name:     "min",
+			agg:      AggregateMin,
+			expected: []ScoredMember{{"a", -5}, {"b", 2}, {"c", 3}, {"d", 1}},
+		},
+		{
+			name:     "max",
+			agg:      AggregateMax,
+			expected: []ScoredMember{{"a", 1}, {"b", 10}, {"c", 3}, {"d", 4}},
+		},
+		{
+			name:     "weighted sum",
+			agg:      AggregateSum,
+			weights:  []float64{2, 1, 0.5},
+			expected: []ScoredMember{{"a", -0.5}, {"b", 14}, {"c", 6}, {"d", 4.5}},
+		},
+		{
+			name:     "weighted max",
+			agg:      AggregateMax,
+			weights:  []float64{1, -1, 1},
+			expected: []ScoredMember{{"a", 1}, {"b", 2}, {"c", 3}, {"d", 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seqs []iter.Seq[ScoredMember]
+			for _, s := range sets {
+				seqs = append(seqs, slices.Values(s))
+			}
+			if result := collectSeq(UnionScored(tt.agg, tt.weights, seqs...)); !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestUnionScored_nan(t *testing.T) {
+	a := slices.Values([]ScoredMember{{"a", math.Inf(1)}})
+	b := slices.Values([]ScoredMember{{"a", math.Inf(-1)}})
+	if result := collectSeq(UnionScored(AggregateSum, nil, a, b)); !slices.Equal(result, []ScoredMember{{"a", 0}}) {
+		t.Errorf("Expected [{a 0}], got %v", result)
+	}
+}
+
+func TestUnionScored_earlyExit(t *testing.T) {
+	a := slices.Values([]ScoredMember{{"a", 1}, {"b", 1}, {"c", 1}})
+	var result []ScoredMember
+	for v := range UnionScored(AggregateSum, nil, a, a) {
+		result = append(result, v)
+		if len(result) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(result, []ScoredMember{{"a", 2}, {"b", 2}}) {
+		t.Errorf("Expected [{a 2} {b 2}], got %v", result)
+	}
+}
+
+func TestUnionScored_panics(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		f     func()
+		panic string
+	}{
+		{"invalid aggregate", func() { UnionScored(3, nil) }, "kway: invalid aggregate"},
+		{"weights mismatch", func() { UnionScored(AggregateSum, []float64{1}) }, "kway: weights length mismatch"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tt.panic {
+					t.Errorf("Expected %q, got %v", tt.panic, r)
+				}
+			}()
+			tt.f()
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package kway
+
+import (
+	"iter"
+	"slices"
+)
+
+// SplitRanges splits `seq`, which must be sorted, into len(bounds)+1
+// consecutive ranges, per [Merger.MergePartitioned], yielding the index of
+// each range, with a sequence of its elements, e.g. to redistribute a merged
+// dataset into sorted partitions, when re-sharding. See [SampleBounds] to
+// derive bounds from a sample of the data.
+//
+// Every range is yielded, in order, including those that are empty, such
+// that each output may be created in turn. As `seq` is read once, without
+// buffering, the elements of each range are only available until the next
+// range is yielded, with any not consumed being skipped. Iterating a range
+// again, while it is current, resumes after the elements already yielded.
+func SplitRanges[T any](cmp func(a, b T) int, bounds []T, seq iter.Seq[T]) iter.Seq2[int, iter.Seq[T]] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	if !slices.IsSortedFunc(bounds, cmp) {
+		panic("kway: partition bounds must be sorted")
+	}
+	return func(yield func(int, iter.Seq[T]) bool) {
+		next, stop := iter.Pull(seq)
+		defer stop()
+		v, ok := next()
+		// within returns whether the current element belongs to range i
+		within := func(i int) bool {
+			return ok && (i == len(bounds) || cmp(v, bounds[i]) < 0)
+		}
+		current := 0
+		for i := 0; i <= len(bounds); i++ {
+			current = i
+			elements := func(yield func(T) bool) {
+				for current == i && within(i) {
+					e := v
+					v, ok = next()
+					if !yield(e) {
+						return
+					}
+				}
+			}
+			if !yield(i, elements) {
+				return
+			}
+			current = -1
+			for within(i) {
+				v, ok = next()
+			}
+		}
+	}
+}
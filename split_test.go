@@ -0,0 +1,104 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestSplitRanges(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 50 {
+		values := make([]int, r.IntN(100))
+		for i := range values {
+			values[i] = r.IntN(50)
+		}
+		slices.Sort(values)
+		bounds := SampleBounds(cmp.Compare[int], 1+r.IntN(6), values)
+		if r.IntN(4) == 0 {
+			bounds = append(bounds, 100)
+		}
+		var (
+			indexes []int
+			actual  []int
+		)
+		for i, elements := range SplitRanges(cmp.Compare[int], bounds, slices.Values(values)) {
+			indexes = append(indexes, i)
+			for v := range elements {
+				if i > 0 && v < bounds[i-1] || i < len(bounds) && v >= bounds[i] {
+					t.Errorf("Expected %v outside range %d of %v", v, i, bounds)
+				}
+				actual = append(actual, v)
+			}
+		}
+		if len(indexes) != len(bounds)+1 {
+			t.Errorf("Expected %d ranges, got %v", len(bounds)+1, indexes)
+		}
+		if !slices.Equal(actual, values) {
+			t.Errorf("Expected %v, got %v", values, actual)
+		}
+	}
+}
+
+func TestSplitRanges_Partial(t *testing.T) {
+	values := slices.Values([]int{1, 2, 3, 5, 6, 7, 9, 10})
+	var actual [][]int
+	for i, elements := range SplitRanges(cmp.Compare[int], []int{0, 5, 8, 8}, values) {
+		var r []int
+		for v := range elements {
+			r = append(r, v)
+			if i == 1 && v == 2 {
+				break
+			}
+		}
+		if i == 2 {
+			// resumes after the elements already yielded
+			for v := range elements {
+				r = append(r, v)
+				break
+			}
+			r = append(r, collectSeq(elements)...)
+		}
+		actual = append(actual, r)
+		if i == 3 {
+			break
+		}
+	}
+	expected := [][]int{nil, {1, 2}, {5, 6, 7}, nil}
+	if !slices.EqualFunc(actual, expected, slices.Equal) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+}
+
+func TestSplitRanges_Stale(t *testing.T) {
+	var ranges []iter.Seq[int]
+	for _, elements := range SplitRanges(cmp.Compare[int], []int{2}, slices.Values([]int{1, 2, 3})) {
+		ranges = append(ranges, elements)
+	}
+	for i, elements := range ranges {
+		if actual := collectSeq(elements); len(actual) != 0 {
+			t.Errorf("Expected range %d to be empty, got %v", i, actual)
+		}
+	}
+}
+
+func TestSplitRanges_Panics(t *testing.T) {
+	for _, tc := range []struct {
+		f        func()
+		expected string
+	}{
+		{func() { SplitRanges(nil, nil, slices.Values([]int{})) }, "kway: nil comparison function"},
+		{func() { SplitRanges(cmp.Compare[int], []int{2, 1}, slices.Values([]int{})) }, "kway: partition bounds must be sorted"},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != tc.expected {
+					t.Errorf("Expected %v, got %v", tc.expected, r)
+				}
+			}()
+			tc.f()
+		}()
+	}
+}
@@ -0,0 +1,66 @@
+package kway
+
+// Stats are statistics about a merge, collected by [WithStats].
+type Stats struct {
+	// Comparisons is the number of times the comparison function was called
+	// by the merge.
+	Comparisons int64
+	// Moves is the number of times the engine moved an element, between
+	// nodes, i.e. swaps within a heap, or exchanges with the losers cached
+	// by [StrategyLoserTree]. Together with Comparisons, it indicates the
+	// cost of the engine, e.g. to choose between strategies.
+	Moves int64
+	// Yielded is the total number of elements yielded by the merge.
+	Yielded int64
+	// Sources are the statistics for each input sequence, by index.
+	Sources []SourceStats
+}
+
+// SourceStats are statistics about a single input sequence of a merge.
+type SourceStats struct {
+	// Pulled is the number of elements pulled from the input sequence.
+	Pulled int64
+	// Yielded is the number of elements from the input sequence that were
+	// yielded by the merge.
+	Yielded int64
+	// Exhausted indicates that the input sequence ended, i.e. it had no more
+	// elements, as opposed to being stopped, or not iterated.
+	Exhausted bool
+	// ExhaustedAt is the value of [Stats.Yielded], at the time [Exhausted]
+	// was set, i.e. the number of elements that had been yielded by the merge.
+	ExhaustedAt int64
+}
+
+// WithStats enables collection of statistics about each merge, into `stats`,
+// which is reset at the start of each iteration of the merged sequence. The
+// statistics may be read during iteration, from the same goroutine, or after
+// iteration. Concurrent iteration of merges sharing the same `stats` is not
+// supported.
+func WithStats(stats *Stats) Option {
+	if stats == nil {
+		panic("kway: nil stats")
+	}
+	return func(o *options) {
+		o.stats = stats
+	}
+}
+
+func statsNext[E any](stats *Stats, source *SourceStats, next func() (E, bool)) func() (E, bool) {
+	return func() (E, bool) {
+		v, ok := next()
+		if ok {
+			source.Pulled++
+		} else {
+			source.Exhausted = true
+			source.ExhaustedAt = stats.Yielded
+		}
+		return v, ok
+	}
+}
+
+func statsCompare[E any](stats *Stats, cmp func(a, b E) int) func(a, b E) int {
+	return func(a, b E) int {
+		stats.Comparisons++
+		return cmp(a, b)
+	}
+}
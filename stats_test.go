@@ -0,0 +1,109 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestWithStats(t *testing.T) {
+	var stats Stats
+	m := NewMerger(cmp.Compare[int], WithStats(&stats))
+
+	result := collectSeq(m.Merge(
+		sliceSeq([]int{1, 2}),
+		nil,
+		sliceSeq([]int{3, 4, 5}),
+	))
+	if !slices.Equal(result, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Expected [1 2 3 4 5], got %v", result)
+	}
+
+	if stats.Yielded != 5 {
+		t.Errorf("Expected 5 yielded, got %d", stats.Yielded)
+	}
+	if stats.Comparisons == 0 {
+		t.Error("Expected comparisons to be counted")
+	}
+	expected := []SourceStats{
+		{Pulled: 2, Yielded: 2, Exhausted: true, ExhaustedAt: 2},
+		{},
+		{Pulled: 3, Yielded: 3, Exhausted: true, ExhaustedAt: 5},
+	}
+	if !slices.Equal(stats.Sources, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, stats.Sources)
+	}
+}
+
+func TestWithStats_EarlyTermination(t *testing.T) {
+	var stats Stats
+	m := NewMerger(cmp.Compare[int], WithStats(&stats))
+
+	var result []int
+	for v := range m.Merge(sliceSeq([]int{1, 3, 5}), sliceSeq([]int{2, 4})) {
+		result = append(result, v)
+		if len(result) == 2 {
+			break
+		}
+	}
+
+	expected := Stats{
+		Comparisons: stats.Comparisons,
+		Yielded:     2,
+		Sources: []SourceStats{
+			{Pulled: 2, Yielded: 1},
+			{Pulled: 1, Yielded: 1},
+		},
+	}
+	if stats.Yielded != expected.Yielded || !slices.Equal(stats.Sources, expected.Sources) {
+		t.Errorf("Expected %+v, got %+v", expected, stats)
+	}
+
+	// reset per iteration
+	_ = collectSeq(m.Merge(sliceSeq([]int{1})))
+	if stats.Yielded != 1 || len(stats.Sources) != 1 || stats.Sources[0] != (SourceStats{Pulled: 1, Yielded: 1, Exhausted: true, ExhaustedAt: 1}) {
+		t.Errorf("Expected stats to be reset, got %+v", stats)
+	}
+}
+
+func TestWithStats_Moves(t *testing.T) {
+	for _, strategy := range []Strategy{StrategyHeap, StrategyLoserTree, StrategyHeap4} {
+		t.Run(strategy.String(), func(t *testing.T) {
+			var stats Stats
+			m := NewMerger(cmp.Compare[int], WithStats(&stats), WithStrategy(strategy))
+
+			_ = collectSeq(m.Merge(sliceSeq([]int{1, 2, 3})))
+			if stats.Moves != 0 {
+				t.Errorf("Expected no moves for a single source, got %d", stats.Moves)
+			}
+
+			seqs := make([]iter.Seq[int], 8)
+			for i := range seqs {
+				seqs[i] = sliceSeq([]int{i, i + 8, i + 16})
+			}
+			_ = collectSeq(m.Merge(seqs...))
+			if stats.Moves == 0 || stats.Moves > stats.Comparisons {
+				t.Errorf("Expected moves to be counted, got %d, with %d comparisons", stats.Moves, stats.Comparisons)
+			}
+		})
+	}
+}
+
+func TestWithStats_Merger2(t *testing.T) {
+	var stats Stats
+	m := NewMerger2(func(a1 int, a2 string, b1 int, b2 string) int { return cmp.Compare(a1, b1) }, WithStats(&stats))
+	_, _ = collectSeq2(m.Merge(sliceSeq2([]int{1, 3}, []string{"a", "c"}), sliceSeq2([]int{2}, []string{"b"})))
+	if stats.Yielded != 3 || stats.Sources[0].Yielded != 2 || stats.Sources[1].Yielded != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestWithStats_Nil(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil stats")
+		}
+	}()
+	_ = WithStats(nil)
+}
@@ -0,0 +1,54 @@
+package kway
+
+import (
+	"fmt"
+)
+
+// Strategy selects the engine used by a [Merger] to order the current
+// elements of its sources, see [WithStrategy]. All strategies produce the
+// same output, differing only in performance.
+type Strategy int
+
+const (
+	// StrategyHeap uses a binary heap, which costs up to two comparisons per
+	// level, i.e. about 2·log2(k) per element, and is the default.
+	StrategyHeap Strategy = iota
+	// StrategyLoserTree uses a tournament tree of losers, which, after
+	// yielding an element, replays only the path from the refilled source to
+	// the root, comparing against the loser cached at each node, costing
+	// about log2(k) comparisons per element. It is preferable for expensive
+	// comparison functions, such as those comparing long strings, or
+	// multi-field structs.
+	StrategyLoserTree
+	// StrategyHeap4 uses a 4-ary heap, which has half the levels of a binary
+	// heap, with the children of each node adjacent in memory, and replaces
+	// the top element in place, in a single pass. It costs about as many
+	// comparisons as [StrategyHeap], with fewer memory accesses, and
+	// interface calls, so is typically faster, particularly for large k.
+	StrategyHeap4
+)
+
+func (x Strategy) String() string {
+	switch x {
+	case StrategyHeap:
+		return "binary heap"
+	case StrategyLoserTree:
+		return "loser tree"
+	case StrategyHeap4:
+		return "4-ary heap"
+	}
+	return fmt.Sprintf("Strategy(%d)", int(x))
+}
+
+// WithStrategy configures the engine used by the Merger's merges. The
+// default is [StrategyHeap].
+func WithStrategy(strategy Strategy) Option {
+	switch strategy {
+	case StrategyHeap, StrategyLoserTree, StrategyHeap4:
+	default:
+		panic("kway: invalid strategy")
+	}
+	return func(o *options) {
+		o.strategy = strategy
+	}
+}
@@ -0,0 +1,142 @@
+package kway
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWithStrategy(t *testing.T) {
+	type item struct{ key, src int }
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, k := range []int{0, 1, 2, 3, 5, 8, 13} {
+		t.Run(fmt.Sprint(k), func(t *testing.T) {
+			inputs := make([][]item, k)
+			for i := range inputs {
+				for range r.IntN(50) {
+					inputs[i] = append(inputs[i], item{r.IntN(20), i})
+				}
+				slices.SortFunc(inputs[i], func(a, b item) int { return cmp.Compare(a.key, b.key) })
+			}
+			compare := func(a, b item) int { return cmp.Compare(a.key, b.key) }
+			seqs := func() []iter.Seq[item] {
+				seqs := make([]iter.Seq[item], k)
+				for i, s := range inputs {
+					if i != 1 {
+						seqs[i] = slices.Values(s)
+					}
+				}
+				return seqs
+			}
+			expected := collectSeq(NewMerger(compare).Merge(seqs()...))
+			for _, strategy := range []Strategy{StrategyLoserTree, StrategyHeap4} {
+				var stats Stats
+				actual := collectSeq(NewMerger(compare, WithStrategy(strategy), WithStats(&stats)).Merge(seqs()...))
+				if !slices.Equal(actual, expected) {
+					t.Errorf("%s: Expected %v, got %v", strategy, expected, actual)
+				}
+				if stats.Yielded != int64(len(expected)) {
+					t.Errorf("%s: Expected %d, got %d", strategy, len(expected), stats.Yielded)
+				}
+			}
+		})
+	}
+}
+
+func TestWithStrategy_comparisons(t *testing.T) {
+	const k = 64
+	seqs := make([]iter.Seq[int], k)
+	for i := range seqs {
+		seqs[i] = func(yield func(int) bool) {
+			for v := i; v < k*100; v += k {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+	var heapStats, treeStats Stats
+	expected := collectSeq(NewMerger(cmp.Compare[int], WithStats(&heapStats)).Merge(seqs...))
+	actual := collectSeq(NewMerger(cmp.Compare[int], WithStats(&treeStats), WithStrategy(StrategyLoserTree)).Merge(seqs...))
+	if !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+	// about log2(k) = 6 comparisons per element, rather than up to 12
+	if n := int64(len(expected)); treeStats.Comparisons > 7*n || treeStats.Comparisons >= heapStats.Comparisons {
+		t.Errorf("Expected fewer comparisons, got %d vs %d, for %d elements", treeStats.Comparisons, heapStats.Comparisons, n)
+	}
+}
+
+func TestWithStrategy_merge2(t *testing.T) {
+	compare := func(a1 int, a2 string, b1 int, b2 string) int { return cmp.Compare(a1, b1) }
+	seqs := []iter.Seq2[int, string]{
+		sliceSeq2([]int{1, 3, 5}, []string{"a", "b", "c"}),
+		sliceSeq2([]int{1, 2, 5}, []string{"d", "e", "f"}),
+	}
+	k, v := collectSeq2(NewMerger2(compare, WithStrategy(StrategyLoserTree)).Merge(seqs...))
+	if expected := []int{1, 1, 2, 3, 5, 5}; !slices.Equal(k, expected) {
+		t.Errorf("Expected %v, got %v", expected, k)
+	}
+	if expected := []string{"a", "d", "e", "b", "c", "f"}; !slices.Equal(v, expected) {
+		t.Errorf("Expected %v, got %v", expected, v)
+	}
+}
+
+func TestWithStrategy_invalid(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "kway: invalid strategy" {
+			t.Errorf("Expected panic, got %v", r)
+		}
+	}()
+	WithStrategy(Strategy(-1))
+}
+
+func TestStrategy_String(t *testing.T) {
+	for s, expected := range map[Strategy]string{
+		StrategyHeap:      "binary heap",
+		StrategyLoserTree: "loser tree",
+		StrategyHeap4:     "4-ary heap",
+		Strategy(99):      "Strategy(99)",
+	} {
+		if actual := s.String(); actual != expected {
+			t.Errorf("Expected %q, got %q", expected, actual)
+		}
+	}
+}
+
+func BenchmarkWithStrategy(b *testing.B) {
+	// long common prefixes make the comparison expensive
+	prefix := strings.Repeat("x", 256)
+	b.Run("strings", func(b *testing.B) {
+		benchmarkStrategies(b, strings.Compare, func(i int) string { return fmt.Sprintf("%s%08d", prefix, i) })
+	})
+	b.Run("ints", func(b *testing.B) { benchmarkStrategies(b, cmp.Compare[int], func(i int) int { return i }) })
+}
+
+func benchmarkStrategies[T any](b *testing.B, compare func(a, b T) int, value func(i int) T) {
+	for _, k := range []int{4, 16, 64, 256, 1024} {
+		inputs := make([][]T, k)
+		for i := range inputs {
+			for j := range 1 << 14 / k {
+				inputs[i] = append(inputs[i], value(j*k+i))
+			}
+		}
+		cursors := make([]Cursor[T], k)
+		for _, strategy := range []Strategy{StrategyHeap, StrategyLoserTree, StrategyHeap4} {
+			m := NewMerger(compare, WithStrategy(strategy))
+			b.Run(fmt.Sprintf("k=%d/%s", k, strategy), func(b *testing.B) {
+				for b.Loop() {
+					for i, s := range inputs {
+						cursors[i] = NewSortedSlice(compare, s).Cursor()
+					}
+					for range m.MergeCursors(cursors...) {
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package kway
+
+// Summary is a summary of the elements yielded by a merge, accumulated as
+// they are consumed, see [WithSummary].
+type Summary[T any] struct {
+	// Count is the number of elements yielded.
+	Count int64
+	// Min and Max are the first and last elements yielded, i.e. the least
+	// and greatest, as the output is sorted, if Count is non-zero.
+	Min, Max T
+	// Accumulators are additionally applied to each element yielded, e.g.
+	// [Fold], or [Sum].
+	Accumulators []Accumulator[T]
+}
+
+// Accumulator accumulates the elements yielded by a merge, see [Summary].
+type Accumulator[T any] interface {
+	// Reset resets the accumulator, at the start of each iteration.
+	Reset()
+	// Add accumulates an element.
+	Add(v T)
+}
+
+// Fold is an [Accumulator], which folds each element into Value, using
+// Func, starting from Init.
+type Fold[T any, A any] struct {
+	Init  A
+	Func  func(acc A, v T) A
+	Value A
+}
+
+// Reset sets Value to Init.
+func (x *Fold[T, A]) Reset() { x.Value = x.Init }
+
+// Add sets Value to the result of Func.
+func (x *Fold[T, A]) Add(v T) { x.Value = x.Func(x.Value, v) }
+
+// Number is a constraint permitting any integer or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Sum returns a [Fold], summing the values returned by `value`, for each
+// element.
+func Sum[T any, N Number](value func(v T) N) *Fold[T, N] {
+	if value == nil {
+		panic("kway: nil value function")
+	}
+	return &Fold[T, N]{Func: func(acc N, v T) N { return acc + value(v) }}
+}
+
+// WithSummary enables accumulation of a summary of the elements yielded by
+// each merge, into `summary`, which is reset at the start of each iteration
+// of the merged sequence. The element type of `summary` must match that of
+// the [Merger], or be a [Pair] of the types of the [Merger2]. As for
+// [WithStats], the summary may be read during iteration, from the same
+// goroutine, or after iteration, and concurrent iteration of merges sharing
+// the same `summary` is not supported.
+func WithSummary[T any](summary *Summary[T]) Option {
+	if summary == nil {
+		panic("kway: nil summary")
+	}
+	return func(o *options) {
+		o.summary = summary
+	}
+}
+
+func (x *Summary[T]) reset() {
+	x.Count = 0
+	x.Min, x.Max = *new(T), *new(T)
+	for _, a := range x.Accumulators {
+		a.Reset()
+	}
+}
+
+func (x *Summary[T]) add(v T) {
+	if x.Count == 0 {
+		x.Min = v
+	}
+	x.Max = v
+	x.Count++
+	for _, a := range x.Accumulators {
+		a.Add(v)
+	}
+}
+
+// summarizer accumulates a summary of the elements yielded by a merge.
+type summarizer[E any] interface {
+	reset()
+	add(v E)
+}
+
+// wrappedSummary is the summarizer of the wrapped elements of a merge.
+type wrappedSummary[E any, T any] struct {
+	summary *Summary[T]
+	value   func(v E) T
+}
+
+func (x wrappedSummary[E, T]) reset() { x.summary.reset() }
+
+func (x wrappedSummary[E, T]) add(v E) { x.summary.add(x.value(v)) }
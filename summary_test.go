@@ -0,0 +1,98 @@
+package kway
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWithSummary(t *testing.T) {
+	sum := Sum(func(v string) int { return len(v) })
+	longest := &Fold[string, string]{Func: func(acc string, v string) string {
+		if len(v) > len(acc) {
+			return v
+		}
+		return acc
+	}}
+	summary := Summary[string]{Accumulators: []Accumulator[string]{sum, longest}}
+	m := NewMerger(strings.Compare, WithSummary(&summary))
+	seq := m.Merge(slices.Values([]string{"b", "dddd"}), slices.Values([]string{"a", "cc", "e"}))
+
+	for range 2 {
+		if actual, expected := collectSeq(seq), []string{"a", "b", "cc", "dddd", "e"}; !slices.Equal(actual, expected) {
+			t.Errorf("Expected %v, got %v", expected, actual)
+		}
+		if summary.Count != 5 || summary.Min != "a" || summary.Max != "e" {
+			t.Errorf("Unexpected summary: %+v", summary)
+		}
+		if sum.Value != 9 {
+			t.Errorf("Expected sum 9, got %d", sum.Value)
+		}
+		if longest.Value != "dddd" {
+			t.Errorf("Expected dddd, got %q", longest.Value)
+		}
+	}
+
+	// stopping early summarizes the elements yielded
+	for v := range seq {
+		if v == "b" {
+			break
+		}
+	}
+	if summary.Count != 2 || summary.Min != "a" || summary.Max != "b" || sum.Value != 2 {
+		t.Errorf("Unexpected summary: %+v, sum %d", summary, sum.Value)
+	}
+
+	for range m.Merge(slices.Values([]string(nil))) {
+	}
+	if summary.Count != 0 || summary.Min != "" || summary.Max != "" || sum.Value != 0 || longest.Value != "" {
+		t.Errorf("Expected the summary to be reset, got %+v", summary)
+	}
+}
+
+func TestWithSummary_Merger2(t *testing.T) {
+	var summary Summary[Pair[string, int]]
+	m := NewMerger2(func(a1 string, a2 int, b1 string, b2 int) int { return cmp.Compare(a2, b2) }, WithSummary(&summary))
+	for range m.Merge(FromPairs(slices.Values([]Pair[string, int]{{"a", 1}, {"c", 3}})), FromPairs(slices.Values([]Pair[string, int]{{"b", 2}}))) {
+	}
+	if summary.Count != 3 || summary.Min != (Pair[string, int]{"a", 1}) || summary.Max != (Pair[string, int]{"c", 3}) {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+}
+
+func TestWithSummary_MergePartitioned(t *testing.T) {
+	sum := Sum(func(v int) int64 { return int64(v) })
+	summary := Summary[int]{Accumulators: []Accumulator[int]{sum}}
+	src := NewSortedSlice(cmp.Compare[int], []int{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	m := NewMerger(cmp.Compare[int], WithSummary(&summary), WithParallelism(2))
+	for range m.MergePartitioned([]int{3, 6}, src, src) {
+	}
+	if summary.Count != 18 || summary.Min != 1 || summary.Max != 9 || sum.Value != 90 {
+		t.Errorf("Unexpected summary: %+v, sum %d", summary, sum.Value)
+	}
+}
+
+func TestWithSummary_Panics(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		f       func()
+		message string
+	}{
+		{"nil", func() { WithSummary[int](nil) }, "kway: nil summary"},
+		{"mismatch", func() { NewMerger(cmp.Compare[int], WithSummary(new(Summary[string]))) }, "kway: summary element type mismatch"},
+		{"mismatch2", func() {
+			NewMerger2(func(a1, a2, b1, b2 int) int { return 0 }, WithSummary(new(Summary[int])))
+		}, "kway: summary element type mismatch"},
+		{"sum", func() { Sum[int, int](nil) }, "kway: nil value function"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tc.message {
+					t.Errorf("Expected %q, got %v", tc.message, r)
+				}
+			}()
+			tc.f()
+		})
+	}
+}
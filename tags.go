@@ -0,0 +1,111 @@
+package kway
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ComparatorFor returns a [Comparator] for T, a struct or pointer to struct,
+// derived from the `kway` tags of its fields, such that types may declare
+// their natural order alongside their definition, e.g.
+//
+//	type Event struct {
+//		Tenant string    `kway:"tenant,asc,1"`
+//		Time   time.Time `kway:"time,desc,2"`
+//	}
+//
+// Each tag has the form "name,order,priority", where the name, which is
+// optional, names the field for [ByFields], the order, which defaults to
+// "asc", is "asc" or "desc", and the priority, which defaults to 0, orders
+// the keys, with ties in the order the fields are declared. The tag "-"
+// excludes a field, as for an untagged field. Fields of embedded structs are
+// included, and are subject to the constraints of [ByFields].
+//
+// The keys are derived once per type, and cached. ComparatorFor panics if no
+// fields are tagged, or with an error wrapping [ErrInvalidField], if a tag is
+// malformed, or a tagged field is invalid.
+func ComparatorFor[T any]() Comparator[T] {
+	keys, err := cachedTagKeys(reflect.TypeFor[T]())
+	if err != nil {
+		panic(err)
+	}
+	if len(keys) == 0 {
+		panic("kway: no fields tagged for ordering")
+	}
+	return func(a, b T) int {
+		return compareFields(keys, reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem())
+	}
+}
+
+// tagsCache holds the []fieldKey derived from the tags of each type.
+var tagsCache sync.Map
+
+func cachedTagKeys(typ reflect.Type) ([]fieldKey, error) {
+	if keys, ok := tagsCache.Load(typ); ok {
+		return keys.([]fieldKey), nil
+	}
+	t := typ
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	type taggedKey struct {
+		fieldKey
+		priority int
+	}
+	var tagged []taggedKey
+	for _, f := range reflect.VisibleFields(t) {
+		tag, ok := f.Tag.Lookup("kway")
+		if !ok || tag == "-" {
+			continue
+		}
+		k := taggedKey{fieldKey: fieldKey{path: [][]int{f.Index}, order: Asc}}
+		_, opts, _ := strings.Cut(tag, ",")
+		for opt := range strings.SplitSeq(opts, ",") {
+			switch opt {
+			case "":
+			case "asc":
+				k.order = Asc
+			case "desc":
+				k.order = Desc
+			default:
+				var err error
+				if k.priority, err = strconv.Atoi(opt); err != nil {
+					return nil, invalidField(typ, f.Name, "invalid tag "+strconv.Quote(tag))
+				}
+			}
+		}
+		if !f.IsExported() {
+			return nil, invalidField(typ, f.Name, "unexported")
+		}
+		if k.cmp = fieldCompare(f.Type); k.cmp == nil {
+			return nil, invalidField(typ, f.Name, "type "+f.Type.String()+" is not ordered")
+		}
+		tagged = append(tagged, k)
+	}
+	slices.SortStableFunc(tagged, func(a, b taggedKey) int { return cmp.Compare(a.priority, b.priority) })
+	keys := make([]fieldKey, len(tagged))
+	for i, k := range tagged {
+		keys[i] = k.fieldKey
+	}
+	tagsCache.Store(typ, keys)
+	return keys, nil
+}
+
+// taggedField returns the field of t, a struct, with the tag name `name`.
+func taggedField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for _, f := range reflect.VisibleFields(t) {
+		if tag, ok := f.Tag.Lookup("kway"); ok && tag != "-" {
+			if tagName, _, _ := strings.Cut(tag, ","); tagName != "" && tagName == name {
+				return f, true
+			}
+		}
+	}
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,119 @@
+package kway
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+type tagsEvent struct {
+	Tenant  string    `kway:"tenant,asc,1"`
+	Time    time.Time `kway:"time,desc,2"`
+	Seq     int       `kway:",3"`
+	Payload string
+	Ignored int `kway:"-"`
+}
+
+type TagsBase struct {
+	ID uint64 `kway:"id"`
+}
+
+type tagsEmbedded struct {
+	*TagsBase
+	Name string `kway:"name,desc"`
+}
+
+func TestComparatorFor(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	c := ComparatorFor[tagsEvent]()
+	for _, tc := range []struct {
+		name   string
+		a, b   tagsEvent
+		result int
+	}{
+		{"priority", tagsEvent{Tenant: "a", Time: epoch}, tagsEvent{Tenant: "b", Time: epoch.Add(time.Hour)}, -1},
+		{"desc", tagsEvent{Tenant: "a", Time: epoch}, tagsEvent{Tenant: "a", Time: epoch.Add(time.Hour)}, 1},
+		{"unnamed", tagsEvent{Seq: 2}, tagsEvent{Seq: 1}, 1},
+		{"untagged", tagsEvent{Payload: "a", Ignored: 1}, tagsEvent{Payload: "b"}, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := c(tc.a, tc.b); result != tc.result {
+				t.Errorf("Expected %d, got %d", tc.result, result)
+			}
+			if result := ComparatorFor[*tagsEvent]()(&tc.a, &tc.b); result != tc.result {
+				t.Errorf("Expected %d for pointers, got %d", tc.result, result)
+			}
+		})
+	}
+}
+
+func TestComparatorFor_Embedded(t *testing.T) {
+	// declaration order, as priorities are equal
+	c := ComparatorFor[tagsEmbedded]()
+	if result := c(tagsEmbedded{TagsBase: &TagsBase{ID: 1}, Name: "a"}, tagsEmbedded{TagsBase: &TagsBase{ID: 1}, Name: "b"}); result != 1 {
+		t.Errorf("Expected 1, got %d", result)
+	}
+	if result := c(tagsEmbedded{Name: "z"}, tagsEmbedded{TagsBase: &TagsBase{}}); result != -1 {
+		t.Errorf("Expected -1, got %d", result)
+	}
+}
+
+func TestComparatorFor_Invalid(t *testing.T) {
+	type badPriority struct {
+		A int `kway:"a,first"`
+	}
+	type unordered struct {
+		A []int `kway:"a"`
+	}
+	type unexported struct {
+		a int `kway:"a"`
+	}
+	for _, tc := range []struct {
+		name    string
+		f       func()
+		message string
+	}{
+		{"priority", func() { ComparatorFor[badPriority]() }, `kway: invalid field: A of kway.badPriority: invalid tag "a,first"`},
+		{"unordered", func() { ComparatorFor[unordered]() }, "kway: invalid field: A of kway.unordered: type []int is not ordered"},
+		{"unexported", func() { ComparatorFor[unexported]() }, "kway: invalid field: a of kway.unexported: unexported"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				err, ok := recover().(error)
+				if !ok || !errors.Is(err, ErrInvalidField) {
+					t.Fatalf("Expected ErrInvalidField, got %v", err)
+				}
+				if err.Error() != tc.message {
+					t.Errorf("Expected %q, got %q", tc.message, err.Error())
+				}
+			}()
+			tc.f()
+		})
+	}
+	for _, f := range []func(){
+		func() { ComparatorFor[int]() },
+		func() { ComparatorFor[fieldsRow]() },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != "kway: no fields tagged for ordering" {
+					t.Errorf("Expected panic, got %v", r)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestByFields_TagNames(t *testing.T) {
+	a := []tagsEvent{{Tenant: "b", Seq: 1}, {Tenant: "a", Seq: 3}}
+	b := []tagsEvent{{Tenant: "b", Seq: 2}}
+	var actual []int
+	for v := range MergeByFields([]string{"-tenant", "Seq"}, slices.Values(b), slices.Values(a)) {
+		actual = append(actual, v.Seq)
+	}
+	if expected := []int{1, 2, 3}; !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+}
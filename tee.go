@@ -0,0 +1,145 @@
+package kway
+
+import (
+	"iter"
+	"sync"
+)
+
+// Tee returns `n` sequences that each yield every element of `seq`, which is
+// iterated at most once. The returned sequences may be consumed concurrently,
+// from separate goroutines, and at most `size` elements are buffered between
+// the fastest and slowest consumer: a consumer that gets `size` elements ahead
+// of the slowest one blocks until the slowest one catches up.
+//
+// Each returned sequence may only be iterated once. A consumer that stops
+// early (or finishes) no longer holds back the others, and `seq` is stopped
+// once every consumer has stopped. Consumers that never start are counted
+// as being at the start of the stream, so all `n` sequences must be consumed
+// (or at least started and stopped) to avoid blocking the others. In
+// particular, consuming the sequences one after another, from a single
+// goroutine, will block as soon as more than `size` elements are buffered.
+func Tee[T any](seq iter.Seq[T], n int, size int) []iter.Seq[T] {
+	if n < 0 {
+		panic("kway: negative tee count")
+	}
+	if size < 1 {
+		panic("kway: tee buffer size must be positive")
+	}
+	if n == 0 {
+		return nil
+	}
+	x := &teeState[T]{
+		seq:    seq,
+		size:   size,
+		pos:    make([]int, n),
+		active: n,
+	}
+	x.cond.L = &x.mu
+	seqs := make([]iter.Seq[T], n)
+	for i := range seqs {
+		seqs[i] = func(yield func(T) bool) { x.consume(i, yield) }
+	}
+	return seqs
+}
+
+type teeState[T any] struct {
+	mu      sync.Mutex
+	cond    sync.Cond
+	seq     iter.Seq[T]
+	next    func() (T, bool)
+	stop    func()
+	buf     []T // elements at positions [base, base+len(buf))
+	base    int
+	size    int
+	pos     []int // per consumer, -1 once the consumer is done
+	active  int
+	pulling bool
+	done    bool
+}
+
+func (x *teeState[T]) consume(c int, yield func(T) bool) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.pos[c] < 0 {
+		return
+	}
+	defer x.leave(c)
+	for {
+		if p := x.pos[c]; p < x.base+len(x.buf) {
+			v := x.buf[p-x.base]
+			x.pos[c]++
+			x.trim()
+			x.mu.Unlock()
+			ok := yield(v)
+			x.mu.Lock()
+			if !ok {
+				return
+			}
+			continue
+		}
+		if x.done {
+			return
+		}
+		if x.pulling || len(x.buf) >= x.size {
+			x.cond.Wait()
+			continue
+		}
+		x.pull()
+	}
+}
+
+// pull fetches the next element from the source, with the lock released
+// while doing so. Must be called with the lock held.
+func (x *teeState[T]) pull() {
+	x.pulling = true
+	x.mu.Unlock()
+	var (
+		v  T
+		ok bool
+	)
+	defer func() {
+		x.mu.Lock()
+		x.pulling = false
+		if ok {
+			x.buf = append(x.buf, v)
+		} else {
+			x.done = true
+		}
+		x.cond.Broadcast()
+	}()
+	if x.next == nil {
+		x.next, x.stop = iter.Pull(x.seq)
+	}
+	v, ok = x.next()
+}
+
+// trim discards buffered elements that every active consumer has seen.
+func (x *teeState[T]) trim() {
+	low := x.base + len(x.buf)
+	for _, p := range x.pos {
+		if p >= 0 && p < low {
+			low = p
+		}
+	}
+	if n := low - x.base; n > 0 {
+		clear(x.buf[:n])
+		x.buf = x.buf[n:]
+		x.base = low
+		x.cond.Broadcast()
+	}
+}
+
+func (x *teeState[T]) leave(c int) {
+	x.pos[c] = -1
+	x.active--
+	x.trim()
+	x.cond.Broadcast()
+	if x.active == 0 {
+		clear(x.buf)
+		x.buf = nil
+		x.done = true
+		if x.stop != nil {
+			x.stop()
+		}
+	}
+}
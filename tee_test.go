@@ -0,0 +1,157 @@
+package kway
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTee_InvalidArguments(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		n, size int
+		msg     string
+	}{
+		{"negative count", -1, 1, "negative tee count"},
+		{"zero size", 2, 0, "buffer size must be positive"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("Expected panic")
+				} else if !strings.Contains(r.(string), tt.msg) {
+					t.Errorf("Expected panic message containing %q, got: %v", tt.msg, r)
+				}
+			}()
+			_ = Tee(sliceSeq([]int{1}), tt.n, tt.size)
+		})
+	}
+}
+
+func TestTee_Zero(t *testing.T) {
+	if seqs := Tee(sliceSeq([]int{1}), 0, 1); seqs != nil {
+		t.Errorf("Expected nil, got %v", seqs)
+	}
+}
+
+func TestTee_Concurrent(t *testing.T) {
+	input := make([]int, 1000)
+	for i := range input {
+		input[i] = i
+	}
+	var iterations atomic.Int32
+	seq := func(yield func(int) bool) {
+		iterations.Add(1)
+		for _, v := range input {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+
+	seqs := Tee(seq, 3, 8)
+	results := make([][]int, len(seqs))
+	var wg sync.WaitGroup
+	for i, seq := range seqs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = collectSeq(seq)
+		}()
+	}
+	wg.Wait()
+
+	if n := iterations.Load(); n != 1 {
+		t.Errorf("Expected source to be iterated once, got %d", n)
+	}
+	for i, result := range results {
+		if !slices.Equal(result, input) {
+			t.Errorf("Consumer %d: expected %d elements in order, got %v", i, len(input), result)
+		}
+	}
+
+	// sequences may only be iterated once
+	if result := collectSeq(seqs[0]); len(result) != 0 {
+		t.Errorf("Expected no elements on second iteration, got %v", result)
+	}
+}
+
+func TestTee_BoundedBuffering(t *testing.T) {
+	var pulled atomic.Int32
+	seq := func(yield func(int) bool) {
+		for i := 0; i < 100; i++ {
+			pulled.Add(1)
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	seqs := Tee(seq, 2, 5)
+
+	var fast []int
+	fastDone := make(chan struct{})
+	go func() {
+		defer close(fastDone)
+		fast = collectSeq(seqs[0])
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for pulled.Load() < 5 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := pulled.Load(); n != 5 {
+		t.Fatalf("Expected the fast consumer to block after 5 elements, got %d pulled", n)
+	}
+
+	slow := collectSeq(seqs[1])
+	<-fastDone
+
+	expected := collectSeq(seq)
+	if !slices.Equal(fast, expected) || !slices.Equal(slow, expected) {
+		t.Errorf("Expected both consumers to see all elements, got %v and %v", fast, slow)
+	}
+}
+
+func TestTee_EarlyStop(t *testing.T) {
+	var stopped atomic.Bool
+	seq := func(yield func(int) bool) {
+		defer stopped.Store(true)
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	seqs := Tee(seq, 2, 4)
+
+	// a consumer that stops early no longer holds back the other
+	for v := range seqs[0] {
+		if v == 1 {
+			break
+		}
+	}
+	if stopped.Load() {
+		t.Fatal("Expected source to remain active while a consumer remains")
+	}
+
+	var result []int
+	for v := range seqs[1] {
+		result = append(result, v)
+		if len(result) == 10 {
+			break
+		}
+	}
+
+	if !slices.Equal(result, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("Unexpected result: %v", result)
+	}
+	if !stopped.Load() {
+		t.Error("Expected source to be stopped once all consumers stopped")
+	}
+}
@@ -0,0 +1,87 @@
+package testkway
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"testing"
+)
+
+// Element is an element of the inputs decoded by [FuzzInputs], identifying
+// its origin, such that the order of elements with equal keys, i.e. the
+// stability of a merge, may be verified.
+type Element struct {
+	// Key is the value compared by [CompareElement].
+	Key int
+	// Source is the index of the input containing the element.
+	Source int
+	// Position is the position of the element within its input.
+	Position int
+}
+
+func (x Element) String() string {
+	return fmt.Sprintf("%d@%d:%d", x.Key, x.Source, x.Position)
+}
+
+// CompareElement compares elements by key, such that elements with equal
+// keys are equal, see [Element].
+func CompareElement(a, b Element) int { return cmp.Compare(a.Key, b.Key) }
+
+// FuzzInputs decodes sorted inputs, per [CompareElement], from arbitrary
+// data, e.g. provided by a fuzz test. Any data is valid. The first byte
+// determines the number of inputs, between 1 and 8, and each subsequent byte
+// appends an element to one of the inputs, with a key up to 3 greater than
+// its predecessor, such that equal keys are common.
+func FuzzInputs(data []byte) [][]Element {
+	if len(data) == 0 {
+		return [][]Element{nil}
+	}
+	inputs := make([][]Element, 1+int(data[0])%8)
+	keys := make([]int, len(inputs))
+	for _, b := range data[1:] {
+		source := int(b) % len(inputs)
+		keys[source] += int(b) / len(inputs) % 4
+		inputs[source] = append(inputs[source], Element{
+			Key:      keys[source],
+			Source:   source,
+			Position: len(inputs[source]),
+		})
+	}
+	return inputs
+}
+
+// Fuzz checks that `merge`, given a sequence for each of the inputs decoded
+// from `data`, per [FuzzInputs], yields the expected output, per
+// [CheckMerged], reporting any error via `t`. The merge must order elements
+// per [CompareElement], breaking ties by input sequence.
+//
+// For example, to fuzz a configuration of [kway.Merger]:
+//
+//	func FuzzMerger(f *testing.F) {
+//		testkway.AddSeeds(f)
+//		m := kway.NewMerger(testkway.CompareElement, kway.WithVerifySorted())
+//		f.Fuzz(func(t *testing.T, data []byte) {
+//			testkway.Fuzz(t, data, m.Merge)
+//		})
+//	}
+func Fuzz(t testing.TB, data []byte, merge func(seqs ...iter.Seq[Element]) iter.Seq[Element]) {
+	t.Helper()
+	inputs := FuzzInputs(data)
+	var output []Element
+	for v := range merge(Seqs(inputs)...) {
+		output = append(output, v)
+	}
+	AssertMerged(t, CompareElement, inputs, output)
+}
+
+// AddSeeds adds a seed corpus, for use with [Fuzz], to `f`, covering
+// various numbers and shapes of inputs.
+func AddSeeds(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0})
+	f.Add([]byte{0, 4, 8, 12})
+	f.Add([]byte{1, 0, 1, 0, 1, 0, 1})
+	f.Add([]byte{2, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11})
+	f.Add([]byte{7, 255, 254, 253, 252, 251, 250, 249, 248, 0, 0, 0, 0})
+	f.Add([]byte{3, 1, 1, 1, 1, 1, 1, 2, 6, 10, 14, 18})
+}
@@ -0,0 +1,123 @@
+package testkway
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/joeycumines/go-kway"
+)
+
+func TestFuzzInputs(t *testing.T) {
+	for _, data := range [][]byte{nil, {0}, {3, 1, 2, 3, 200, 17, 255, 0, 0}} {
+		inputs := FuzzInputs(data)
+		if len(inputs) == 0 || len(inputs) > 8 {
+			t.Errorf("Unexpected number of inputs: %d", len(inputs))
+		}
+		var n int
+		for i, input := range inputs {
+			AssertSorted(t, CompareElement, input)
+			for j, v := range input {
+				if v.Source != i || v.Position != j {
+					t.Errorf("Unexpected element %v, at input %d, position %d", v, i, j)
+				}
+			}
+			n += len(input)
+		}
+		if expected := max(0, len(data)-1); n != expected {
+			t.Errorf("Expected %d elements, got %d", expected, n)
+		}
+	}
+
+	inputs := FuzzInputs([]byte{1, 0, 1, 2, 3, 4, 5})
+	expected := [][]Element{
+		{{0, 0, 0}, {1, 0, 1}, {3, 0, 2}},
+		{{0, 1, 0}, {1, 1, 1}, {3, 1, 2}},
+	}
+	if !slices.EqualFunc(inputs, expected, slices.Equal) {
+		t.Errorf("Expected %v, got %v", expected, inputs)
+	}
+}
+
+func TestFuzz(t *testing.T) {
+	r := &recorder{TB: t}
+	data := []byte{1, 0, 1, 2, 3}
+	Fuzz(r, data, kway.NewMerger(CompareElement).Merge)
+	if len(r.errors) != 0 {
+		t.Errorf("Expected no errors, got %v", r.errors)
+	}
+	// reversing the order of sources breaks stability
+	Fuzz(r, data, func(seqs ...iter.Seq[Element]) iter.Seq[Element] {
+		slices.Reverse(seqs)
+		return kway.Merge(CompareElement, seqs...)
+	})
+	if len(r.errors) != 1 {
+		t.Errorf("Expected 1 error, got %v", r.errors)
+	}
+}
+
+func FuzzMerge(f *testing.F) {
+	AddSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		Fuzz(t, data, func(seqs ...iter.Seq[Element]) iter.Seq[Element] {
+			return kway.Merge(CompareElement, seqs...)
+		})
+	})
+}
+
+func FuzzMerger(f *testing.F) {
+	AddSeeds(f)
+	m := kway.NewMerger(CompareElement, kway.WithVerifySorted(), kway.WithComparatorCheck(1), kway.WithPrefetch(2))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		Fuzz(t, data, m.Merge)
+	})
+}
+
+func FuzzMergeCursors(f *testing.F) {
+	AddSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		Fuzz(t, data, func(seqs ...iter.Seq[Element]) iter.Seq[Element] {
+			return func(yield func(Element) bool) {
+				cursors := make([]kway.Cursor[Element], len(seqs))
+				for i, seq := range seqs {
+					it := kway.NewIterator(seq)
+					defer it.Stop()
+					cursors[i] = it
+				}
+				for v := range kway.MergeCursors(CompareElement, cursors...) {
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		})
+	})
+}
+
+func FuzzMergeBytes(f *testing.F) {
+	AddSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		inputs := FuzzInputs(data)
+		seqs := make([]iter.Seq[[]byte], len(inputs))
+		for i, input := range inputs {
+			seqs[i] = func(yield func([]byte) bool) {
+				for _, v := range input {
+					if !yield([]byte{byte(v.Key >> 8), byte(v.Key)}) {
+						return
+					}
+				}
+			}
+		}
+		var output []int
+		for v := range kway.MergeBytes(seqs...) {
+			output = append(output, int(v[0])<<8|int(v[1]))
+		}
+		var expected []int
+		for _, v := range Expected(CompareElement, inputs...) {
+			expected = append(expected, v.Key)
+		}
+		if !slices.Equal(output, expected) {
+			t.Errorf("Expected %v, got %v", expected, output)
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package testkway
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"iter"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// maxGoldenDiffs is the maximum number of differing lines reported by
+// [CheckGolden].
+const maxGoldenDiffs = 10
+
+// AssertGolden encodes each element of `seq`, typically the output of a
+// merge, using `encode`, and reports an error via `t`, per [CheckGolden], if
+// the result differs from the contents of the golden file at `path`. If
+// `update` is true, the golden file is instead written, creating any parent
+// directories. Typically, update is set by a flag of the test binary, e.g.
+//
+//	var update = flag.Bool("update", false, "update golden files")
+func AssertGolden[T any](t testing.TB, path string, update bool, encode func(w io.Writer, v T) error, seq iter.Seq[T]) {
+	t.Helper()
+	var b bytes.Buffer
+	for v := range seq {
+		if err := encode(&b, v); err != nil {
+			t.Errorf("testkway: encoding %v: %v", v, err)
+			return
+		}
+	}
+	if update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Error(err)
+		} else if err := os.WriteFile(path, b.Bytes(), 0o644); err != nil {
+			t.Error(err)
+		}
+		return
+	}
+	if err := CheckGolden(path, b.Bytes()); err != nil {
+		t.Error(err)
+	}
+}
+
+// CheckGolden returns an error if `output` differs from the contents of the
+// golden file at `path`, describing the differing lines, by line number, up
+// to a limit.
+func CheckGolden(path string, output []byte) error {
+	expected, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("testkway: golden file %s does not exist, it may be created by updating", path)
+	}
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(output, expected) {
+		return nil
+	}
+
+	got, want := splitLines(output), splitLines(expected)
+	var b strings.Builder
+	fmt.Fprintf(&b, "testkway: output differs from golden file %s", path)
+	if len(got) != len(want) {
+		fmt.Fprintf(&b, ", with %d lines, expected %d", len(got), len(want))
+	}
+	b.WriteByte(':')
+	var diffs int
+	for i := range max(len(got), len(want)) {
+		var g, w string
+		if i < len(got) {
+			g = got[i]
+		}
+		if i < len(want) {
+			w = want[i]
+		}
+		if g == w && (i < len(got)) == (i < len(want)) {
+			continue
+		}
+		if diffs == maxGoldenDiffs {
+			b.WriteString("\n...")
+			break
+		}
+		diffs++
+		fmt.Fprintf(&b, "\nline %d:", i+1)
+		if i < len(want) {
+			fmt.Fprintf(&b, "\n- %q", w)
+		}
+		if i < len(got) {
+			fmt.Fprintf(&b, "\n+ %q", g)
+		}
+	}
+	return errors.New(b.String())
+}
+
+// splitLines splits s into lines, retaining line terminators, such that
+// differences in them are reported.
+func splitLines(s []byte) []string {
+	var lines []string
+	for len(s) != 0 {
+		i := bytes.IndexByte(s, '\n') + 1
+		if i == 0 {
+			i = len(s)
+		}
+		lines = append(lines, string(s[:i]))
+		s = s[i:]
+	}
+	return lines
+}
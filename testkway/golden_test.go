@@ -0,0 +1,114 @@
+package testkway
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/joeycumines/go-kway"
+)
+
+func encodeLine(w io.Writer, v int) error {
+	_, err := fmt.Fprintln(w, v)
+	return err
+}
+
+func TestAssertGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "merged.golden")
+	merged := func() iter.Seq[int] {
+		return kway.Merge(cmp.Compare[int], slices.Values([]int{1, 3}), slices.Values([]int{2}))
+	}
+
+	r := &recorder{TB: t}
+	AssertGolden(r, path, false, encodeLine, merged())
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "does not exist") {
+		t.Errorf("Expected missing golden file error, got %v", r.errors)
+	}
+
+	r = &recorder{TB: t}
+	AssertGolden(r, path, true, encodeLine, merged())
+	if len(r.errors) != 0 {
+		t.Fatalf("Expected no errors, got %v", r.errors)
+	}
+	if b, err := os.ReadFile(path); err != nil || string(b) != "1\n2\n3\n" {
+		t.Errorf("Unexpected golden file: %q, %v", b, err)
+	}
+
+	AssertGolden(r, path, false, encodeLine, merged())
+	if len(r.errors) != 0 {
+		t.Errorf("Expected no errors, got %v", r.errors)
+	}
+
+	AssertGolden(r, path, false, encodeLine, slices.Values([]int{1, 3}))
+	if len(r.errors) != 1 {
+		t.Errorf("Expected 1 error, got %v", r.errors)
+	}
+
+	r = &recorder{TB: t}
+	AssertGolden(r, path, false, func(io.Writer, int) error { return errors.New("failed") }, merged())
+	if len(r.errors) != 1 || r.errors[0] != "testkway: encoding 1: failed" {
+		t.Errorf("Expected encoding error, got %v", r.errors)
+	}
+}
+
+func TestCheckGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "golden")
+	if err := os.WriteFile(path, []byte("a\nb\nc\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		output   string
+		expected string
+	}{
+		{name: "equal", output: "a\nb\nc\n"},
+		{name: "changed", output: "a\nx\nc\n", expected: `:
+line 2:
+- "b\n"
++ "x\n"`},
+		{name: "missing line", output: "a\nb\n", expected: `, with 2 lines, expected 3:
+line 3:
+- "c\n"`},
+		{name: "missing terminator", output: "a\nb\nc", expected: `:
+line 3:
+- "c\n"
++ "c"`},
+		{name: "extra line", output: "a\nb\nc\nd\n", expected: `, with 4 lines, expected 3:
+line 4:
++ "d\n"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckGolden(path, []byte(tt.output))
+			if tt.expected == "" {
+				if err != nil {
+					t.Errorf("Expected nil, got %v", err)
+				}
+				return
+			}
+			if expected := "testkway: output differs from golden file " + path + tt.expected; err == nil || err.Error() != expected {
+				t.Errorf("Expected %q, got %v", expected, err)
+			}
+		})
+	}
+}
+
+func TestCheckGolden_limit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "golden")
+	if err := os.WriteFile(path, []byte(strings.Repeat("a\n", 20)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := CheckGolden(path, []byte(strings.Repeat("b\n", 20)))
+	if err == nil || strings.Count(err.Error(), "\nline ") != maxGoldenDiffs || !strings.HasSuffix(err.Error(), "\n...") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package testkway
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// Shape is a named set of sorted inputs, see [Shapes].
+type Shape struct {
+	Name   string
+	Inputs [][]int
+}
+
+// Shapes returns inputs of various shapes, including pathological ones,
+// parameterized by `k` inputs, of up to `n` elements, intended to be tested
+// exhaustively, e.g. as subtests. The inputs are deterministic.
+func Shapes(k, n int) []Shape {
+	rng := rand.New(rand.NewPCG(uint64(k), uint64(n)))
+	return []Shape{
+		{Name: "sorted", Inputs: Sorted(rng, k, n)},
+		{Name: "duplicates", Inputs: Duplicates(rng, k, n, 3)},
+		{Name: "adversarial", Inputs: Adversarial(k, n)},
+		{Name: "all equal", Inputs: AllEqual(k, n)},
+		{Name: "giant and singletons", Inputs: GiantAndSingletons(n, max(0, k-1))},
+		{Name: "alternating", Inputs: Alternating(k, n)},
+		{Name: "skewed", Inputs: Skewed(k, n)},
+	}
+}
+
+// String returns the name and dimensions of the shape.
+func (x Shape) String() string {
+	var n int
+	for _, input := range x.Inputs {
+		n += len(input)
+	}
+	return fmt.Sprintf("%s (%d inputs, %d elements)", x.Name, len(x.Inputs), n)
+}
+
+// AllEqual returns `k` inputs, each of `n` elements, all of which are equal.
+func AllEqual(k, n int) [][]int {
+	inputs := make([][]int, k)
+	for i := range inputs {
+		inputs[i] = make([]int, n)
+	}
+	return inputs
+}
+
+// GiantAndSingletons returns an input of `n` elements, followed by `k`
+// inputs of one element each, with the singletons spread across the range
+// of the first input.
+func GiantAndSingletons(n, k int) [][]int {
+	inputs := make([][]int, 1+k)
+	inputs[0] = make([]int, n)
+	for j := range inputs[0] {
+		inputs[0][j] = j
+	}
+	for i := 1; i < len(inputs); i++ {
+		inputs[i] = []int{(i - 1) * n / max(1, k)}
+	}
+	return inputs
+}
+
+// Alternating returns `k` inputs, each of `n` elements, such that the merged
+// output cycles through the inputs, in order, e.g. 0, k, 2k... for the first
+// input, and 1, k+1, 2k+1... for the second.
+func Alternating(k, n int) [][]int {
+	inputs := make([][]int, k)
+	for i := range inputs {
+		input := make([]int, n)
+		for j := range input {
+			input[j] = j*k + i
+		}
+		inputs[i] = input
+	}
+	return inputs
+}
+
+// Skewed returns `k` inputs, of wildly different lengths, each spanning the
+// same range: the input at index i has n>>i elements, spaced 1<<i apart, so
+// later inputs may be empty.
+func Skewed(k, n int) [][]int {
+	inputs := make([][]int, k)
+	for i := range inputs {
+		var input []int
+		if i < 63 {
+			input = make([]int, n>>i)
+		}
+		for j := range input {
+			input[j] = j << i
+		}
+		inputs[i] = input
+	}
+	return inputs
+}
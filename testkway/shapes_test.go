@@ -0,0 +1,55 @@
+package testkway
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/joeycumines/go-kway"
+)
+
+func TestShapes(t *testing.T) {
+	for _, shape := range Shapes(5, 40) {
+		t.Run(shape.Name, func(t *testing.T) {
+			if len(shape.Inputs) != 5 {
+				t.Errorf("Expected 5 inputs, got %d", len(shape.Inputs))
+			}
+			for i, input := range shape.Inputs {
+				if !slices.IsSorted(input) {
+					t.Errorf("Input %d: expected sorted values, got %v", i, input)
+				}
+			}
+			output := slices.Collect(kway.Merge(cmp.Compare[int], Seqs(shape.Inputs)...))
+			AssertProperties(t, cmp.Compare[int], shape.Inputs, output)
+			AssertMerged(t, cmp.Compare[int], shape.Inputs, output)
+		})
+	}
+}
+
+func TestShape_String(t *testing.T) {
+	if s := (Shape{Name: "x", Inputs: [][]int{{1}, {2, 3}}}).String(); s != "x (2 inputs, 3 elements)" {
+		t.Errorf("Unexpected string: %s", s)
+	}
+}
+
+func TestShapes_values(t *testing.T) {
+	tests := []struct {
+		name     string
+		inputs   [][]int
+		expected [][]int
+	}{
+		{"all equal", AllEqual(2, 3), [][]int{{0, 0, 0}, {0, 0, 0}}},
+		{"giant and singletons", GiantAndSingletons(6, 3), [][]int{{0, 1, 2, 3, 4, 5}, {0}, {2}, {4}}},
+		{"giant only", GiantAndSingletons(2, 0), [][]int{{0, 1}}},
+		{"alternating", Alternating(3, 2), [][]int{{0, 3}, {1, 4}, {2, 5}}},
+		{"skewed", Skewed(3, 4), [][]int{{0, 1, 2, 3}, {0, 2}, {0}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !slices.EqualFunc(tt.inputs, tt.expected, slices.Equal) {
+				t.Errorf("Expected %v, got %v", tt.expected, tt.inputs)
+			}
+		})
+	}
+}
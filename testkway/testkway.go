@@ -0,0 +1,197 @@
+// Package testkway provides support for testing code built on
+// [github.com/joeycumines/go-kway], such as comparison functions, adapters
+// and configurations of [kway.Merger], including generators of sorted input
+// sequences, and checkers of merged output.
+package testkway
+
+import (
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/joeycumines/go-kway"
+)
+
+// Sorted returns `k` sorted inputs, each of a random length, up to `n`
+// elements, with values uniformly distributed in [0, k*n).
+func Sorted(rng *rand.Rand, k, n int) [][]int {
+	return generate(rng, k, n, max(1, k*n))
+}
+
+// Duplicates returns `k` sorted inputs, each of a random length, up to `n`
+// elements, with values uniformly distributed in [0, distinct), such that
+// most elements are equal to elements of the other inputs, for small values
+// of distinct.
+func Duplicates(rng *rand.Rand, k, n, distinct int) [][]int {
+	if distinct <= 0 {
+		panic("testkway: distinct must be positive")
+	}
+	return generate(rng, k, n, distinct)
+}
+
+func generate(rng *rand.Rand, k, n, values int) [][]int {
+	if rng == nil {
+		panic("testkway: nil rand")
+	}
+	inputs := make([][]int, k)
+	for i := range inputs {
+		input := make([]int, rng.IntN(n+1))
+		for j := range input {
+			input[j] = rng.IntN(values)
+		}
+		slices.Sort(input)
+		inputs[i] = input
+	}
+	return inputs
+}
+
+// Adversarial returns `k` sorted inputs, each of `n` elements, interleaved
+// round-robin, in descending order of input, with each pair of consecutive
+// elements of that interleaving sharing a value, e.g. for k=3, the inputs
+// [1 2 4 5], [0 2 3 5] and [0 1 3 4]. For k > 1, each value is therefore
+// held by at most two elements, of different, not necessarily adjacent,
+// inputs, and no input contributes more than two consecutive elements of
+// the merged output. This defeats optimizations for runs, and exercises the
+// ordering of equal elements, by input.
+func Adversarial(k, n int) [][]int {
+	inputs := make([][]int, k)
+	for i := range inputs {
+		input := make([]int, n)
+		for j := range input {
+			input[j] = (j*k + k - 1 - i) / 2
+		}
+		inputs[i] = input
+	}
+	return inputs
+}
+
+// Seqs returns a sequence for each of the inputs, each of which may be
+// iterated multiple times.
+func Seqs[T any](inputs [][]T) []iter.Seq[T] {
+	seqs := make([]iter.Seq[T], len(inputs))
+	for i, input := range inputs {
+		seqs[i] = slices.Values(input)
+	}
+	return seqs
+}
+
+// Expected returns the expected output of merging the inputs, i.e. their
+// concatenation, stably sorted by `cmp`, such that equal elements are
+// ordered by input, as documented by [kway.Merge].
+func Expected[T any](cmp func(a, b T) int, inputs ...[]T) []T {
+	expected := slices.Concat(inputs...)
+	slices.SortStableFunc(expected, cmp)
+	return expected
+}
+
+// CheckSorted returns a [*kway.OrderError], identifying the position of the
+// first element of `s` which compares less than its predecessor, or nil if
+// `s` is sorted.
+func CheckSorted[T any](cmp func(a, b T) int, s []T) error {
+	for i := 1; i < len(s); i++ {
+		if cmp(s[i], s[i-1]) < 0 {
+			return &kway.OrderError{Source: -1, Position: i, Prev: s[i-1], Next: s[i]}
+		}
+	}
+	return nil
+}
+
+// CheckMerged returns an error identifying the first difference between
+// `output` and the [Expected] output of merging the inputs, or nil if they
+// are equal, including in the order of equal elements.
+func CheckMerged[T comparable](cmp func(a, b T) int, inputs [][]T, output []T) error {
+	expected := Expected(cmp, inputs...)
+	for i := range min(len(expected), len(output)) {
+		if output[i] != expected[i] {
+			return fmt.Errorf("testkway: output element %d is %v, expected %v", i, output[i], expected[i])
+		}
+	}
+	if len(output) != len(expected) {
+		return fmt.Errorf("testkway: output has %d elements, expected %d", len(output), len(expected))
+	}
+	return nil
+}
+
+// CheckLength returns an error if the length of `output` differs from the
+// total length of the inputs.
+func CheckLength[T any](inputs [][]T, output []T) error {
+	var n int
+	for _, input := range inputs {
+		n += len(input)
+	}
+	if len(output) != n {
+		return fmt.Errorf("testkway: output has %d elements, expected %d", len(output), n)
+	}
+	return nil
+}
+
+// CheckPermutation returns an error if `output` is not a permutation of the
+// elements of the inputs, i.e. if their multisets differ, identifying an
+// element with differing counts.
+func CheckPermutation[T comparable](inputs [][]T, output []T) error {
+	counts := make(map[T]int)
+	for _, input := range inputs {
+		for _, v := range input {
+			counts[v]++
+		}
+	}
+	for _, v := range output {
+		counts[v]--
+	}
+	for _, v := range output {
+		if c := counts[v]; c < 0 {
+			return fmt.Errorf("testkway: output has %d more of element %v than the inputs", -c, v)
+		}
+	}
+	for _, input := range inputs {
+		for _, v := range input {
+			if c := counts[v]; c > 0 {
+				return fmt.Errorf("testkway: output has %d fewer of element %v than the inputs", c, v)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckProperties returns an error if `output` violates a property of
+// merging the inputs, per [CheckLength], [CheckPermutation] and
+// [CheckSorted]. Unlike [CheckMerged], the order of equal elements is not
+// checked, e.g. to test merges that are not stable.
+func CheckProperties[T comparable](cmp func(a, b T) int, inputs [][]T, output []T) error {
+	if err := CheckLength(inputs, output); err != nil {
+		return err
+	}
+	if err := CheckPermutation(inputs, output); err != nil {
+		return err
+	}
+	return CheckSorted(cmp, output)
+}
+
+// AssertSorted reports an error via `t`, per [CheckSorted], if `s` is not
+// sorted.
+func AssertSorted[T any](t testing.TB, cmp func(a, b T) int, s []T) {
+	t.Helper()
+	if err := CheckSorted(cmp, s); err != nil {
+		t.Error(err)
+	}
+}
+
+// AssertMerged reports an error via `t`, per [CheckMerged], if `output` is not
+// the expected output of merging the inputs.
+func AssertMerged[T comparable](t testing.TB, cmp func(a, b T) int, inputs [][]T, output []T) {
+	t.Helper()
+	if err := CheckMerged(cmp, inputs, output); err != nil {
+		t.Error(err)
+	}
+}
+
+// AssertProperties reports an error via `t`, per [CheckProperties], if
+// `output` violates a property of merging the inputs.
+func AssertProperties[T comparable](t testing.TB, cmp func(a, b T) int, inputs [][]T, output []T) {
+	t.Helper()
+	if err := CheckProperties(cmp, inputs, output); err != nil {
+		t.Error(err)
+	}
+}
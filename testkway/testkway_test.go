@@ -0,0 +1,192 @@
+package testkway
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/joeycumines/go-kway"
+)
+
+func TestSorted(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	inputs := Sorted(rng, 5, 20)
+	if len(inputs) != 5 {
+		t.Fatalf("Expected 5 inputs, got %d", len(inputs))
+	}
+	for i, input := range inputs {
+		if len(input) > 20 || !slices.IsSorted(input) {
+			t.Errorf("Input %d: expected up to 20 sorted values, got %v", i, input)
+		}
+		for _, v := range input {
+			if v < 0 || v >= 100 {
+				t.Errorf("Input %d: value %d out of range", i, v)
+			}
+		}
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for i, input := range Duplicates(rng, 4, 50, 3) {
+		if !slices.IsSorted(input) {
+			t.Errorf("Input %d: expected sorted values, got %v", i, input)
+		}
+		for _, v := range input {
+			if v < 0 || v >= 3 {
+				t.Errorf("Input %d: value %d out of range", i, v)
+			}
+		}
+	}
+}
+
+func TestAdversarial(t *testing.T) {
+	inputs := Adversarial(3, 4)
+	expected := [][]int{{1, 2, 4, 5}, {0, 2, 3, 5}, {0, 1, 3, 4}}
+	for i := range expected {
+		if !slices.Equal(inputs[i], expected[i]) {
+			t.Errorf("Input %d: expected %v, got %v", i, expected[i], inputs[i])
+		}
+	}
+}
+
+func TestGenerators_panics(t *testing.T) {
+	for _, fn := range []func(){
+		func() { Sorted(nil, 1, 1) },
+		func() { Duplicates(rand.New(rand.NewPCG(1, 2)), 1, 1, 0) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestCheckSorted(t *testing.T) {
+	if err := CheckSorted(cmp.Compare[int], []int{1, 2, 2, 3}); err != nil {
+		t.Errorf("Expected nil, got %v", err)
+	}
+	err := CheckSorted(cmp.Compare[int], []int{1, 3, 2})
+	var orderErr *kway.OrderError
+	if !errors.As(err, &orderErr) || orderErr.Position != 2 || orderErr.Prev != 3 || orderErr.Next != 2 {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestCheckMerged(t *testing.T) {
+	type element struct{ key, source int }
+	compare := func(a, b element) int { return cmp.Compare(a.key, b.key) }
+	inputs := [][]element{{{1, 0}, {2, 0}}, {{1, 1}, {3, 1}}}
+
+	tests := []struct {
+		name   string
+		output []element
+		err    string
+	}{
+		{name: "valid", output: []element{{1, 0}, {1, 1}, {2, 0}, {3, 1}}},
+		{name: "unstable", output: []element{{1, 1}, {1, 0}, {2, 0}, {3, 1}}, err: "output element 0 is {1 1}, expected {1 0}"},
+		{name: "short", output: []element{{1, 0}, {1, 1}, {2, 0}}, err: "output has 3 elements, expected 4"},
+		{name: "long", output: []element{{1, 0}, {1, 1}, {2, 0}, {3, 1}, {4, 0}}, err: "output has 5 elements, expected 4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckMerged(compare, inputs, tt.output)
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("Expected nil, got %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (x *recorder) Helper() {}
+
+func (x *recorder) Error(args ...any) {
+	x.errors = append(x.errors, args[0].(error).Error())
+}
+
+func (x *recorder) Errorf(format string, args ...any) {
+	x.errors = append(x.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssert(t *testing.T) {
+	r := &recorder{TB: t}
+	AssertSorted(r, cmp.Compare[int], []int{1, 2})
+	AssertMerged(r, cmp.Compare[int], [][]int{{1}, {2}}, []int{1, 2})
+	if len(r.errors) != 0 {
+		t.Errorf("Expected no errors, got %v", r.errors)
+	}
+	AssertSorted(r, cmp.Compare[int], []int{2, 1})
+	AssertMerged(r, cmp.Compare[int], [][]int{{1}, {2}}, []int{2, 1})
+	if len(r.errors) != 2 {
+		t.Errorf("Expected 2 errors, got %v", r.errors)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	for _, inputs := range [][][]int{
+		Sorted(rng, 8, 100),
+		Duplicates(rng, 8, 100, 4),
+		Adversarial(8, 100),
+	} {
+		AssertMerged(t, cmp.Compare[int], inputs, slices.Collect(kway.Merge(cmp.Compare[int], Seqs(inputs)...)))
+	}
+}
+
+func TestCheckProperties(t *testing.T) {
+	inputs := [][]int{{1, 2, 2}, {0, 2}}
+
+	tests := []struct {
+		name   string
+		output []int
+		err    string
+	}{
+		{name: "valid", output: []int{0, 1, 2, 2, 2}},
+		{name: "short", output: []int{0, 1, 2, 2}, err: "output has 4 elements, expected 5"},
+		{name: "extra", output: []int{0, 1, 2, 2, 3}, err: "output has 1 more of element 3 than the inputs"},
+		{name: "replaced", output: []int{0, 1, 1, 2, 2}, err: "output has 1 more of element 1 than the inputs"},
+		{name: "unsorted", output: []int{0, 2, 1, 2, 2}, err: "element 2 (1) is less than element 1 (2)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckProperties(cmp.Compare[int], inputs, tt.output)
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("Expected nil, got %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+
+	if err := CheckPermutation(inputs, []int{2, 0, 1}); err == nil || err.Error() != "testkway: output has 2 fewer of element 2 than the inputs" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	r := &recorder{TB: t}
+	AssertProperties(r, cmp.Compare[int], inputs, []int{0, 1, 2, 2, 2})
+	AssertProperties(r, cmp.Compare[int], inputs, []int{0, 1, 2, 2})
+	if len(r.errors) != 1 {
+		t.Errorf("Expected 1 error, got %v", r.errors)
+	}
+}
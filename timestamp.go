@@ -0,0 +1,228 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"time"
+
+	"github.com/joeycumines/go-kway/heap"
+)
+
+// MergeTimestamps performs a k-way merge of the provided input sequences,
+// ordering elements by the time returned by `key`, tolerating input
+// sequences that are only sorted to within `skew`, e.g. on account of clock
+// drift between the sources of events. That is, each element of an input
+// sequence may be up to `skew` earlier than the elements preceding it, in the
+// same sequence.
+//
+// Elements are held back, until no input sequence can produce an earlier
+// element, so the output is sorted, provided the input sequences satisfy the
+// tolerance. Elements with equal times are ordered by input sequence, then
+// by their order within each input sequence. Elements that violate the
+// tolerance are yielded as soon as possible, out of order.
+//
+// The number of elements held back is bounded by the number of elements
+// within a window of twice `skew`, across all input sequences.
+func MergeTimestamps[T any](skew time.Duration, key func(v T) time.Time, seqs ...iter.Seq[T]) iter.Seq[T] {
+	if skew < 0 {
+		panic("kway: negative skew")
+	}
+	if key == nil {
+		panic("kway: nil key function")
+	}
+	if !anySeq(seqs) {
+		return emptySeq[T]
+	}
+	return func(yield func(T) bool) {
+		type source struct {
+			next func() (T, bool)
+			// low is the earliest time that may still be produced, valid if
+			// started
+			low     time.Time
+			max     time.Time
+			started bool
+		}
+		type item struct {
+			v      T
+			t      time.Time
+			source int
+			seq    int64
+		}
+
+		sources := make([]source, len(seqs))
+		indexes := make([]int, 0, len(seqs))
+		for i, seq := range seqs {
+			if seq != nil {
+				next, stop := iter.Pull(seq)
+				defer stop()
+				sources[i].next = next
+				indexes = append(indexes, i)
+			}
+		}
+		// the active sources, ordered by the earliest time they may produce
+		active := heap.New(func(a, b int) int {
+			sa, sb := &sources[a], &sources[b]
+			if sa.started != sb.started {
+				if !sa.started {
+					return -1
+				}
+				return 1
+			}
+			if v := sa.low.Compare(sb.low); v != 0 {
+				return v
+			}
+			return cmp.Compare(a, b)
+		}, indexes)
+		// the elements held back
+		pending := heap.New(func(a, b item) int {
+			if v := a.t.Compare(b.t); v != 0 {
+				return v
+			}
+			if v := cmp.Compare(a.source, b.source); v != 0 {
+				return v
+			}
+			return cmp.Compare(a.seq, b.seq)
+		}, nil)
+
+		var n int64
+		for {
+			if active.Len() == 0 {
+				if pending.Len() == 0 || !yield(pending.Pop().v) {
+					return
+				}
+				continue
+			}
+			i := active.Slice()[0]
+			src := &sources[i]
+			if top, ok := pending.Peek(); ok && src.started && top.t.Before(src.low) {
+				pending.Pop()
+				if !yield(top.v) {
+					return
+				}
+				continue
+			}
+			v, ok := src.next()
+			if !ok {
+				active.Pop()
+				continue
+			}
+			t := key(v)
+			if !src.started || t.After(src.max) {
+				src.max = t
+			}
+			src.low, src.started = src.max.Add(-skew), true
+			active.Fix(0)
+			pending.Push(item{v: v, t: t, source: i, seq: n})
+			n++
+		}
+	}
+}
+
+// Buckets groups the elements of `seq`, which must be sorted by the time
+// returned by `key`, e.g. a merged stream of events, into consecutive buckets
+// of duration `size`, yielding the start of each bucket, with its elements,
+// in order, such that rollups may be computed in a single streaming pass.
+// Buckets are aligned per [time.Time.Truncate], e.g. to the minute or hour,
+// and an element preceding the current bucket, violating the sort order, is
+// included in it.
+//
+// Buckets without elements are yielded, with a nil slice, between those of
+// the first and last elements, if `includeEmpty`, or otherwise skipped. The
+// slice of elements is reused, and must not be retained beyond each
+// iteration.
+func Buckets[T any](seq iter.Seq[T], key func(v T) time.Time, size time.Duration, includeEmpty bool) iter.Seq2[time.Time, []T] {
+	if key == nil {
+		panic("kway: nil key function")
+	}
+	if size <= 0 {
+		panic("kway: bucket size must be positive")
+	}
+	return func(yield func(time.Time, []T) bool) {
+		var (
+			start time.Time
+			batch []T
+		)
+		for v := range seq {
+			if t := key(v); len(batch) == 0 {
+				start = t.Truncate(size)
+			} else if !t.Before(start.Add(size)) {
+				if !yield(start, batch) {
+					return
+				}
+				clear(batch)
+				batch = batch[:0]
+				next := t.Truncate(size)
+				for start = start.Add(size); includeEmpty && start.Before(next); start = start.Add(size) {
+					if !yield(start, nil) {
+						return
+					}
+				}
+				start = next
+			}
+			batch = append(batch, v)
+		}
+		if len(batch) != 0 {
+			yield(start, batch)
+		}
+	}
+}
+
+// TemporalJoin joins the elements of `a` and `b`, each sorted by the time
+// returned by their key function, yielding each pair whose times are within
+// `window` of each other, inclusive, e.g. to enrich a stream of events with
+// those of another, such as the readings of a sensor. Pairs are yielded in
+// order of the elements of `a`, then those of `b`.
+//
+// Only the elements of `b` within the window of the current element of `a`
+// are buffered, and `b` is read only as far as the window requires, so the
+// memory used is bounded by the density of `b`, rather than its size.
+func TemporalJoin[A any, B any](a iter.Seq[A], b iter.Seq[B], keyA func(v A) time.Time, keyB func(v B) time.Time, window time.Duration) iter.Seq2[A, B] {
+	if keyA == nil || keyB == nil {
+		panic("kway: nil key function")
+	}
+	if window < 0 {
+		panic("kway: negative window")
+	}
+	type item struct {
+		v B
+		t time.Time
+	}
+	return func(yield func(A, B) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+		var (
+			active []item
+			done   bool
+		)
+		for va := range a {
+			ta := keyA(va)
+			lo, hi := ta.Add(-window), ta.Add(window)
+			// drop the elements of b preceding the window
+			i := 0
+			for i < len(active) && active[i].t.Before(lo) {
+				i++
+			}
+			clear(active[:i])
+			active = active[i:]
+			// read b until past the window
+			for !done && (len(active) == 0 || !active[len(active)-1].t.After(hi)) {
+				vb, ok := next()
+				if !ok {
+					done = true
+					break
+				}
+				if tb := keyB(vb); !tb.Before(lo) {
+					active = append(active, item{vb, tb})
+				}
+			}
+			for _, x := range active {
+				if x.t.After(hi) {
+					break
+				}
+				if !yield(va, x.v) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package kway
+
+import (
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMergeTimestamps(t *testing.T) {
+	type event struct {
+		at     time.Time
+		source int
+		id     int
+	}
+	key := func(v event) time.Time { return v.at }
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	const skew = 5 * time.Second
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for round := range 100 {
+		var (
+			seqs []iter.Seq[event]
+			all  []event
+		)
+		for i := range r.IntN(5) + 1 {
+			var s []event
+			at := base
+			for j := range r.IntN(30) {
+				at = at.Add(time.Duration(r.IntN(4)) * time.Second)
+				s = append(s, event{at, i, j})
+			}
+			// swap adjacent elements, within the tolerance
+			for j := 1; j < len(s); j++ {
+				if r.IntN(3) == 0 && s[j].at.Sub(s[j-1].at) <= skew {
+					s[j-1], s[j] = s[j], s[j-1]
+					j++
+				}
+			}
+			all = append(all, s...)
+			seqs = append(seqs, sliceSeq(s))
+		}
+		expected := slices.Clone(all)
+		slices.SortStableFunc(expected, func(a, b event) int { return a.at.Compare(b.at) })
+
+		if result := collectSeq(MergeTimestamps(skew, key, seqs...)); !slices.Equal(result, expected) {
+			t.Fatalf("Round %d: expected %v, got %v", round, expected, result)
+		}
+	}
+}
+
+func TestMergeTimestamps_NoSkew(t *testing.T) {
+	key := func(v time.Time) time.Time { return v }
+	base := time.Unix(0, 0)
+	at := func(s int) time.Time { return base.Add(time.Duration(s) * time.Second) }
+
+	result := collectSeq(MergeTimestamps(0, key, sliceSeq([]time.Time{at(1), at(3)}), nil, sliceSeq([]time.Time{at(2)})))
+	if expected := []time.Time{at(1), at(2), at(3)}; !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestMergeTimestamps_EarlyTermination(t *testing.T) {
+	key := func(v time.Time) time.Time { return v }
+	base := time.Unix(0, 0)
+	for v := range MergeTimestamps(time.Second, key, sliceSeq([]time.Time{base, base.Add(time.Hour)})) {
+		if !v.Equal(base) {
+			t.Errorf("Expected %v, got %v", base, v)
+		}
+		break
+	}
+}
+
+func TestMergeTimestamps_Panics(t *testing.T) {
+	key := func(v time.Time) time.Time { return v }
+	for name, fn := range map[string]func(){
+		"negative skew": func() { MergeTimestamps(-1, key) },
+		"nil key":       func() { MergeTimestamps[time.Time](0, nil) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
+
+func TestBuckets(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes ...int) []time.Time {
+		var s []time.Time
+		for _, m := range minutes {
+			s = append(s, base.Add(time.Duration(m)*time.Minute))
+		}
+		return s
+	}
+	key := func(v time.Time) time.Time { return v }
+
+	type bucket struct {
+		start  time.Time
+		values []time.Time
+	}
+	tests := []struct {
+		name         string
+		input        []time.Time
+		size         time.Duration
+		includeEmpty bool
+		expected     []bucket
+	}{
+		{"none", nil, time.Hour, true, nil},
+		{
+			"skipping empty",
+			at(5, 10, 70, 200),
+			time.Hour,
+			false,
+			[]bucket{{at(0)[0], at(5, 10)}, {at(60)[0], at(70)}, {at(180)[0], at(200)}},
+		},
+		{
+			"including empty",
+			at(5, 10, 70, 200),
+			time.Hour,
+			true,
+			[]bucket{{at(0)[0], at(5, 10)}, {at(60)[0], at(70)}, {at(120)[0], nil}, {at(180)[0], at(200)}},
+		},
+		{
+			"boundaries",
+			at(59, 60, 61),
+			time.Hour,
+			true,
+			[]bucket{{at(0)[0], at(59)}, {at(60)[0], at(60, 61)}},
+		},
+		{
+			"out of order",
+			at(30, 20, 90),
+			time.Hour,
+			false,
+			[]bucket{{at(0)[0], at(30, 20)}, {at(60)[0], at(90)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actual []bucket
+			for start, values := range Buckets(slices.Values(tt.input), key, tt.size, tt.includeEmpty) {
+				actual = append(actual, bucket{start, slices.Clone(values)})
+			}
+			if !slices.EqualFunc(actual, tt.expected, func(a, b bucket) bool {
+				return a.start.Equal(b.start) && slices.EqualFunc(a.values, b.values, time.Time.Equal)
+			}) {
+				t.Errorf("Expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestBuckets_EarlyTermination(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	input := []time.Time{base, base.Add(3 * time.Hour)}
+	for _, limit := range []int{1, 2} {
+		var n int
+		for range Buckets(slices.Values(input), func(v time.Time) time.Time { return v }, time.Hour, true) {
+			if n++; n == limit {
+				break
+			}
+		}
+		if n != limit {
+			t.Errorf("Expected %d buckets, got %d", limit, n)
+		}
+	}
+}
+
+func TestBuckets_Panics(t *testing.T) {
+	key := func(v time.Time) time.Time { return v }
+	for name, fn := range map[string]func(){
+		"nil key":   func() { Buckets[time.Time](nil, nil, time.Hour, false) },
+		"zero size": func() { Buckets(nil, key, 0, false) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
+
+func TestTemporalJoin(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	key := func(v time.Time) time.Time { return v }
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 50 {
+		random := func() []time.Time {
+			s := make([]time.Time, r.IntN(30))
+			for i := range s {
+				s[i] = base.Add(time.Duration(r.IntN(100)) * time.Second)
+			}
+			slices.SortFunc(s, time.Time.Compare)
+			return s
+		}
+		a, b := random(), random()
+		window := time.Duration(r.IntN(10)) * time.Second
+		type pair struct{ a, b time.Time }
+		var expected []pair
+		for _, va := range a {
+			for _, vb := range b {
+				if d := va.Sub(vb); d >= -window && d <= window {
+					expected = append(expected, pair{va, vb})
+				}
+			}
+		}
+		var actual []pair
+		for va, vb := range TemporalJoin(slices.Values(a), slices.Values(b), key, key, window) {
+			actual = append(actual, pair{va, vb})
+		}
+		if !slices.Equal(actual, expected) {
+			t.Errorf("Expected %v, got %v", expected, actual)
+		}
+	}
+}
+
+func TestTemporalJoin_Lazy(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	key := func(v time.Time) time.Time { return v }
+	var read int
+	b := func(yield func(time.Time) bool) {
+		for i := 0; ; i++ {
+			read++
+			if !yield(base.Add(time.Duration(i) * time.Second)) {
+				return
+			}
+		}
+	}
+	a := []time.Time{base.Add(10 * time.Second), base.Add(20 * time.Second)}
+	var matched []time.Time
+	for va, vb := range TemporalJoin(slices.Values(a), b, key, key, 2*time.Second) {
+		if va.Equal(a[1]) {
+			matched = append(matched, vb)
+		}
+	}
+	if len(matched) != 5 {
+		t.Errorf("Expected 5 matches, got %v", matched)
+	}
+	if read != 24 {
+		t.Errorf("Expected 24 elements read, got %d", read)
+	}
+}
+
+func TestTemporalJoin_EarlyTermination(t *testing.T) {
+	s := []time.Time{time.Unix(0, 0), time.Unix(1, 0)}
+	key := func(v time.Time) time.Time { return v }
+	var n int
+	for range TemporalJoin(slices.Values(s), slices.Values(s), key, key, time.Hour) {
+		if n++; n == 3 {
+			break
+		}
+	}
+	if n != 3 {
+		t.Errorf("Expected 3 pairs, got %d", n)
+	}
+}
+
+func TestTemporalJoin_Panics(t *testing.T) {
+	key := func(v time.Time) time.Time { return v }
+	for name, fn := range map[string]func(){
+		"nil key":         func() { TemporalJoin[time.Time, time.Time](nil, nil, key, nil, 0) },
+		"negative window": func() { TemporalJoin(nil, nil, key, key, -1) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
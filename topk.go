@@ -0,0 +1,104 @@
+package kway
+
+import (
+	"iter"
+	"slices"
+)
+
+// KSmallest returns the `k` smallest elements across the provided sorted
+// input sequences, in ascending order. It is equivalent to collecting the
+// first `k` elements of [Merge], and stops pulling from the input sequences
+// as soon as they have been found.
+//
+// See [Merge] for details on the comparison function and stability.
+func KSmallest[T any](cmp func(a, b T) int, k int, seqs ...iter.Seq[T]) []T {
+	if k < 0 {
+		panic("kway: negative k")
+	}
+	merged := Merge(cmp, seqs...)
+	if k == 0 {
+		return nil
+	}
+	result := make([]T, 0, k)
+	for v := range merged {
+		result = append(result, v)
+		if len(result) == k {
+			break
+		}
+	}
+	return result
+}
+
+// KLargest returns the `k` largest elements across the provided sorted
+// (ascending) input sequences, in descending order. The result is the last `k`
+// elements of [Merge], reversed, meaning that elements comparing equal are
+// ordered by descending sequence index.
+//
+// Each input sequence must be consumed entirely, but only the last `k`
+// elements of each are retained. Where the inputs can be iterated in reverse,
+// [KLargestDescending] avoids reading the input sequences in full.
+func KLargest[T any](cmp func(a, b T) int, k int, seqs ...iter.Seq[T]) []T {
+	if k < 0 {
+		panic("kway: negative k")
+	}
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	if k == 0 {
+		return nil
+	}
+	// the tail of each sequence, reversed, with the sequences placed in
+	// reverse order, to prefer higher indexes for equal elements
+	tails := make([]iter.Seq[T], len(seqs))
+	for i, seq := range seqs {
+		if seq == nil {
+			continue
+		}
+		tail := make([]T, 0, k)
+		var n int
+		for v := range seq {
+			if len(tail) < k {
+				tail = append(tail, v)
+			} else {
+				tail[n%k] = v
+			}
+			n++
+		}
+		if len(tail) == k {
+			// rotate the ring buffer, so the oldest element is first
+			off := n % k
+			slices.Reverse(tail[:off])
+			slices.Reverse(tail[off:])
+			slices.Reverse(tail)
+		}
+		tails[len(seqs)-1-i] = func(yield func(T) bool) {
+			for _, v := range slices.Backward(tail) {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+	return KSmallest(reverseCompare(cmp), k, tails...)
+}
+
+// KLargestDescending returns the `k` largest elements across the provided
+// input sequences, in descending order, where each input sequence is sorted
+// in descending order according to `cmp` (e.g. an ascending slice, iterated
+// backwards). Only the elements necessary to determine the result are
+// pulled from the input sequences.
+//
+// Elements comparing equal are ordered by ascending sequence index, exactly
+// as [Merge] would order them, given the reversed comparison function.
+func KLargestDescending[T any](cmp func(a, b T) int, k int, seqs ...iter.Seq[T]) []T {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	return KSmallest(reverseCompare(cmp), k, seqs...)
+}
+
+func reverseCompare[T any](cmp func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int {
+		return cmp(b, a)
+	}
+}
@@ -0,0 +1,155 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestKSmallest(t *testing.T) {
+	tests := []struct {
+		name     string
+		k        int
+		seqs     [][]int
+		expected []int
+	}{
+		{name: "zero k", k: 0, seqs: [][]int{{1, 2}}},
+		{name: "no sequences", k: 3},
+		{name: "fewer than k", k: 5, seqs: [][]int{{1, 4}, {2}}, expected: []int{1, 2, 4}},
+		{name: "exactly k", k: 3, seqs: [][]int{{1, 4}, {2}}, expected: []int{1, 2, 4}},
+		{name: "more than k", k: 3, seqs: [][]int{{1, 4, 7}, {2, 5}, {0, 9}}, expected: []int{0, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seqs []iter.Seq[int]
+			for _, s := range tt.seqs {
+				seqs = append(seqs, sliceSeq(s))
+			}
+			if result := KSmallest(cmp.Compare[int], tt.k, seqs...); !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestKSmallest_StopsEarly(t *testing.T) {
+	var pulled int
+	seq := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	result := KSmallest(cmp.Compare[int], 3, seq)
+	if !slices.Equal(result, []int{0, 1, 2}) {
+		t.Errorf("Expected [0 1 2], got %v", result)
+	}
+	if pulled > 4 {
+		t.Errorf("Expected at most 4 pulls, got %d", pulled)
+	}
+}
+
+func TestKSmallest_NegativeK(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for negative k")
+		} else if !strings.Contains(r.(string), "negative k") {
+			t.Errorf("Expected panic message about negative k, got: %v", r)
+		}
+	}()
+	_ = KSmallest(cmp.Compare[int], -1)
+}
+
+func TestKLargest(t *testing.T) {
+	tests := []struct {
+		name     string
+		k        int
+		seqs     [][]int
+		expected []int
+	}{
+		{name: "zero k", k: 0, seqs: [][]int{{1, 2}}},
+		{name: "no sequences", k: 3},
+		{name: "fewer than k", k: 5, seqs: [][]int{{1, 4}, {2}}, expected: []int{4, 2, 1}},
+		{name: "more than k", k: 3, seqs: [][]int{{1, 4, 7, 8}, {2, 5}, {0, 9}}, expected: []int{9, 8, 7}},
+		{name: "ring wraps", k: 3, seqs: [][]int{{1, 2, 3, 4, 5, 6, 7}, {}}, expected: []int{7, 6, 5}},
+		{name: "spread over sources", k: 4, seqs: [][]int{{1, 10}, {5, 11}, {2, 3, 12}}, expected: []int{12, 11, 10, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seqs []iter.Seq[int]
+			for _, s := range tt.seqs {
+				seqs = append(seqs, sliceSeq(s))
+			}
+			if result := KLargest(cmp.Compare[int], tt.k, seqs...); !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestKLargest_Stability(t *testing.T) {
+	type stableValue struct {
+		value int
+		seqID int
+	}
+
+	cmpFunc := func(a, b stableValue) int {
+		return cmp.Compare(a.value, b.value)
+	}
+
+	seq1 := sliceSeq([]stableValue{{1, 1}, {2, 1}, {3, 1}})
+	seq2 := sliceSeq([]stableValue{{2, 2}, {3, 2}})
+	seq3 := sliceSeq([]stableValue{{3, 3}})
+
+	merged := collectSeq(Merge(cmpFunc, seq1, nil, seq2, seq3))
+	slices.Reverse(merged)
+
+	result := KLargest(cmpFunc, 5, seq1, nil, seq2, seq3)
+	if !slices.Equal(result, merged[:5]) {
+		t.Errorf("Expected %v, got %v", merged[:5], result)
+	}
+}
+
+func TestKLargestDescending(t *testing.T) {
+	var pulled int
+	descending := func(yield func(int) bool) {
+		for i := 100; i >= 0; i -= 2 {
+			pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	result := KLargestDescending(cmp.Compare[int], 4, descending, slices.Values([]int{99, 3}))
+	if !slices.Equal(result, []int{100, 99, 98, 96}) {
+		t.Errorf("Expected [100 99 98 96], got %v", result)
+	}
+	if pulled > 5 {
+		t.Errorf("Expected at most 5 pulls, got %d", pulled)
+	}
+}
+
+func TestKLargest_NilCompareFunction(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"KLargest":           func() { _ = KLargest[int](nil, 1) },
+		"KLargestDescending": func() { _ = KLargestDescending[int](nil, 1) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("Expected panic for nil comparison function")
+				} else if !strings.Contains(r.(string), "nil comparison function") {
+					t.Errorf("Expected panic message about nil comparison function, got: %v", r)
+				}
+			}()
+			fn()
+		})
+	}
+}
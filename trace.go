@@ -0,0 +1,99 @@
+package kway
+
+import (
+	"fmt"
+	"iter"
+)
+
+// TraceOp identifies the kind of a [TraceEvent].
+type TraceOp int
+
+const (
+	// TraceRefill indicates that an element was pulled from Source.
+	TraceRefill TraceOp = iota + 1
+	// TraceExhausted indicates that Source ended, i.e. had no more elements.
+	TraceExhausted
+	// TraceCompare indicates that the current element of Source was compared
+	// to that of Other, with the comparison function returning Result. Ties
+	// between sources are resolved by the engine, after the comparison.
+	TraceCompare
+	// TracePop indicates that the current element of Source was removed from
+	// the engine, to be emitted.
+	TracePop
+)
+
+func (x TraceOp) String() string {
+	switch x {
+	case TraceRefill:
+		return "refill"
+	case TraceExhausted:
+		return "exhausted"
+	case TraceCompare:
+		return "compare"
+	case TracePop:
+		return "pop"
+	}
+	return fmt.Sprintf("TraceOp(%d)", int(x))
+}
+
+// TraceEvent is an operation performed by the merge engine, see [WithTrace].
+type TraceEvent struct {
+	Op TraceOp
+	// Source is the index of the input sequence the operation relates to.
+	Source int
+	// Other is the index of the other input sequence, for TraceCompare.
+	Other int
+	// Result is the result of the comparison, for TraceCompare.
+	Result int
+}
+
+func (x TraceEvent) String() string {
+	if x.Op == TraceCompare {
+		return fmt.Sprintf("%s %d %d = %d", x.Op, x.Source, x.Other, x.Result)
+	}
+	return fmt.Sprintf("%s %d", x.Op, x.Source)
+}
+
+// WithTrace configures a sink, which is called with each operation performed
+// by the merge engine, in order, from the goroutine iterating the merged
+// sequence. Tracing has significant overhead, and is intended for diagnosing
+// the behavior of merges.
+func WithTrace(sink func(event TraceEvent)) Option {
+	if sink == nil {
+		panic("kway: nil trace sink")
+	}
+	return func(o *options) {
+		o.trace = sink
+	}
+}
+
+func traceNext[E any](sink func(event TraceEvent), source int, next func() (E, bool)) func() (E, bool) {
+	return func() (E, bool) {
+		v, ok := next()
+		if ok {
+			sink(TraceEvent{Op: TraceRefill, Source: source})
+		} else {
+			sink(TraceEvent{Op: TraceExhausted, Source: source})
+		}
+		return v, ok
+	}
+}
+
+func traceCompare[E wrappedValue](sink func(event TraceEvent), cmp func(a, b E) int) func(a, b E) int {
+	return func(a, b E) int {
+		v := cmp(a, b)
+		sink(TraceEvent{Op: TraceCompare, Source: a.index(), Other: b.index(), Result: v})
+		return v
+	}
+}
+
+func tracePop[E wrappedValue](sink func(event TraceEvent), seq iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for v := range seq {
+			sink(TraceEvent{Op: TracePop, Source: v.index()})
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
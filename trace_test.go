@@ -0,0 +1,72 @@
+package kway
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestTraceOp_String(t *testing.T) {
+	for op, expected := range map[TraceOp]string{
+		TraceRefill:    "refill",
+		TraceExhausted: "exhausted",
+		TraceCompare:   "compare",
+		TracePop:       "pop",
+		TraceOp(99):    "TraceOp(99)",
+	} {
+		if s := op.String(); s != expected {
+			t.Errorf("Expected %q, got %q", expected, s)
+		}
+	}
+}
+
+func TestTraceEvent_String(t *testing.T) {
+	if s := (TraceEvent{Op: TraceCompare, Source: 1, Other: 2, Result: -1}).String(); s != "compare 1 2 = -1" {
+		t.Errorf("Unexpected string: %q", s)
+	}
+	if s := (TraceEvent{Op: TracePop, Source: 3}).String(); s != "pop 3" {
+		t.Errorf("Unexpected string: %q", s)
+	}
+}
+
+func TestWithTrace(t *testing.T) {
+	var events []TraceEvent
+	m := NewMerger(cmp.Compare[int], WithTrace(func(event TraceEvent) {
+		events = append(events, event)
+	}))
+
+	result := collectSeq(m.Merge(sliceSeq([]int{1}), sliceSeq([]int{2})))
+	if !slices.Equal(result, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", result)
+	}
+
+	var ops []TraceOp
+	var pops []int
+	for _, event := range events {
+		ops = append(ops, event.Op)
+		if event.Op == TracePop {
+			pops = append(pops, event.Source)
+		}
+	}
+	if ops[0] != TraceRefill || ops[1] != TraceRefill {
+		t.Errorf("Expected initial refills, got %v", events)
+	}
+	if !slices.Equal(pops, []int{0, 1}) {
+		t.Errorf("Expected pops from sources [0 1], got %v", events)
+	}
+	if !slices.Contains(ops, TraceCompare) || !slices.Contains(ops, TraceExhausted) {
+		t.Errorf("Expected compare and exhausted events, got %v", events)
+	}
+	if events[len(events)-1] != (TraceEvent{Op: TraceExhausted, Source: 1}) {
+		t.Errorf("Expected the last event to be source 1 exhausted, got %v", events)
+	}
+}
+
+func TestWithTrace_Nil(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for nil sink")
+		}
+	}()
+	_ = WithTrace(nil)
+}
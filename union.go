@@ -0,0 +1,74 @@
+package kway
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// SourceSet is a set of input sequence indexes, as a bitset, in which index
+// i is present if bit i%64 of element i/64 is set. See [UnionSources].
+type SourceSet []uint64
+
+// Has returns true if index `i` is in the set.
+func (x SourceSet) Has(i int) bool {
+	return i >= 0 && i/64 < len(x) && x[i/64]&(1<<(i%64)) != 0
+}
+
+// Len returns the number of indexes in the set.
+func (x SourceSet) Len() (n int) {
+	for _, w := range x {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// All returns the indexes in the set, in ascending order.
+func (x SourceSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, w := range x {
+			for w != 0 {
+				j := bits.TrailingZeros64(w)
+				if !yield(i*64 + j) {
+					return
+				}
+				w &^= 1 << j
+			}
+		}
+	}
+}
+
+// UnionSources performs a k-way merge of the provided sorted input
+// sequences, yielding each distinct element once, per `cmp`, with the set of
+// indexes of the sequences containing it, e.g. to find the elements present
+// in sequences 0 and 2, but not 1, in a single pass. The yielded element is
+// the first of those that compare equal, per [Merge].
+//
+// For up to 64 sequences, the set has a single element, usable directly as
+// a bitmask. The set is reused, and must be cloned (e.g. via [slices.Clone])
+// to be retained beyond each iteration.
+func UnionSources[T any](cmp func(a, b T) int, seqs ...iter.Seq[T]) iter.Seq2[T, SourceSet] {
+	merged := MergeIndexed(cmp, seqs...)
+	return func(yield func(T, SourceSet) bool) {
+		var (
+			prev T
+			ok   bool
+		)
+		set := make(SourceSet, (len(seqs)+63)/64)
+		for i, v := range merged {
+			if ok && cmp(prev, v) != 0 {
+				if !yield(prev, set) {
+					return
+				}
+				clear(set)
+				ok = false
+			}
+			if !ok {
+				prev, ok = v, true
+			}
+			set[i/64] |= 1 << (i % 64)
+		}
+		if ok {
+			yield(prev, set)
+		}
+	}
+}
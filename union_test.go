@@ -0,0 +1,136 @@
+package kway
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestUnionSources(t *testing.T) {
+	type entry struct {
+		value   int
+		sources []int
+	}
+	tests := []struct {
+		name     string
+		input    [][]int
+		expected []entry
+	}{
+		{
+			name:     "no sequences",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:     "single sequence",
+			input:    [][]int{{1, 1, 2}},
+			expected: []entry{{1, []int{0}}, {2, []int{0}}},
+		},
+		{
+			name:  "overlapping",
+			input: [][]int{{1, 2, 4}, {2, 3, 4}, {4, 5}},
+			expected: []entry{
+				{1, []int{0}},
+				{2, []int{0, 1}},
+				{3, []int{1}},
+				{4, []int{0, 1, 2}},
+				{5, []int{2}},
+			},
+		},
+		{
+			name:     "nil sequence",
+			input:    [][]int{{1}, nil, {1}},
+			expected: []entry{{1, []int{0, 2}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seqs []iter.Seq[int]
+			for _, s := range tt.input {
+				if s != nil {
+					seqs = append(seqs, slices.Values(s))
+				} else {
+					seqs = append(seqs, nil)
+				}
+			}
+			var result []entry
+			for v, set := range UnionSources(cmp.Compare[int], seqs...) {
+				if n := set.Len(); n == 0 {
+					t.Errorf("Expected non-empty set for %d", v)
+				}
+				result = append(result, entry{v, slices.Collect(set.All())})
+			}
+			if !slices.EqualFunc(result, tt.expected, func(a, b entry) bool {
+				return a.value == b.value && slices.Equal(a.sources, b.sources)
+			}) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestUnionSources_ManySources(t *testing.T) {
+	seqs := make([]iter.Seq[int], 130)
+	for i := range seqs {
+		if i%3 == 0 {
+			seqs[i] = slices.Values([]int{1})
+		} else {
+			seqs[i] = slices.Values([]int{2})
+		}
+	}
+	for v, set := range UnionSources(cmp.Compare[int], seqs...) {
+		if len(set) != 3 {
+			t.Errorf("Expected 3 words, got %d", len(set))
+		}
+		for i := range seqs {
+			if set.Has(i) != ((i%3 == 0) == (v == 1)) {
+				t.Errorf("Value %d: unexpected membership of %d", v, i)
+			}
+		}
+		if set.Has(-1) || set.Has(len(set)*64) {
+			t.Errorf("Expected out of range indexes to be absent")
+		}
+	}
+}
+
+func TestUnionSources_Bitmask(t *testing.T) {
+	a, b, c := slices.Values([]int{1, 2, 3}), slices.Values([]int{2, 3}), slices.Values([]int{1, 3})
+	var result []int
+	for v, set := range UnionSources(cmp.Compare[int], a, b, c) {
+		// present in a and c, but not b
+		if set[0] == 0b101 {
+			result = append(result, v)
+		}
+	}
+	if !slices.Equal(result, []int{1}) {
+		t.Errorf("Expected [1], got %v", result)
+	}
+}
+
+func TestUnionSources_EarlyExit(t *testing.T) {
+	var result []int
+	for v := range UnionSources(cmp.Compare[int], slices.Values([]int{1, 2, 3}), slices.Values([]int{1, 3})) {
+		result = append(result, v)
+		if len(result) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(result, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", result)
+	}
+}
+
+func TestSourceSet_All_EarlyExit(t *testing.T) {
+	set := SourceSet{0b110, 1}
+	if result := slices.Collect(set.All()); !slices.Equal(result, []int{1, 2, 64}) {
+		t.Errorf("Expected [1 2 64], got %v", result)
+	}
+	for i := range set.All() {
+		if i != 1 {
+			t.Errorf("Expected 1, got %d", i)
+		}
+		break
+	}
+}
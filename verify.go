@@ -0,0 +1,198 @@
+package kway
+
+import (
+	"fmt"
+	"iter"
+)
+
+// OrderError indicates that an input sequence was not sorted, according to
+// the comparison function.
+type OrderError struct {
+	// Source is the index of the offending input sequence, or -1 if the
+	// error was not raised by a merge (e.g. by [AssertSorted]).
+	Source int
+	// Position is the zero-based position of Next, within the source.
+	Position int
+	// Prev and Next are the offending pair of consecutive elements, where
+	// Next compared less than Prev. For sequences of pairs ([iter.Seq2]),
+	// each is a [2]any, holding both values.
+	Prev, Next any
+}
+
+func (e *OrderError) Error() string {
+	var source string
+	if e.Source >= 0 {
+		source = fmt.Sprintf("source %d", e.Source)
+	} else {
+		source = "sequence"
+	}
+	return fmt.Sprintf("kway: %s is not sorted: element %d (%v) is less than element %d (%v)",
+		source, e.Position, e.Next, e.Position-1, e.Prev)
+}
+
+// DuplicateKeyError indicates that elements comparing equal originated from
+// multiple input sequences, as detected by [WithUniqueKeys].
+type DuplicateKeyError struct {
+	// Key is the duplicated element (or key).
+	Key any
+	// First and Second are the indexes of the input sequences containing the
+	// key, in merge order.
+	First, Second int
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("kway: duplicate key %v in sources %d and %d", e.Key, e.First, e.Second)
+}
+
+// AssertSorted returns a sequence that yields the elements of `seq`
+// unchanged, panicking with an [*OrderError] if an element compares less
+// than its predecessor, before yielding it.
+//
+// See [Merge] for details on the comparison function.
+func AssertSorted[T any](cmp func(a, b T) int, seq iter.Seq[T]) iter.Seq[T] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	return verifySeq(cmp, -1, seq, func(err *OrderError) { panic(err) })
+}
+
+// AssertSorted2 is the [iter.Seq2] equivalent of [AssertSorted].
+func AssertSorted2[T1 any, T2 any](cmp func(a1 T1, a2 T2, b1 T1, b2 T2) int, seq iter.Seq2[T1, T2]) iter.Seq2[T1, T2] {
+	if cmp == nil {
+		panic("kway: nil comparison function")
+	}
+	return verifySeq2(cmp, -1, seq, func(err *OrderError) { panic(err) })
+}
+
+// verifySeq wraps seq, calling fail then stopping, if an element compares
+// less than its predecessor.
+func verifySeq[T any](cmp func(a, b T) int, source int, seq iter.Seq[T], fail func(err *OrderError)) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var (
+			prev T
+			n    int
+		)
+		for v := range seq {
+			if n != 0 && cmp(prev, v) > 0 {
+				fail(&OrderError{
+					Source:   source,
+					Position: n,
+					Prev:     prev,
+					Next:     v,
+				})
+				return
+			}
+			if !yield(v) {
+				return
+			}
+			prev = v
+			n++
+		}
+	}
+}
+
+// verifySeq2 is the [iter.Seq2] equivalent of verifySeq.
+func verifySeq2[T1 any, T2 any](cmp func(a1 T1, a2 T2, b1 T1, b2 T2) int, source int, seq iter.Seq2[T1, T2], fail func(err *OrderError)) iter.Seq2[T1, T2] {
+	return func(yield func(T1, T2) bool) {
+		var (
+			prev1 T1
+			prev2 T2
+			n     int
+		)
+		for v1, v2 := range seq {
+			if n != 0 && cmp(prev1, prev2, v1, v2) > 0 {
+				fail(&OrderError{
+					Source:   source,
+					Position: n,
+					Prev:     [2]any{prev1, prev2},
+					Next:     [2]any{v1, v2},
+				})
+				return
+			}
+			if !yield(v1, v2) {
+				return
+			}
+			prev1, prev2 = v1, v2
+			n++
+		}
+	}
+}
+
+// verifyNext is the pull equivalent of verifySeq, returning false after
+// calling fail.
+func verifyNext[T any](cmp func(a, b T) int, source int, next func() (T, bool), fail func(err *OrderError)) func() (T, bool) {
+	var (
+		prev T
+		n    int
+	)
+	return func() (T, bool) {
+		v, ok := next()
+		if !ok {
+			return v, false
+		}
+		if n != 0 && cmp(prev, v) > 0 {
+			fail(&OrderError{
+				Source:   source,
+				Position: n,
+				Prev:     prev,
+				Next:     v,
+			})
+			return *new(T), false
+		}
+		prev = v
+		n++
+		return v, true
+	}
+}
+
+// verifyNext2 is the pull equivalent of verifySeq2, see verifyNext.
+func verifyNext2[T1 any, T2 any](cmp func(a1 T1, a2 T2, b1 T1, b2 T2) int, source int, next func() (T1, T2, bool), fail func(err *OrderError)) func() (T1, T2, bool) {
+	var (
+		prev1 T1
+		prev2 T2
+		n     int
+	)
+	return func() (T1, T2, bool) {
+		v1, v2, ok := next()
+		if !ok {
+			return v1, v2, false
+		}
+		if n != 0 && cmp(prev1, prev2, v1, v2) > 0 {
+			fail(&OrderError{
+				Source:   source,
+				Position: n,
+				Prev:     [2]any{prev1, prev2},
+				Next:     [2]any{v1, v2},
+			})
+			return *new(T1), *new(T2), false
+		}
+		prev1, prev2 = v1, v2
+		n++
+		return v1, v2, true
+	}
+}
+
+// checkUnique wraps the output of a merge, calling fail then stopping, if
+// consecutive elements are equal, but from different sources.
+func checkUnique[E wrappedValue](seq iter.Seq[E], equal func(a, b E) bool, key func(v E) any, fail func(err error)) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		var (
+			prev E
+			ok   bool
+		)
+		for v := range seq {
+			if ok && prev.index() != v.index() && equal(prev, v) {
+				fail(&DuplicateKeyError{
+					Key:    key(v),
+					First:  prev.index(),
+					Second: v.index(),
+				})
+				return
+			}
+			if !yield(v) {
+				return
+			}
+			prev, ok = v, true
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package kway
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestOrderError_Error(t *testing.T) {
+	err := &OrderError{Source: 2, Position: 5, Prev: 10, Next: 3}
+	expected := "kway: source 2 is not sorted: element 5 (3) is less than element 4 (10)"
+	if s := err.Error(); s != expected {
+		t.Errorf("Expected %q, got %q", expected, s)
+	}
+
+	err = &OrderError{Source: -1, Position: 1, Prev: "b", Next: "a"}
+	expected = "kway: sequence is not sorted: element 1 (a) is less than element 0 (b)"
+	if s := err.Error(); s != expected {
+		t.Errorf("Expected %q, got %q", expected, s)
+	}
+}
+
+func TestVerifySeq(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []int
+		expected []int
+		err      *OrderError
+	}{
+		{name: "empty"},
+		{name: "sorted", input: []int{1, 2, 2, 3}, expected: []int{1, 2, 2, 3}},
+		{
+			name:     "unsorted",
+			input:    []int{1, 3, 2, 4},
+			expected: []int{1, 3},
+			err:      &OrderError{Source: 7, Position: 2, Prev: 3, Next: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err *OrderError
+			result := collectSeq(verifySeq(cmp.Compare[int], 7, sliceSeq(tt.input), func(e *OrderError) {
+				if err != nil {
+					t.Error("Expected fail to be called at most once")
+				}
+				err = e
+			}))
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+			if (err == nil) != (tt.err == nil) || (err != nil && *err != *tt.err) {
+				t.Errorf("Expected error %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestWithVerifySorted(t *testing.T) {
+	m := NewMerger(cmp.Compare[int], WithVerifySorted())
+
+	result := collectSeq(m.Merge(sliceSeq([]int{1, 3}), sliceSeq([]int{2, 2, 4})))
+	if !slices.Equal(result, []int{1, 2, 2, 3, 4}) {
+		t.Errorf("Expected [1 2 2 3 4], got %v", result)
+	}
+
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		for range m.Merge(sliceSeq([]int{1, 3}), sliceSeq([]int{2, 5, 4})) {
+		}
+	}()
+
+	err, _ := recovered.(error)
+	var orderErr *OrderError
+	if !errors.As(err, &orderErr) {
+		t.Fatalf("Expected panic with *OrderError, got %v", recovered)
+	}
+	if *orderErr != (OrderError{Source: 1, Position: 2, Prev: 5, Next: 4}) {
+		t.Errorf("Unexpected error: %+v", orderErr)
+	}
+}
+
+func TestAssertSorted(t *testing.T) {
+	result := collectSeq(AssertSorted(cmp.Compare[int], sliceSeq([]int{1, 1, 2, 3})))
+	if !slices.Equal(result, []int{1, 1, 2, 3}) {
+		t.Errorf("Expected [1 1 2 3], got %v", result)
+	}
+
+	result = nil
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		for v := range AssertSorted(cmp.Compare[int], sliceSeq([]int{1, 4, 2, 5})) {
+			result = append(result, v)
+		}
+	}()
+	if !slices.Equal(result, []int{1, 4}) {
+		t.Errorf("Expected [1 4] before the violation, got %v", result)
+	}
+	orderErr, ok := recovered.(*OrderError)
+	if !ok {
+		t.Fatalf("Expected panic with *OrderError, got %v", recovered)
+	}
+	if *orderErr != (OrderError{Source: -1, Position: 2, Prev: 4, Next: 2}) {
+		t.Errorf("Unexpected error: %+v", orderErr)
+	}
+}
+
+func TestAssertSorted2(t *testing.T) {
+	cmpFunc := func(a1 int, a2 string, b1 int, b2 string) int {
+		return cmp.Compare(a1, b1)
+	}
+
+	r1, r2 := collectSeq2(AssertSorted2(cmpFunc, sliceSeq2([]int{1, 2}, []string{"a", "b"})))
+	if !slices.Equal(r1, []int{1, 2}) || !slices.Equal(r2, []string{"a", "b"}) {
+		t.Errorf("Unexpected result: %v, %v", r1, r2)
+	}
+
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		for range AssertSorted2(cmpFunc, sliceSeq2([]int{1, 3, 2}, []string{"a", "c", "b"})) {
+		}
+	}()
+	orderErr, ok := recovered.(*OrderError)
+	if !ok {
+		t.Fatalf("Expected panic with *OrderError, got %v", recovered)
+	}
+	if *orderErr != (OrderError{Source: -1, Position: 2, Prev: [2]any{3, "c"}, Next: [2]any{2, "b"}}) {
+		t.Errorf("Unexpected error: %+v", orderErr)
+	}
+}
+
+func TestAssertSorted_NilCompareFunction(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"AssertSorted":  func() { _ = AssertSorted[int](nil, nil) },
+		"AssertSorted2": func() { _ = AssertSorted2[int, int](nil, nil) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("Expected panic for nil comparison function")
+				}
+			}()
+			fn()
+		})
+	}
+}
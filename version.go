@@ -0,0 +1,164 @@
+package kway
+
+import (
+	"cmp"
+	"strings"
+)
+
+// CompareSemver is a comparison function for semantic versions, e.g.
+// "1.2.3", "v1.10.0-rc.1", ordered by precedence, per the Semantic
+// Versioning 2.0.0 specification. An optional "v" prefix is permitted.
+// Pre-release versions precede the associated release, and their
+// identifiers are compared numerically, if they consist of digits, or
+// lexically, otherwise, with numeric identifiers preceding others.
+//
+// Build metadata, following "+", does not affect precedence, so versions that
+// differ only in build metadata compare equal, leaving their relative order
+// to the stability of the merge. Strings that are not valid semantic
+// versions order after all valid versions, per [strings.Compare].
+func CompareSemver(a, b string) int {
+	va, okA := parseSemver(a)
+	vb, okB := parseSemver(b)
+	switch {
+	case !okA && !okB:
+		return strings.Compare(a, b)
+	case !okA:
+		return 1
+	case !okB:
+		return -1
+	}
+	for i := range va.core {
+		if v := compareNumeric(va.core[i], vb.core[i]); v != 0 {
+			return v
+		}
+	}
+	switch {
+	case va.pre == "" && vb.pre == "":
+		return 0
+	case va.pre == "":
+		return 1
+	case vb.pre == "":
+		return -1
+	}
+	pa, pb := va.pre, vb.pre
+	for pa != "" && pb != "" {
+		var ia, ib string
+		ia, pa, _ = strings.Cut(pa, ".")
+		ib, pb, _ = strings.Cut(pb, ".")
+		na, nb := isNumeric(ia), isNumeric(ib)
+		switch {
+		case na && nb:
+			if v := compareNumeric(ia, ib); v != 0 {
+				return v
+			}
+		case na:
+			return -1
+		case nb:
+			return 1
+		default:
+			if v := strings.Compare(ia, ib); v != 0 {
+				return v
+			}
+		}
+	}
+	return cmp.Compare(len(pa), len(pb))
+}
+
+type semver struct {
+	core [3]string
+	pre  string
+}
+
+// parseSemver parses s, without allocating, reporting whether it is valid.
+func parseSemver(s string) (v semver, ok bool) {
+	s = strings.TrimPrefix(s, "v")
+	s, _, _ = strings.Cut(s, "+")
+	s, v.pre, ok = strings.Cut(s, "-")
+	if ok && !validIdentifiers(v.pre) {
+		return v, false
+	}
+	for i := range v.core {
+		var part string
+		if i == len(v.core)-1 {
+			part, s = s, ""
+		} else if part, s, ok = strings.Cut(s, "."); !ok {
+			return v, false
+		}
+		if !isNumeric(part) || (len(part) > 1 && part[0] == '0') {
+			return v, false
+		}
+		v.core[i] = part
+	}
+	return v, true
+}
+
+// validIdentifiers reports whether s is a valid sequence of dot-separated
+// pre-release identifiers.
+func validIdentifiers(s string) bool {
+	for id := range strings.SplitSeq(s, ".") {
+		if id == "" || (len(id) > 1 && id[0] == '0' && isNumeric(id)) {
+			return false
+		}
+		for i := 0; i < len(id); i++ {
+			if c := id[i]; !isDigit(c) && c != '-' && !('a' <= c && c <= 'z') && !('A' <= c && c <= 'Z') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CompareDottedVersion is a comparison function for simple dotted versions,
+// e.g. "1.2.10", "2024.06", comparing dot-separated components in turn,
+// using [CompareNatural], such that numeric components are compared
+// numerically, and missing trailing components are treated as "0". Versions
+// that are otherwise equal, e.g. "1.2" and "1.2.0", are ordered by
+// [strings.Compare].
+func CompareDottedVersion(a, b string) int {
+	sa, sb := a, b
+	for sa != "" || sb != "" {
+		var ca, cb string
+		ca, sa, _ = strings.Cut(sa, ".")
+		cb, sb, _ = strings.Cut(sb, ".")
+		if ca == "" {
+			ca = "0"
+		}
+		if cb == "" {
+			cb = "0"
+		}
+		if v := compareComponent(ca, cb); v != 0 {
+			return v
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// compareComponent compares version components, per [CompareNatural],
+// ignoring differences only in leading zeros.
+func compareComponent(a, b string) int {
+	if isNumeric(a) && isNumeric(b) {
+		return compareNumeric(a, b)
+	}
+	return CompareNatural(a, b)
+}
+
+// compareNumeric compares strings of digits, of any length, numerically.
+func compareNumeric(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if v := cmp.Compare(len(a), len(b)); v != 0 {
+		return v
+	}
+	return strings.Compare(a, b)
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
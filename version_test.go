@@ -0,0 +1,100 @@
+package kway
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompareSemver(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"v1.0.0", "1.0.0", 0},
+		{"1.0.0", "2.0.0", -1},
+		{"1.9.0", "1.10.0", -1},
+		{"1.0.9", "1.0.10", -1},
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-alpha.beta", "1.0.0-beta", -1},
+		{"1.0.0-beta", "1.0.0-beta.2", -1},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"1.0.0-beta.11", "1.0.0-rc.1", -1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0-x-y", "1.0.0-x-z", -1},
+		{"1.0.0+build.1", "1.0.0+build.2", 0},
+		{"1.0.0-rc.1+build", "1.0.0-rc.1", 0},
+		{"99999999999999999999.0.0", "100000000000000000000.0.0", -1},
+		{"1.0.0", "1.0", -1},
+		{"1.0", "1.0.x", -1},
+		{"1.0.0", "01.0.0", -1},
+		{"1.0.0", "1.0.0-", -1},
+		{"1.0.0", "1.0.0-a..b", -1},
+		{"1.0.0", "1.0.0-01", -1},
+		{"1.0.0", "1.0.0-a_b", -1},
+		{"1.0.0-0", "1.0.0-00a", -1},
+	}
+
+	for _, tt := range tests {
+		if v := CompareSemver(tt.a, tt.b); v != tt.expected {
+			t.Errorf("CompareSemver(%q, %q) = %d, want %d", tt.a, tt.b, v, tt.expected)
+		}
+		if v := CompareSemver(tt.b, tt.a); v != -tt.expected {
+			t.Errorf("CompareSemver(%q, %q) = %d, want %d", tt.b, tt.a, v, -tt.expected)
+		}
+	}
+}
+
+func TestCompareSemver_Merge(t *testing.T) {
+	result := collectSeq(Merge(CompareSemver,
+		sliceSeq([]string{"v1.2.0", "v1.10.0-rc.1", "v1.10.0"}),
+		sliceSeq([]string{"v1.9.0", "v1.10.0-beta.2", "v1.10.0-beta.10"}),
+	))
+	expected := []string{"v1.2.0", "v1.9.0", "v1.10.0-beta.2", "v1.10.0-beta.10", "v1.10.0-rc.1", "v1.10.0"}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestCompareDottedVersion(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"1", "1", 0},
+		{"1.2", "1.10", -1},
+		{"1.2.9", "1.2.10", -1},
+		{"1.2", "1.2.0", -1},
+		{"1.2", "1.2.1", -1},
+		{"1.02", "1.2", -1},
+		{"1.02", "1.3", -1},
+		{"2024.06", "2024.10", -1},
+		{"1.2a", "1.2b", -1},
+		{"1.2rc1", "1.2rc10", -1},
+		{"10.0", "9.9.9", 1},
+		{"1..2", "1.0.2", -1},
+	}
+
+	for _, tt := range tests {
+		if v := CompareDottedVersion(tt.a, tt.b); v != tt.expected {
+			t.Errorf("CompareDottedVersion(%q, %q) = %d, want %d", tt.a, tt.b, v, tt.expected)
+		}
+		if v := CompareDottedVersion(tt.b, tt.a); v != -tt.expected {
+			t.Errorf("CompareDottedVersion(%q, %q) = %d, want %d", tt.b, tt.a, v, -tt.expected)
+		}
+	}
+}
+
+func TestCompareDottedVersion_Merge(t *testing.T) {
+	result := collectSeq(Merge(CompareDottedVersion,
+		sliceSeq([]string{"1.2", "1.10", "2.0"}),
+		sliceSeq([]string{"1.9.1", "1.10.1"}),
+	))
+	expected := []string{"1.2", "1.9.1", "1.10", "1.10.1", "2.0"}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
@@ -0,0 +1,128 @@
+package kway
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+)
+
+// LSN is a log sequence number, as used to order the records of a
+// write-ahead log, optionally qualified by the term (or epoch) of the writer,
+// such that LSNs are ordered by term, then index. Plain LSNs have a term of
+// 0. See [MergeWAL].
+type LSN struct {
+	Term  uint64
+	Index uint64
+}
+
+// Compare returns -1, 0 or +1, as x is less than, equal to, or greater than
+// y, ordering by term, then index.
+func (x LSN) Compare(y LSN) int {
+	if c := cmp.Compare(x.Term, y.Term); c != 0 {
+		return c
+	}
+	return cmp.Compare(x.Index, y.Index)
+}
+
+func (x LSN) String() string {
+	return fmt.Sprintf("%d/%d", x.Term, x.Index)
+}
+
+// GapPolicy determines whether [MergeWAL] permits gaps between the LSNs of
+// consecutive records.
+type GapPolicy int
+
+const (
+	// AllowGaps permits gaps, e.g. for logs that are sparse, or truncated.
+	AllowGaps GapPolicy = iota
+	// RejectGaps stops at the first gap, reporting a [*GapError].
+	RejectGaps
+)
+
+// GapError indicates missing LSNs, between consecutive records of a merged
+// write-ahead log, as detected by [MergeWAL].
+type GapError struct {
+	// Prev and Next are the LSNs of the consecutive records.
+	Prev, Next LSN
+	// Source is the index of the segment containing Next.
+	Source int
+}
+
+func (e *GapError) Error() string {
+	return fmt.Sprintf("kway: gap in log sequence, from %v to %v in source %d", e.Prev, e.Next, e.Source)
+}
+
+// MergeWAL performs a k-way merge of the provided write-ahead log segments,
+// each sorted by the LSN of its records, per `lsn`, e.g. for recovery of a
+// storage engine, from overlapping or replicated segments. Each segment is
+// verified to be sorted, per [Merger.MergeChecked].
+//
+// Records with the same LSN, whether from the same or different segments,
+// are duplicates, handled per `policy`, as for [Merge2Dedup]:
+// [RejectDuplicates] reports a [*DuplicateKeyError], with the LSN as its key.
+// Gaps, where the index of a record is not one greater than that of its
+// predecessor, with the same term, are handled per `gaps`. A change of term
+// is not a gap, as some logs number each term from the start.
+//
+// Records are yielded with a nil error, until a violation is detected, at
+// which point the zero value is yielded with the error, and iteration stops.
+func MergeWAL[T any](lsn func(v T) LSN, policy DuplicatePolicy, gaps GapPolicy, segments ...iter.Seq[T]) iter.Seq2[T, error] {
+	if lsn == nil {
+		panic("kway: nil LSN function")
+	}
+	if policy < KeepFirst || policy > RejectDuplicates {
+		panic("kway: invalid duplicate policy")
+	}
+	if gaps < AllowGaps || gaps > RejectGaps {
+		panic("kway: invalid gap policy")
+	}
+	if !anySeq(segments) {
+		return emptySeq2[T, error]
+	}
+	m := NewMerger(func(a, b T) int { return lsn(a).Compare(lsn(b)) })
+	return func(yield func(T, error) bool) {
+		var err error
+		fail := func(e error) {
+			if err == nil {
+				err = e
+			}
+		}
+		var (
+			prev    *wrappedSeqValue[T]
+			prevLSN LSN
+		)
+		for v := range m.merge(seqSources(segments), true, fail) {
+			l := lsn(v.v)
+			if prev != nil {
+				if l == prevLSN {
+					if policy == RejectDuplicates {
+						err = &DuplicateKeyError{Key: l, First: prev.i, Second: v.i}
+						break
+					}
+					if policy == KeepLast {
+						prev = v
+					}
+					continue
+				}
+				if gaps == RejectGaps && l.Term == prevLSN.Term && l.Index != prevLSN.Index+1 {
+					err = &GapError{Prev: prevLSN, Next: l, Source: v.i}
+					break
+				}
+				// KeepLast must wait for the end of each run
+				if policy == KeepLast && !yield(prev.v, nil) {
+					return
+				}
+			}
+			if policy != KeepLast && !yield(v.v, nil) {
+				return
+			}
+			prev, prevLSN = v, l
+		}
+		if prev != nil && policy == KeepLast && !yield(prev.v, nil) {
+			return
+		}
+		if err != nil {
+			yield(*new(T), err)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package kway
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+)
+
+type walRecord struct {
+	lsn  LSN
+	data string
+}
+
+func walLSN(r walRecord) LSN { return r.lsn }
+
+// collectWAL returns the records and error yielded by seq.
+func collectWAL(seq iter.Seq2[walRecord, error]) (records []walRecord, err error) {
+	for r, e := range seq {
+		if e != nil {
+			if err != nil {
+				panic("multiple errors")
+			}
+			err = e
+			continue
+		}
+		if err != nil {
+			panic("record after error")
+		}
+		records = append(records, r)
+	}
+	return records, err
+}
+
+func TestLSN_Compare(t *testing.T) {
+	tests := []struct {
+		a, b     LSN
+		expected int
+	}{
+		{LSN{0, 1}, LSN{0, 2}, -1},
+		{LSN{1, 1}, LSN{0, 2}, 1},
+		{LSN{1, 2}, LSN{1, 2}, 0},
+		{LSN{1, 3}, LSN{2, 1}, -1},
+	}
+	for _, tt := range tests {
+		if result := tt.a.Compare(tt.b); result != tt.expected {
+			t.Errorf("%v.Compare(%v): Expected %d, got %d", tt.a, tt.b, tt.expected, result)
+		}
+	}
+	if s := (LSN{2, 7}).String(); s != "2/7" {
+		t.Errorf("Expected 2/7, got %s", s)
+	}
+}
+
+func TestMergeWAL(t *testing.T) {
+	segments := [][]walRecord{
+		{{LSN{1, 1}, "a"}, {LSN{1, 2}, "b"}, {LSN{1, 3}, "c0"}},
+		{{LSN{1, 3}, "c1"}, {LSN{1, 4}, "d"}, {LSN{2, 1}, "e"}},
+	}
+	tests := []struct {
+		name     string
+		policy   DuplicatePolicy
+		gaps     GapPolicy
+		input    [][]walRecord
+		expected []string
+		err      error
+	}{
+		{
+			name:     "keep first",
+			policy:   KeepFirst,
+			input:    segments,
+			expected: []string{"a", "b", "c0", "d", "e"},
+		},
+		{
+			name:     "keep last",
+			policy:   KeepLast,
+			gaps:     RejectGaps,
+			input:    segments,
+			expected: []string{"a", "b", "c1", "d", "e"},
+		},
+		{
+			name:     "reject duplicates",
+			policy:   RejectDuplicates,
+			input:    segments,
+			expected: []string{"a", "b", "c0"},
+			err:      &DuplicateKeyError{Key: LSN{1, 3}, First: 0, Second: 1},
+		},
+		{
+			name:     "duplicate within segment",
+			policy:   RejectDuplicates,
+			input:    [][]walRecord{{{LSN{0, 1}, "a"}, {LSN{0, 1}, "b"}}},
+			expected: []string{"a"},
+			err:      &DuplicateKeyError{Key: LSN{0, 1}, First: 0, Second: 0},
+		},
+		{
+			name:     "allow gaps",
+			input:    [][]walRecord{{{LSN{0, 1}, "a"}, {LSN{0, 5}, "b"}}},
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "reject gaps",
+			policy:   KeepLast,
+			gaps:     RejectGaps,
+			input:    [][]walRecord{{{LSN{0, 1}, "a"}, {LSN{0, 2}, "b"}}, {{LSN{0, 2}, "c"}, {LSN{0, 4}, "d"}}},
+			expected: []string{"a", "c"},
+			err:      &GapError{Prev: LSN{0, 2}, Next: LSN{0, 4}, Source: 1},
+		},
+		{
+			name:     "unsorted",
+			input:    [][]walRecord{{{LSN{0, 2}, "a"}, {LSN{0, 1}, "b"}}},
+			expected: []string{"a"},
+			err:      &OrderError{Source: 0, Position: 1, Prev: walRecord{LSN{0, 2}, "a"}, Next: walRecord{LSN{0, 1}, "b"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seqs []iter.Seq[walRecord]
+			for _, s := range tt.input {
+				seqs = append(seqs, slices.Values(s))
+			}
+			records, err := collectWAL(MergeWAL(walLSN, tt.policy, tt.gaps, seqs...))
+			var result []string
+			for _, r := range records {
+				result = append(result, r.data)
+			}
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+			if (err == nil) != (tt.err == nil) || (err != nil && err.Error() != tt.err.Error()) {
+				t.Errorf("Expected error %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestMergeWAL_errorTypes(t *testing.T) {
+	_, err := collectWAL(MergeWAL(walLSN, KeepFirst, RejectGaps, slices.Values([]walRecord{{LSN{3, 1}, "a"}, {LSN{3, 3}, "b"}})))
+	var gapErr *GapError
+	if !errors.As(err, &gapErr) || gapErr.Prev != (LSN{3, 1}) || gapErr.Next != (LSN{3, 3}) {
+		t.Errorf("Expected *GapError, got %v", err)
+	}
+	records, err := collectWAL(MergeWAL(walLSN, KeepFirst, RejectGaps, slices.Values([]walRecord{{LSN{1, 9}, "a"}, {LSN{2, 1}, "b"}})))
+	if err != nil || len(records) != 2 {
+		t.Errorf("Expected a change of term to be permitted, got %v, %v", records, err)
+	}
+}
+
+func TestMergeWAL_earlyExit(t *testing.T) {
+	var n int
+	for range MergeWAL(walLSN, KeepLast, AllowGaps, slices.Values([]walRecord{{LSN{0, 1}, "a"}, {LSN{0, 2}, "b"}, {LSN{0, 3}, "c"}})) {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("Expected 1 record, got %d", n)
+	}
+	if records, err := collectWAL(MergeWAL(walLSN, KeepFirst, AllowGaps)); records != nil || err != nil {
+		t.Errorf("Expected nothing, got %v, %v", records, err)
+	}
+}
+
+func TestMergeWAL_panics(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		f     func()
+		panic string
+	}{
+		{"nil lsn", func() { MergeWAL[walRecord](nil, KeepFirst, AllowGaps) }, "kway: nil LSN function"},
+		{"invalid policy", func() { MergeWAL(walLSN, -1, AllowGaps) }, "kway: invalid duplicate policy"},
+		{"invalid gaps", func() { MergeWAL(walLSN, KeepFirst, 2) }, "kway: invalid gap policy"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tt.panic {
+					t.Errorf("Expected %q, got %v", tt.panic, r)
+				}
+			}()
+			tt.f()
+		})
+	}
+}
@@ -1,7 +1,5 @@
 package kway
 
-import "iter"
-
 type wrappedSeqValue[T any] struct {
 	i int
 	v T
@@ -9,6 +7,8 @@ type wrappedSeqValue[T any] struct {
 
 func (x *wrappedSeqValue[T]) index() int { return x.i }
 
+func (x *wrappedSeqValue[T]) key() any { return x.v }
+
 type wrappedSeq2Value[T1 any, T2 any] struct {
 	i  int
 	v1 T1
@@ -17,23 +17,25 @@ type wrappedSeq2Value[T1 any, T2 any] struct {
 
 func (x *wrappedSeq2Value[T1, T2]) index() int { return x.i }
 
-func wrapSeq[T any](i int, seq iter.Seq[T]) iter.Seq[*wrappedSeqValue[T]] {
-	return func(yield func(*wrappedSeqValue[T]) bool) {
-		for v := range seq {
-			if !yield(&wrappedSeqValue[T]{i, v}) {
-				return
-			}
+func (x *wrappedSeq2Value[T1, T2]) key() any { return x.v1 }
+
+func wrapNext[T any](i int, next func() (T, bool)) func() (*wrappedSeqValue[T], bool) {
+	return func() (*wrappedSeqValue[T], bool) {
+		v, ok := next()
+		if !ok {
+			return nil, false
 		}
+		return &wrappedSeqValue[T]{i: i, v: v}, true
 	}
 }
 
-func wrapSeq2[T1 any, T2 any](i int, seq iter.Seq2[T1, T2]) iter.Seq[*wrappedSeq2Value[T1, T2]] {
-	return func(yield func(*wrappedSeq2Value[T1, T2]) bool) {
-		for v1, v2 := range seq {
-			if !yield(&wrappedSeq2Value[T1, T2]{i, v1, v2}) {
-				return
-			}
+func wrapNext2[T1 any, T2 any](i int, next func() (T1, T2, bool)) func() (*wrappedSeq2Value[T1, T2], bool) {
+	return func() (*wrappedSeq2Value[T1, T2], bool) {
+		v1, v2, ok := next()
+		if !ok {
+			return nil, false
 		}
+		return &wrappedSeq2Value[T1, T2]{i: i, v1: v1, v2: v2}, true
 	}
 }
 
@@ -0,0 +1,97 @@
+package kway
+
+import (
+	"bufio"
+	"io"
+	"iter"
+)
+
+// WithWriteBuffer configures [Merger.MergeTo] to buffer its output, using a
+// buffer of `size` bytes, flushed every `flushEvery` elements, or only when
+// full, and at the end of the merge, if `flushEvery` is 0. By default, each
+// element is encoded directly to the writer.
+func WithWriteBuffer(size int, flushEvery int) Option {
+	if size <= 0 {
+		panic("kway: write buffer size must be positive")
+	}
+	if flushEvery < 0 {
+		panic("kway: negative flush interval")
+	}
+	return func(o *options) {
+		o.writeBuffer = size
+		o.flushEvery = int64(flushEvery)
+	}
+}
+
+// MergeTo performs a k-way merge of the provided sorted input sequences, per
+// [Merger.Merge], writing each element to `w` using `encode`, and returning
+// the number of bytes written to `w`.
+//
+// The merge stops at the first error, which is returned, whether from
+// `encode`, `w`, or a violation detected by options such as
+// [WithVerifySorted], which are reported as errors, rather than panics. Any
+// buffered output, per [WithWriteBuffer], is flushed before returning,
+// including on error.
+func (m *Merger[T]) MergeTo(w io.Writer, encode func(w io.Writer, v T) error, seqs ...iter.Seq[T]) (int64, error) {
+	if encode == nil {
+		panic("kway: nil encode function")
+	}
+	m, _, err := m.bounded(countSeqs(seqs), m.opts.verifySorted, 0)
+	if err != nil {
+		return 0, err
+	}
+	cw := &countingWriter{w: w}
+	var (
+		out io.Writer = cw
+		bw  *bufio.Writer
+	)
+	if m.opts.writeBuffer > 0 {
+		bw = bufio.NewWriterSize(cw, m.opts.writeBuffer)
+		out = bw
+	}
+
+	fail := func(e error) {
+		if err == nil {
+			err = e
+		}
+	}
+	srcs, release := prefetchSources(&m.opts, seqs)
+	defer release()
+	var n int64
+	for v := range m.merge(srcs, m.opts.verifySorted, fail) {
+		if err = encode(out, v.v); err != nil {
+			break
+		}
+		n++
+		if bw != nil && m.opts.flushEvery > 0 && n%m.opts.flushEvery == 0 {
+			if err = bw.Flush(); err != nil {
+				break
+			}
+		}
+	}
+	if bw != nil {
+		if e := bw.Flush(); err == nil {
+			err = e
+		}
+	}
+	return cw.n, err
+}
+
+// MergeTo performs a k-way merge of the provided sorted input sequences,
+// writing each element to `w` using `encode`. It is equivalent to
+// [Merger.MergeTo], using a [Merger] without options.
+func MergeTo[T any](w io.Writer, cmp func(a, b T) int, encode func(w io.Writer, v T) error, seqs ...iter.Seq[T]) (int64, error) {
+	return NewMerger(cmp).MergeTo(w, encode, seqs...)
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (x *countingWriter) Write(p []byte) (int, error) {
+	n, err := x.w.Write(p)
+	x.n += int64(n)
+	return n, err
+}
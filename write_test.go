@@ -0,0 +1,124 @@
+package kway
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func encodeLine(w io.Writer, v int) error {
+	_, err := fmt.Fprintf(w, "%d\n", v)
+	return err
+}
+
+// flushRecorder records the content of each write.
+type flushRecorder struct {
+	writes []string
+}
+
+func (x *flushRecorder) Write(p []byte) (int, error) {
+	x.writes = append(x.writes, string(p))
+	return len(p), nil
+}
+
+func TestMergeTo(t *testing.T) {
+	var b bytes.Buffer
+	n, err := MergeTo(&b, cmp.Compare[int], encodeLine, sliceSeq([]int{1, 10}), nil, sliceSeq([]int{2}))
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if s := b.String(); s != "1\n2\n10\n" {
+		t.Errorf("Expected %q, got %q", "1\n2\n10\n", s)
+	}
+	if n != int64(b.Len()) {
+		t.Errorf("Expected %d, got %d", b.Len(), n)
+	}
+}
+
+func TestMergerMergeTo_WriteBuffer(t *testing.T) {
+	tests := []struct {
+		name       string
+		flushEvery int
+		expected   []string
+	}{
+		{
+			name:       "flush at end",
+			flushEvery: 0,
+			expected:   []string{"1\n2\n3\n4\n5\n"},
+		},
+		{
+			name:       "flush every 2",
+			flushEvery: 2,
+			expected:   []string{"1\n2\n", "3\n4\n", "5\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var w flushRecorder
+			m := NewMerger(cmp.Compare[int], WithWriteBuffer(64, tt.flushEvery))
+			n, err := m.MergeTo(&w, encodeLine, sliceSeq([]int{1, 3, 5}), sliceSeq([]int{2, 4}))
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if n != 10 {
+				t.Errorf("Expected 10, got %d", n)
+			}
+			if strings.Join(w.writes, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("Expected %q, got %q", tt.expected, w.writes)
+			}
+		})
+	}
+}
+
+func TestMergerMergeTo_EncodeError(t *testing.T) {
+	boom := errors.New("boom")
+	var b bytes.Buffer
+	m := NewMerger(cmp.Compare[int], WithWriteBuffer(64, 0))
+	n, err := m.MergeTo(&b, func(w io.Writer, v int) error {
+		if v == 3 {
+			return boom
+		}
+		return encodeLine(w, v)
+	}, sliceSeq([]int{1, 3}), sliceSeq([]int{2, 4}))
+	if !errors.Is(err, boom) {
+		t.Errorf("Expected boom, got %v", err)
+	}
+	if s := b.String(); s != "1\n2\n" || n != 4 {
+		t.Errorf("Expected the output before the error to be flushed, got %q (%d)", s, n)
+	}
+}
+
+func TestMergerMergeTo_VerifySorted(t *testing.T) {
+	var b bytes.Buffer
+	m := NewMerger(cmp.Compare[int], WithVerifySorted())
+	_, err := m.MergeTo(&b, encodeLine, sliceSeq([]int{1, 3, 2}))
+	var orderErr *OrderError
+	if !errors.As(err, &orderErr) {
+		t.Errorf("Expected *OrderError, got %v", err)
+	}
+	if s := b.String(); s != "1\n3\n" {
+		t.Errorf("Expected %q, got %q", "1\n3\n", s)
+	}
+}
+
+func TestWithWriteBuffer_Invalid(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"zero size":      func() { WithWriteBuffer(0, 0) },
+		"negative flush": func() { WithWriteBuffer(1, -1) },
+		"nil encode":     func() { MergeTo[int](io.Discard, cmp.Compare[int], nil) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			fn()
+		})
+	}
+}